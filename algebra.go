@@ -0,0 +1,206 @@
+package set
+
+// Union adds all the elements of the sets a and b to the given destination set, and returns the
+// destination. Unlike [ComparableSet.Union], where the type of the returned set follows the
+// receiver, this lets the caller choose the representation of the result:
+//
+//	union := set.Union(&set.HashSet[int]{}, arraySet, dynamicSet)
+//
+// The destination may be empty, or already contain elements (which are kept). It may also be one of
+// the input sets, to add the elements of the other set to it in place.
+func Union[E comparable, S Set[E]](dst S, a ComparableSet[E], b ComparableSet[E]) S {
+	dst.AddFromSet(a)
+	dst.AddFromSet(b)
+	return dst
+}
+
+// Intersection adds the elements that exist in both of the sets a and b to the given destination
+// set, and returns the destination. Like [Union], this lets the caller choose the representation
+// of the result, unlike [ComparableSet.Intersection]. The destination must not be one of the input
+// sets.
+//
+// Intersection iterates over the smaller of the two sets, and checks if each element is contained
+// in the larger one.
+func Intersection[E comparable, S Set[E]](dst S, a ComparableSet[E], b ComparableSet[E]) S {
+	smaller, larger := a, b
+	if smaller.Size() > larger.Size() {
+		smaller, larger = larger, smaller
+	}
+
+	for element := range smaller.All() {
+		if larger.Contains(element) {
+			dst.Add(element)
+		}
+	}
+
+	return dst
+}
+
+// Difference adds the elements of set a that do not exist in set b to the given destination set,
+// and returns the destination. Like [Union], this lets the caller choose the representation of the
+// result. The destination must not be one of the input sets.
+func Difference[E comparable, S Set[E]](dst S, a ComparableSet[E], b ComparableSet[E]) S {
+	for element := range a.All() {
+		if !b.Contains(element) {
+			dst.Add(element)
+		}
+	}
+
+	return dst
+}
+
+// IntersectionSize returns the number of elements that exist in both of the sets a and b, without
+// building the intersection. This is useful for scoring and thresholding, such as computing the
+// Jaccard similarity of two sets, where only the size of the result is needed.
+//
+// Like [Intersection], it iterates over the smaller of the two sets, and checks if each element is
+// contained in the larger one.
+func IntersectionSize[E comparable](a ComparableSet[E], b ComparableSet[E]) int {
+	smaller, larger := a, b
+	if smaller.Size() > larger.Size() {
+		smaller, larger = larger, smaller
+	}
+
+	count := 0
+	for element := range smaller.All() {
+		if larger.Contains(element) {
+			count++
+		}
+	}
+	return count
+}
+
+// UnionSize returns the number of elements that exist in either of the sets a and b, without
+// building the union. It is computed as the sum of the sizes of a and b, minus [IntersectionSize].
+func UnionSize[E comparable](a ComparableSet[E], b ComparableSet[E]) int {
+	return a.Size() + b.Size() - IntersectionSize(a, b)
+}
+
+// DifferenceSize returns the number of elements of set a that do not exist in set b, without
+// building the difference. It is computed as the size of a, minus [IntersectionSize].
+func DifferenceSize[E comparable](a ComparableSet[E], b ComparableSet[E]) int {
+	return a.Size() - IntersectionSize(a, b)
+}
+
+// Diff compares the two given sets, and returns the elements that were added in after (present in
+// after but not in before), and the elements that were removed (present in before but not in
+// after). This is useful for reconciling a desired state against an actual state, with a single
+// call instead of two Difference calls.
+//
+// Each input set is iterated once. The underlying types of the returned sets are *DynamicSet, since
+// diffs are often small.
+func Diff[E comparable](
+	before ComparableSet[E],
+	after ComparableSet[E],
+) (added Set[E], removed Set[E]) {
+	addedSet := NewDynamicSet[E]()
+	removedSet := NewDynamicSet[E]()
+
+	for element := range after.All() {
+		if !before.Contains(element) {
+			addedSet.Add(element)
+		}
+	}
+
+	for element := range before.All() {
+		if !after.Contains(element) {
+			removedSet.Add(element)
+		}
+	}
+
+	return &addedSet, &removedSet
+}
+
+// Convert adds the result of calling the given function on each element of the source set to the
+// given destination set, and returns the destination. This changes the element type of a set, for
+// example to project a set of structs to a set of their IDs. Elements that convert to the same
+// value are added only once, so the destination may end up smaller than the source. Like [Union],
+// this lets the caller choose the representation of the result:
+//
+//	userIDs := set.Convert(&set.HashSet[int]{}, users, func(user User) int { return user.ID })
+//
+// The destination may be empty, or already contain elements (which are kept).
+func Convert[From comparable, To comparable, S Set[To]](
+	dst S,
+	source ComparableSet[From],
+	convert func(element From) To,
+) S {
+	for element := range source.All() {
+		dst.Add(convert(element))
+	}
+
+	return dst
+}
+
+// EqualFunc checks if the two given sets, which may have different element types, have the same
+// size, and every element in each set has a matching element in the other set, using the given
+// equality function. This is like [slices.EqualFunc], and lets you compare e.g. a set of structs
+// against a set of their IDs without first converting one of the sets.
+//
+// Since elements can only be matched through the function, EqualFunc is O(n*m). If elements of A
+// can be converted to B, [EqualBy] is O(n) instead.
+func EqualFunc[A comparable, B comparable](
+	a ComparableSet[A],
+	b ComparableSet[B],
+	eq func(A, B) bool,
+) bool {
+	if a.Size() != b.Size() {
+		return false
+	}
+
+	for elementA := range a.All() {
+		if !containsFunc(b, func(elementB B) bool { return eq(elementA, elementB) }) {
+			return false
+		}
+	}
+
+	for elementB := range b.All() {
+		if !containsFunc(a, func(elementA A) bool { return eq(elementA, elementB) }) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// EqualBy checks if the set b contains exactly the elements of set a converted by the given
+// projection function. For example, to check if a set of users has exactly the IDs in a set of
+// user IDs:
+//
+//	set.EqualBy(users, userIDs, func(user User) int { return user.ID })
+//
+// If the projection maps two elements of a to the same element, the sets are not equal, since b
+// then has fewer matching elements than a.
+func EqualBy[A comparable, B comparable](
+	a ComparableSet[A],
+	b ComparableSet[B],
+	project func(A) B,
+) bool {
+	if a.Size() != b.Size() {
+		return false
+	}
+
+	// Since the sizes are equal, all projections being present in b and distinct means that they
+	// cover all of b.
+	projected := make(map[B]struct{}, a.Size())
+	for element := range a.All() {
+		projection := project(element)
+
+		if _, duplicate := projected[projection]; duplicate || !b.Contains(projection) {
+			return false
+		}
+		projected[projection] = struct{}{}
+	}
+
+	return true
+}
+
+func containsFunc[E comparable](set ComparableSet[E], matches func(E) bool) bool {
+	for element := range set.All() {
+		if matches(element) {
+			return true
+		}
+	}
+
+	return false
+}
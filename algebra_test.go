@@ -0,0 +1,127 @@
+package set_test
+
+import (
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestUnionFunc(t *testing.T) {
+	a := set.ArraySetOf(1, 2, 3)
+	b := set.HashSetOf(3, 4)
+
+	union := set.Union(&set.HashSet[int]{}, a, b)
+	assertSize(t, union, 4)
+	assertContains(t, union, 1, 2, 3, 4)
+
+	// Union into one of the input sets.
+	set.Union(&a, a, b)
+	assertSize(t, a, 4)
+	assertContains(t, a, 1, 2, 3, 4)
+}
+
+func TestIntersectionFunc(t *testing.T) {
+	testAllSetTypes(func(dst set.Set[int], setName string) {
+		result := set.Intersection(dst, set.ArraySetOf(1, 2, 3), set.HashSetOf(2, 3, 4, 5))
+
+		assertSize(t, result, 2)
+		assertContains(t, result, 2, 3)
+	})
+}
+
+func TestDifferenceFunc(t *testing.T) {
+	a := set.HashSetOf(1, 2, 3)
+	b := set.ArraySetOf(2, 4)
+
+	difference := set.Difference(&set.ArraySet[int]{}, a, b)
+	assertSize(t, difference, 2)
+	assertContains(t, difference, 1, 3)
+
+	difference = set.Difference(&set.ArraySet[int]{}, b, a)
+	assertSize(t, difference, 1)
+	assertContains(t, difference, 4)
+}
+
+func TestAlgebraSizes(t *testing.T) {
+	a := set.HashSetOf(1, 2, 3)
+	b := set.ArraySetOf(2, 3, 4, 5)
+
+	for _, testCase := range []struct {
+		name     string
+		actual   int
+		expected int
+	}{
+		{"IntersectionSize", set.IntersectionSize[int](a, b), 2},
+		{"UnionSize", set.UnionSize[int](a, b), 5},
+		{"DifferenceSize(a, b)", set.DifferenceSize[int](a, b), 1},
+		{"DifferenceSize(b, a)", set.DifferenceSize[int](b, a), 2},
+		{"IntersectionSize with empty", set.IntersectionSize[int](a, set.ArraySet[int]{}), 0},
+	} {
+		if testCase.actual != testCase.expected {
+			t.Errorf("expected %s == %d, got %d", testCase.name, testCase.expected, testCase.actual)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	before := set.HashSetOf(1, 2, 3)
+	after := set.ArraySetOf(2, 3, 4, 5)
+
+	added, removed := set.Diff(before, after)
+
+	assertSize(t, added, 2)
+	assertContains(t, added, 4, 5)
+	assertSize(t, removed, 1)
+	assertContains(t, removed, 1)
+
+	added, removed = set.Diff(before, before)
+	if !added.IsEmpty() || !removed.IsEmpty() {
+		t.Errorf("expected diff of equal sets to be empty, got added %v and removed %v", added, removed)
+	}
+}
+
+func TestEqualFunc(t *testing.T) {
+	structs := set.ArraySetOf(testStruct{i: 1, s: "a"}, testStruct{i: 2, s: "b"})
+	matchesID := func(element testStruct, id int) bool { return element.i == id }
+
+	if !set.EqualFunc(structs, set.HashSetOf(1, 2), matchesID) {
+		t.Errorf("expected %v to equal IDs {1, 2}", structs)
+	}
+	if set.EqualFunc(structs, set.HashSetOf(1, 3), matchesID) {
+		t.Errorf("expected %v to not equal IDs {1, 3}", structs)
+	}
+	if set.EqualFunc(structs, set.HashSetOf(1), matchesID) {
+		t.Errorf("expected %v to not equal IDs {1}", structs)
+	}
+}
+
+func TestEqualBy(t *testing.T) {
+	structs := set.ArraySetOf(testStruct{i: 1, s: "a"}, testStruct{i: 2, s: "b"})
+	id := func(element testStruct) int { return element.i }
+
+	if !set.EqualBy(structs, set.HashSetOf(1, 2), id) {
+		t.Errorf("expected %v to equal IDs {1, 2}", structs)
+	}
+	if set.EqualBy(structs, set.HashSetOf(2, 3), id) {
+		t.Errorf("expected %v to not equal IDs {2, 3}", structs)
+	}
+
+	duplicateIDs := set.ArraySetOf(testStruct{i: 1, s: "a"}, testStruct{i: 1, s: "b"})
+	if set.EqualBy(duplicateIDs, set.HashSetOf(1, 2), id) {
+		t.Errorf("expected %v with duplicate projections to not equal IDs {1, 2}", duplicateIDs)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	structs := set.ArraySetOf(
+		testStruct{i: 1, s: "a"},
+		testStruct{i: 2, s: "b"},
+		testStruct{i: 1, s: "c"},
+	)
+
+	ids := set.Convert(&set.HashSet[int]{}, structs, func(element testStruct) int {
+		return element.i
+	})
+	assertSize(t, ids, 2)
+	assertContains(t, ids, 1, 2)
+}
@@ -0,0 +1,119 @@
+package set
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// An Allocator provides the backing storage for sets created with [ArraySetWithAllocator] and
+// [SortedArraySetWithAllocator]. This lets programs that create many short-lived sets take their
+// storage from memory that is reused, rather than allocating new memory for each set and leaving
+// it to the garbage collector. [Arena] is the allocator provided by this package.
+//
+// Allocators only provide a set's initial storage: if a set grows beyond the capacity it was
+// created with, it moves to memory allocated as usual.
+type Allocator[E any] interface {
+	// Allocate returns a slice with length 0 and at least the given capacity, whose backing array is
+	// not used by any other slice returned by the allocator until its memory is released.
+	Allocate(capacity int) []E
+}
+
+// An Arena is an [Allocator] that hands out set storage from large chunks of memory, and reclaims
+// all of it at once with [Arena.Release]. This is useful for batch jobs that create many sets for
+// each unit of work and then discard them all: instead of allocating the storage of every set
+// separately, the arena allocates a few chunks, and reuses them for the next unit of work after
+// Release.
+//
+//	arena := set.NewArena[string](4096)
+//	for _, batch := range batches {
+//		for _, record := range batch {
+//			tags := set.ArraySetWithAllocator[string](arena, len(record.Tags))
+//			...
+//		}
+//		arena.Release()
+//	}
+//
+// Only array-based sets can use an arena, since the storage of Go maps cannot be provided by the
+// caller. For hash-based sets, [HashSetPool] reuses the storage of released sets instead.
+//
+// Arena is not safe for concurrent use. It must not be copied after first use.
+type Arena[E any] struct {
+	chunks    [][]E
+	chunkSize int
+	current   int
+	offset    int
+}
+
+// NewArena creates a new [Arena] that allocates memory in chunks of the given number of elements.
+// Allocations larger than the chunk size are not taken from the arena, but allocated as usual.
+// It must not be copied after first use.
+//
+// Panics if chunkSize is less than 1.
+func NewArena[E any](chunkSize int) *Arena[E] {
+	if chunkSize < 1 {
+		panic(fmt.Sprintf("set: Arena chunk size must be at least 1, got %d", chunkSize))
+	}
+
+	return &Arena[E]{chunks: nil, chunkSize: chunkSize, current: 0, offset: 0}
+}
+
+// Allocate returns a slice with length 0 and the given capacity, taken from the arena's current
+// chunk, implementing [Allocator]. When the current chunk is full, the arena moves to the next
+// chunk, allocating a new one if it has none left.
+//
+// Panics if capacity is negative.
+func (arena *Arena[E]) Allocate(capacity int) []E {
+	if capacity < 0 {
+		panic(fmt.Sprintf("set: Allocate called with negative capacity %d", capacity))
+	}
+	if capacity > arena.chunkSize {
+		return make([]E, 0, capacity)
+	}
+
+	if arena.current < len(arena.chunks) && arena.offset+capacity > arena.chunkSize {
+		arena.current++
+		arena.offset = 0
+	}
+	if arena.current == len(arena.chunks) {
+		arena.chunks = append(arena.chunks, make([]E, arena.chunkSize))
+	}
+
+	start := arena.offset
+	arena.offset += capacity
+	// Limiting the capacity makes appends beyond it move to new memory, rather than overwriting the
+	// next allocation in the chunk.
+	return arena.chunks[arena.current][start:start:arena.offset]
+}
+
+// Release reclaims all memory allocated from the arena, to be reused by later allocations. The
+// arena keeps its chunks, so allocating the same amount again does not allocate new memory.
+//
+// Sets created with memory from the arena must not be used after Release, as their storage will be
+// handed out again.
+func (arena *Arena[E]) Release() {
+	for _, chunk := range arena.chunks[:min(arena.current+1, len(arena.chunks))] {
+		// Clearing the used chunks lets the garbage collector free anything that the released
+		// elements pointed to.
+		clear(chunk)
+	}
+
+	arena.current = 0
+	arena.offset = 0
+}
+
+// ArraySetWithAllocator creates a new [ArraySet], with its initial storage of the given capacity
+// taken from the given allocator.
+// It must not be copied after first use.
+func ArraySetWithAllocator[E comparable](allocator Allocator[E], capacity int) ArraySet[E] {
+	return ArraySet[E]{elements: allocator.Allocate(capacity)}
+}
+
+// SortedArraySetWithAllocator creates a new [SortedArraySet], sorted in ascending order, with its
+// initial storage of the given capacity taken from the given allocator.
+// It must not be copied after first use.
+func SortedArraySetWithAllocator[E cmp.Ordered](
+	allocator Allocator[E],
+	capacity int,
+) SortedArraySet[E] {
+	return SortedArraySet[E]{elements: allocator.Allocate(capacity), compare: cmp.Compare[E]}
+}
@@ -0,0 +1,46 @@
+package set_test
+
+import (
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestArena(t *testing.T) {
+	arena := set.NewArena[int](16)
+
+	first := set.ArraySetWithAllocator[int](arena, 4)
+	second := set.ArraySetWithAllocator[int](arena, 4)
+	first.AddMultiple(1, 2, 3, 4)
+	second.AddMultiple(5, 6, 7, 8)
+
+	// Growing beyond the allocated capacity must not overwrite the next set in the chunk.
+	first.Add(9)
+	assertSize(t, first, 5)
+	assertContains(t, first, 1, 2, 3, 4, 9)
+	assertSize(t, second, 4)
+	assertContains(t, second, 5, 6, 7, 8)
+
+	sorted := set.SortedArraySetWithAllocator[int](arena, 8)
+	sorted.AddMultiple(3, 1, 2)
+	if got := sorted.String(); got != "SortedArraySet{1, 2, 3}" {
+		t.Errorf("expected SortedArraySet{1, 2, 3}, got %s", got)
+	}
+}
+
+func TestArenaReusesMemoryAfterRelease(t *testing.T) {
+	arena := set.NewArena[int](64)
+
+	fill := func() {
+		for range 20 {
+			arraySet := set.ArraySetWithAllocator[int](arena, 8)
+			arraySet.AddMultiple(1, 2, 3)
+		}
+		arena.Release()
+	}
+
+	fill()
+	if allocs := testing.AllocsPerRun(10, fill); allocs != 0 {
+		t.Errorf("expected no allocations after arena was released, got %v", allocs)
+	}
+}
@@ -1,7 +1,10 @@
 package set
 
 import (
+	"database/sql/driver"
 	"fmt"
+	"iter"
+	"slices"
 	"strings"
 )
 
@@ -16,6 +19,11 @@ type ArraySet[E comparable] struct {
 	elements []E
 }
 
+// arraySetBulkAddThreshold is the combined number of existing and added elements above which bulk
+// adds to an ArraySet check for duplicates with a temporary map. Below it, scanning the backing
+// slice is faster than allocating the map.
+const arraySetBulkAddThreshold = 32
+
 // NewArraySet creates a new [ArraySet] for elements of type E.
 // It must not be copied after first use.
 func NewArraySet[E comparable]() ArraySet[E] {
@@ -40,16 +48,87 @@ func ArraySetOf[E comparable](elements ...E) ArraySet[E] {
 // Duplicate elements in the slice are added only once.
 func ArraySetFromSlice[E comparable](elements []E) ArraySet[E] {
 	set := ArraySet[E]{elements: make([]E, 0, len(elements))}
+	set.AddFromSlice(elements)
+	return set
+}
+
+// ArraySetAdoptingSlice creates a new [ArraySet] that takes ownership of the given slice, using it
+// as the set's backing storage instead of copying it. This avoids doubling memory when the elements
+// are already held in a slice. The caller must not use the slice after passing it, since the set
+// may modify it.
+// It must not be copied after first use.
+//
+// Duplicate elements are removed in place, keeping the first occurrence of each element. If the
+// elements are known to be unique, use [ArraySetAdoptingUniqueSlice] to skip this check.
+func ArraySetAdoptingSlice[E comparable](elements []E) ArraySet[E] {
+	unique := elements[:0]
+
+	// Like AddFromSlice, large inputs are checked with a temporary map rather than by scanning.
+	var seen map[E]struct{}
+	if len(elements) > arraySetBulkAddThreshold {
+		seen = make(map[E]struct{}, len(elements))
+	}
 
 	for _, element := range elements {
-		if set.Contains(element) {
+		if seen != nil {
+			if _, duplicate := seen[element]; duplicate {
+				continue
+			}
+			seen[element] = struct{}{}
+		} else if slices.Contains(unique, element) {
 			continue
 		}
 
-		set.elements = append(set.elements, element)
+		unique = append(unique, element)
 	}
 
-	return set
+	// Zeroes the elements left over after removing duplicates, so they can be garbage collected.
+	clear(elements[len(unique):])
+	return ArraySet[E]{elements: unique}
+}
+
+// ArraySetAdoptingUniqueSlice creates a new [ArraySet] that takes ownership of the given slice,
+// like [ArraySetAdoptingSlice], but assumes that the elements are unique instead of checking them,
+// so it is O(1). The caller must not use the slice after passing it, since the set may modify it.
+// It must not be copied after first use.
+//
+// If the slice contains duplicates, the set is invalid: Size counts the duplicates, and Remove
+// only removes one of them.
+func ArraySetAdoptingUniqueSlice[E comparable](elements []E) ArraySet[E] {
+	return ArraySet[E]{elements: elements}
+}
+
+// ArraySetFromSliceStrict creates a new [ArraySet] from the elements in the given slice, like
+// [ArraySetFromSlice], except that duplicate elements are an error rather than added only once.
+// This is useful for input such as user configuration, where a duplicate is likely a mistake.
+// It must not be copied after first use.
+//
+// If the slice contains duplicates, the returned error is a [DuplicateElementError] for the first
+// element that occurs again.
+func ArraySetFromSliceStrict[E comparable](elements []E) (ArraySet[E], error) {
+	set := ArraySet[E]{elements: make([]E, 0, len(elements))}
+
+	// Like AddFromSlice, large inputs are checked with a temporary map rather than by scanning.
+	var seen map[E]struct{}
+	if len(elements) > arraySetBulkAddThreshold {
+		seen = make(map[E]struct{}, len(elements))
+	}
+
+	for i, element := range elements {
+		var duplicate bool
+		if seen != nil {
+			_, duplicate = seen[element]
+			seen[element] = struct{}{}
+		} else {
+			duplicate = set.Contains(element)
+		}
+
+		if duplicate {
+			return ArraySet[E]{elements: nil}, DuplicateElementError[E]{Element: element, Index: i}
+		}
+		set.elements = append(set.elements, element)
+	}
+	return set, nil
 }
 
 // Add adds the given element to the set.
@@ -64,6 +143,34 @@ func (set *ArraySet[E]) Add(element E) {
 	set.elements = append(set.elements, element)
 }
 
+// AddStrict adds the given element to the set, or returns an [ElementExistsError] if the element
+// is already present. Unlike Add, this does not silently accept adding an element twice, which is
+// useful for enforcing uniqueness, such as when claiming IDs.
+func (set *ArraySet[E]) AddStrict(element E) error {
+	if set.Contains(element) {
+		return ElementExistsError[E]{Element: element}
+	}
+
+	set.Add(element)
+	return nil
+}
+
+// TryAdd adds the given element to the set, unless the set already holds maxSize elements, in
+// which case it returns a [SetFullError] without adding it. Adding an element that is already
+// present is a no-op, and succeeds even when the set is full. This is useful for enforcing limits,
+// such as a maximum number of labels per tenant, without checking Size around every Add.
+func (set *ArraySet[E]) TryAdd(element E, maxSize int) error {
+	if set.Contains(element) {
+		return nil
+	}
+	if set.Size() >= maxSize {
+		return SetFullError[E]{Element: element, MaxSize: maxSize}
+	}
+
+	set.Add(element)
+	return nil
+}
+
 // AddMultiple adds the given elements to the set. Duplicate elements are added only once, and
 // elements already present in the set are not added.
 func (set *ArraySet[E]) AddMultiple(elements ...E) {
@@ -72,44 +179,159 @@ func (set *ArraySet[E]) AddMultiple(elements ...E) {
 
 // AddFromSlice adds the elements from the given slice to the set. Duplicate elements are added only
 // once, and elements already present in the set are not added.
+//
+// For large inputs, duplicates are checked with a temporary map instead of scanning the set for
+// every element, so that adding n elements is O(n) rather than O(n²).
 func (set *ArraySet[E]) AddFromSlice(elements []E) {
-	if set.elements == nil {
-		set.elements = make([]E, 0, len(elements))
+	set.elements = slices.Grow(set.elements, len(elements))
+
+	if len(set.elements)+len(elements) <= arraySetBulkAddThreshold {
+		for _, element := range elements {
+			set.Add(element)
+		}
+		return
+	}
+
+	seen := make(map[E]struct{}, len(set.elements)+len(elements))
+	for _, element := range set.elements {
+		seen[element] = struct{}{}
 	}
 
 	for _, element := range elements {
-		set.Add(element)
+		if _, alreadyAdded := seen[element]; !alreadyAdded {
+			seen[element] = struct{}{}
+			set.elements = append(set.elements, element)
+		}
 	}
 }
 
-// AddFromSet adds elements from the given other set to the set.
+// AddFromSet adds elements from the given other set to the set. The set's capacity is grown up
+// front to fit the other set's elements.
+//
+// If the other set is a known type that guarantees unique elements ([ArraySet], [HashSet] or
+// [DynamicSet]), its elements only need to be checked against the elements that were in the set
+// before the call, and are appended directly if the set is empty. Other sets (such as a
+// [SliceSet] with duplicates) are deduplicated as their elements are added.
 func (set *ArraySet[E]) AddFromSet(otherSet ComparableSet[E]) {
-	if set.elements == nil {
-		set.elements = make([]E, 0, otherSet.Size())
+	unique := hasUniqueElements(otherSet)
+	if unique && set.IsEmpty() {
+		set.elements = otherSet.AppendTo(set.elements)
+		return
 	}
 
-	otherSet.All()(func(element E) bool {
-		set.Add(element)
+	set.elements = slices.Grow(set.elements, otherSet.Size())
+
+	if len(set.elements)+otherSet.Size() <= arraySetBulkAddThreshold {
+		for element := range otherSet.All() {
+			set.Add(element)
+		}
+		return
+	}
+
+	existing := make(map[E]struct{}, len(set.elements))
+	for _, element := range set.elements {
+		existing[element] = struct{}{}
+	}
+
+	for element := range otherSet.All() {
+		if _, alreadyAdded := existing[element]; !alreadyAdded {
+			if !unique {
+				existing[element] = struct{}{}
+			}
+			set.elements = append(set.elements, element)
+		}
+	}
+}
+
+// hasUniqueElements returns true if the given set is of a type that is guaranteed to hold unique
+// elements, so that its elements do not need to be deduplicated against each other.
+func hasUniqueElements[E comparable](set ComparableSet[E]) bool {
+	switch set.(type) {
+	case ArraySet[E], *ArraySet[E], HashSet[E], *HashSet[E], DynamicSet[E], *DynamicSet[E]:
 		return true
-	})
+	default:
+		return false
+	}
+}
+
+// AddFromSeq adds the elements from the given iterator to the set, without first collecting them
+// into a slice. Duplicate elements are added only once, and elements already present in the set are
+// not added.
+func (set *ArraySet[E]) AddFromSeq(seq iter.Seq[E]) {
+	for element := range seq {
+		set.Add(element)
+	}
 }
 
 // Remove removes the given element from the set.
 // If the element is not present in the set, Remove is a no-op.
+//
+// Since sets are unordered, the last element is moved into the removed element's place, instead of
+// shifting all following elements. So after the linear search for the element, Remove is O(1).
 func (set *ArraySet[E]) Remove(element E) {
 	for i, candidate := range set.elements {
 		if element == candidate {
-			set.elements = append(set.elements[:i], set.elements[i+1:]...)
+			last := len(set.elements) - 1
+			set.elements[i] = set.elements[last]
+
+			// Zeroes the vacated slot, so that the backing array does not keep a reference to it.
+			var zero E
+			set.elements[last] = zero
+
+			set.elements = set.elements[:last]
 			return
 		}
 	}
 }
 
+// RemoveStrict removes the given element from the set, or returns an [ElementNotFoundError] if
+// the element is not present. Unlike Remove, this does not silently accept removing a missing
+// element.
+func (set *ArraySet[E]) RemoveStrict(element E) error {
+	if !set.Contains(element) {
+		return ElementNotFoundError[E]{Element: element}
+	}
+
+	set.Remove(element)
+	return nil
+}
+
 // Clear removes all elements from the set, leaving an empty set with the same capacity as before.
 func (set *ArraySet[E]) Clear() {
 	set.elements = set.elements[:0]
 }
 
+// Grow increases the set's capacity, if necessary, to guarantee room for another n elements. After
+// Grow(n), at least n elements can be added to the set without another allocation.
+//
+// Panics if n is negative.
+func (set *ArraySet[E]) Grow(n int) {
+	checkGrowCount(n)
+	set.elements = slices.Grow(set.elements, n)
+}
+
+// Clip removes unused capacity from the set's backing slice, like [slices.Clip]. Unlike
+// slices.Clip, it reallocates the slice to fit the set's current size, so a long-lived set that was
+// temporarily large gives the memory of its largest size back. If the set has no unused capacity,
+// Clip is a no-op.
+//
+// After Clip, slices previously returned by AsSlice no longer alias the set.
+func (set *ArraySet[E]) Clip() {
+	if len(set.elements) == cap(set.elements) {
+		return
+	}
+
+	clipped := make([]E, len(set.elements))
+	copy(clipped, set.elements)
+	set.elements = clipped
+}
+
+// Cap returns the capacity of the set's backing slice, i.e. the number of elements the set can hold
+// before it must reallocate.
+func (set ArraySet[E]) Cap() int {
+	return cap(set.elements)
+}
+
 // Contains checks if given element is present in the set.
 func (set ArraySet[E]) Contains(element E) bool {
 	for _, candidate := range set.elements {
@@ -121,6 +343,35 @@ func (set ArraySet[E]) Contains(element E) bool {
 	return false
 }
 
+// At returns the element at the given index in the set's backing slice. Elements keep their
+// position until the set is modified: Add appends to the end, and Remove moves the last element
+// into the removed element's place.
+//
+// Panics if the index is out of range [0, Size()).
+func (set ArraySet[E]) At(index int) E {
+	if index < 0 || index >= len(set.elements) {
+		panic(fmt.Sprintf(
+			"set: index %d out of range for ArraySet of size %d",
+			index,
+			len(set.elements),
+		))
+	}
+
+	return set.elements[index]
+}
+
+// IndexOf returns the index of the given element in the set's backing slice (see [ArraySet.At]),
+// or -1 if the element is not present in the set.
+func (set ArraySet[E]) IndexOf(element E) int {
+	for i, candidate := range set.elements {
+		if element == candidate {
+			return i
+		}
+	}
+
+	return -1
+}
+
 // Size returns the number of elements in the set.
 func (set ArraySet[E]) Size() int {
 	return len(set.elements)
@@ -138,6 +389,16 @@ func (set ArraySet[E]) Equals(otherSet ComparableSet[E]) bool {
 
 // IsSubsetOf checks if all of the elements in the set exist in the other given set.
 func (set ArraySet[E]) IsSubsetOf(otherSet ComparableSet[E]) bool {
+	if otherElements, ok := hashSetBackingMap(otherSet); ok {
+		for _, element := range set.elements {
+			if _, contains := otherElements[element]; !contains {
+				return false
+			}
+		}
+
+		return true
+	}
+
 	for _, element := range set.elements {
 		if !otherSet.Contains(element) {
 			return false
@@ -164,16 +425,8 @@ func (set ArraySet[E]) Union(otherSet ComparableSet[E]) Set[E] {
 // other given set.
 func (set ArraySet[E]) UnionArraySet(otherSet ComparableSet[E]) ArraySet[E] {
 	union := ArraySetWithCapacity[E](set.Size() + otherSet.Size())
-
-	for _, element := range set.elements {
-		union.Add(element)
-	}
-
-	otherSet.All()(func(element E) bool {
-		union.Add(element)
-		return true
-	})
-
+	union.elements = append(union.elements, set.elements...)
+	union.AddFromSet(otherSet)
 	return union
 }
 
@@ -196,23 +449,47 @@ func (set ArraySet[E]) IntersectionArraySet(otherSet ComparableSet[E]) ArraySet[
 	}
 
 	intersection := ArraySetWithCapacity[E](capacity)
-	for _, element := range set.elements {
-		if otherSet.Contains(element) {
-			intersection.Add(element)
+
+	// Since the elements of the receiver set are already unique, we can append them directly
+	// instead of going through Add.
+	if otherElements, ok := hashSetBackingMap(otherSet); ok {
+		for _, element := range set.elements {
+			if _, contains := otherElements[element]; contains {
+				intersection.elements = append(intersection.elements, element)
+			}
+		}
+	} else {
+		for _, element := range set.elements {
+			if otherSet.Contains(element) {
+				intersection.elements = append(intersection.elements, element)
+			}
 		}
 	}
 
 	return intersection
 }
 
-// ToSlice returns a slice with all the elements in the set.
-//
-// Mutating the slice may invalidate the set, since it uses the same backing storage. To avoid this,
-// call CopyArraySet first.
+// ToSlice creates a slice with all the elements in the set. The slice is a copy, so it can be
+// mutated freely - to access the set's backing slice without copying, use [ArraySet.AsSlice].
 func (set ArraySet[E]) ToSlice() []E {
+	return slices.Clone(set.elements)
+}
+
+// AsSlice returns the set's backing slice, without copying it.
+//
+// Mutating the slice may invalidate the set, since it uses the same backing storage. The slice may
+// also be changed by later operations on the set. To get a copy that is safe to mutate, use
+// ToSlice instead.
+func (set ArraySet[E]) AsSlice() []E {
 	return set.elements
 }
 
+// AppendTo appends the elements in the set to the given slice, and returns the extended slice,
+// like the append builtin. Unlike ToSlice, this lets the caller reuse a buffer across calls.
+func (set ArraySet[E]) AppendTo(slice []E) []E {
+	return append(slice, set.elements...)
+}
+
 // ToMap creates a map with all the set's elements as keys.
 func (set ArraySet[E]) ToMap() map[E]struct{} {
 	m := make(map[E]struct{}, len(set.elements))
@@ -239,6 +516,16 @@ func (set ArraySet[E]) CopyArraySet() ArraySet[E] {
 	return newSet
 }
 
+// CopyCompact creates a new ArraySet with all the same elements as the original set, but unlike
+// CopyArraySet, with capacity for only its current elements. This is useful when keeping many
+// long-lived copies of sets that were temporarily large, so that each copy does not keep the unused
+// capacity of its original.
+func (set ArraySet[E]) CopyCompact() ArraySet[E] {
+	newSet := ArraySet[E]{elements: make([]E, len(set.elements))}
+	copy(newSet.elements, set.elements)
+	return newSet
+}
+
 // String returns a string representation of the set, implementing [fmt.Stringer].
 //
 // An ArraySet of elements 1, 2 and 3 will be printed as: ArraySet{1, 2, 3}
@@ -258,11 +545,14 @@ func (set ArraySet[E]) String() string {
 	return stringBuilder.String()
 }
 
-// All returns an [Iterator] function, which when called will loop over the elements in the set and
-// call the given yield function on each element. If yield returns false, iteration stops.
+// All returns an iterator over the elements in the set, which can be used in a for-range loop:
+//
+//	for element := range set.All() {
+//		fmt.Println(element)
+//	}
 //
 // Since sets are unordered, iteration order is non-deterministic.
-func (set ArraySet[E]) All() Iterator[E] {
+func (set ArraySet[E]) All() iter.Seq[E] {
 	return func(yield func(element E) bool) {
 		for _, element := range set.elements {
 			if !yield(element) {
@@ -271,3 +561,73 @@ func (set ArraySet[E]) All() Iterator[E] {
 		}
 	}
 }
+
+// MarshalBinary encodes the set in a compact, length-prefixed binary format, implementing
+// [encoding.BinaryMarshaler]. Strings, numbers, bools and other fixed-size element types are
+// supported, as well as element types that implement encoding.BinaryMarshaler (with pointers to the
+// element type implementing [encoding.BinaryUnmarshaler]).
+func (set ArraySet[E]) MarshalBinary() ([]byte, error) {
+	return marshalSetBinary[E](set)
+}
+
+// UnmarshalBinary replaces the elements of the set with the elements decoded from the given data,
+// which must be in the format produced by [ArraySet.MarshalBinary]. It implements
+// [encoding.BinaryUnmarshaler].
+func (set *ArraySet[E]) UnmarshalBinary(data []byte) error {
+	return unmarshalSetBinary[E](data, set)
+}
+
+// Value converts the set to a PostgreSQL array literal string (such as {1,2,3} or {"a","b"}),
+// implementing [driver.Valuer] so the set can be stored in an array or text column. Strings,
+// numbers, bools and element types implementing [encoding.TextMarshaler] are supported.
+func (set ArraySet[E]) Value() (driver.Value, error) {
+	return sqlValueFromSet[E](set)
+}
+
+// Scan replaces the elements of the set with the elements parsed from the given PostgreSQL array
+// literal (as a string or []byte), implementing [database/sql.Scanner]. A NULL value results in an
+// empty set.
+func (set *ArraySet[E]) Scan(src any) error {
+	return scanSQLValueIntoSet[E](src, set)
+}
+
+// Format implements [fmt.Formatter], supporting the following verbs:
+//   - %v and %s print the set like String (%+v formats elements with %+v)
+//   - %q quotes string elements
+//   - %#v prints the set in Go syntax, like [ArraySet.GoString]
+//   - Other verbs are applied to each element, like fmt does for slices (e.g. %x prints elements in
+//     hex)
+//
+// Precision limits the number of printed elements (e.g. %.2v prints ArraySet{1, 2, ...}), and width
+// pads the output.
+func (set ArraySet[E]) Format(state fmt.State, verb rune) {
+	formatSet[E](state, verb, "ArraySet", set)
+}
+
+// GoString returns a representation of the set in Go syntax, implementing [fmt.GoStringer]. This is
+// also used by the %#v verb. An ArraySet of elements 1, 2 and 3 will be printed as:
+// set.ArraySetOf[int](1, 2, 3)
+func (set ArraySet[E]) GoString() string {
+	return goSyntaxString[E]("ArraySet", set)
+}
+
+// arraySetBackingSlice returns the backing slice of the given set, if it is an ArraySet, a
+// DynamicSet that is currently an ArraySet, or a SliceSet. This lets operations between sets use
+// the other set's slice directly, instead of going through an interface method call for every
+// element.
+func arraySetBackingSlice[E comparable](set ComparableSet[E]) (elements []E, ok bool) {
+	switch set := set.(type) {
+	case ArraySet[E]:
+		return set.elements, true
+	case *ArraySet[E]:
+		return set.elements, true
+	case DynamicSet[E]:
+		return set.array.elements, set.IsArraySet()
+	case *DynamicSet[E]:
+		return set.array.elements, set.IsArraySet()
+	case SliceSet[E]:
+		return set, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,88 @@
+package set
+
+import (
+	"sync/atomic"
+)
+
+// An AtomicSet holds a set that is replaced as a whole, for read-mostly data such as allowlists or
+// feature flags, which are loaded by many goroutines and occasionally swapped for a new version.
+// Readers call [AtomicSet.Load] to get the current set without locking, and writers call
+// [AtomicSet.Store] or [AtomicSet.Update] to swap in a new set atomically.
+//
+// Sets passed to an AtomicSet are treated as immutable: they must not be modified after being
+// stored, since readers may be using them concurrently. Similarly, sets returned by Load must not
+// be modified. Unlike [CopyOnWriteSet], which copies its set on every write, this lets the caller
+// choose the set type, and build each new version however they like.
+//
+// The zero value for an AtomicSet is ready to use, and holds an empty set. It must not be copied
+// after first use.
+type AtomicSet[E comparable] struct {
+	current atomic.Pointer[ComparableSet[E]]
+}
+
+// NewAtomicSet creates a new [AtomicSet], holding the given initial set.
+// It must not be copied after first use.
+func NewAtomicSet[E comparable](initial ComparableSet[E]) *AtomicSet[E] {
+	set := &AtomicSet[E]{}
+	set.Store(initial)
+	return set
+}
+
+// Load returns the set that is currently stored. If no set has been stored, it returns an empty
+// set.
+//
+// The returned set must not be modified.
+func (set *AtomicSet[E]) Load() ComparableSet[E] {
+	current := set.current.Load()
+	if current == nil {
+		return HashSet[E]{elements: nil}
+	}
+	return *current
+}
+
+// Store atomically replaces the current set with the given set.
+//
+// The given set must not be modified after it is stored.
+func (set *AtomicSet[E]) Store(newSet ComparableSet[E]) {
+	set.current.Store(&newSet)
+}
+
+// Swap atomically replaces the current set with the given set, and returns the previous set.
+//
+// The given set must not be modified after it is stored.
+func (set *AtomicSet[E]) Swap(newSet ComparableSet[E]) (previous ComparableSet[E]) {
+	previousPointer := set.current.Swap(&newSet)
+	if previousPointer == nil {
+		return HashSet[E]{elements: nil}
+	}
+	return *previousPointer
+}
+
+// Update replaces the current set with the set returned by the given function, which receives the
+// current set. If another goroutine stores a new set while the function runs, the function is
+// called again with the new set, until the replacement succeeds without interference (a
+// compare-and-swap loop). The function must therefore not have side effects, and must not modify
+// the set it receives - to add elements, create a modified copy:
+//
+//	allowlist.Update(func(current set.ComparableSet[string]) set.ComparableSet[string] {
+//		updated := current.Copy()
+//		updated.Add("new-entry")
+//		return updated
+//	})
+func (set *AtomicSet[E]) Update(update func(current ComparableSet[E]) ComparableSet[E]) {
+	for {
+		currentPointer := set.current.Load()
+
+		var current ComparableSet[E]
+		if currentPointer == nil {
+			current = HashSet[E]{elements: nil}
+		} else {
+			current = *currentPointer
+		}
+
+		newSet := update(current)
+		if set.current.CompareAndSwap(currentPointer, &newSet) {
+			return
+		}
+	}
+}
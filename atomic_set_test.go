@@ -0,0 +1,46 @@
+package set_test
+
+import (
+	"sync"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestAtomicSetLoadAndStore(t *testing.T) {
+	var atomicSet set.AtomicSet[string]
+	if !atomicSet.Load().IsEmpty() {
+		t.Errorf("expected zero value AtomicSet to hold an empty set, got %v", atomicSet.Load())
+	}
+
+	atomicSet.Store(set.HashSetOf("a", "b"))
+	assertContains(t, atomicSet.Load(), "a", "b")
+
+	previous := atomicSet.Swap(set.ArraySetOf("c"))
+	assertContains(t, previous, "a", "b")
+	assertSize(t, atomicSet.Load(), 1)
+	assertContains(t, atomicSet.Load(), "c")
+}
+
+func TestAtomicSetConcurrentUpdate(t *testing.T) {
+	atomicSet := set.NewAtomicSet[int](set.HashSetOf[int]())
+
+	var waitGroup sync.WaitGroup
+	for goroutine := range 8 {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+
+			for i := range 50 {
+				atomicSet.Update(func(current set.ComparableSet[int]) set.ComparableSet[int] {
+					updated := current.Copy()
+					updated.Add(goroutine*50 + i)
+					return updated
+				})
+			}
+		}()
+	}
+	waitGroup.Wait()
+
+	assertSize(t, atomicSet.Load(), 400)
+}
@@ -16,10 +16,11 @@ const (
 )
 
 var (
-	setInts     = createRandomIntSlice(setSize)
-	intArraySet = set.ArraySetFromSlice(setInts)
-	intHashSet  = set.HashSetFromSlice(setInts)
-	inputInts   = createRandomIntSlice(inputSize)
+	setInts            = createRandomIntSlice(setSize)
+	intArraySet        = set.ArraySetFromSlice(setInts)
+	intIntegerArraySet = set.IntegerArraySetFromSlice(setInts)
+	intHashSet         = set.HashSetFromSlice(setInts)
+	inputInts          = createRandomIntSlice(inputSize)
 
 	setStrings     = createRandomStringSlice(setSize)
 	stringArraySet = set.ArraySetFromSlice(setStrings)
@@ -46,6 +47,16 @@ func BenchmarkIntArraySet(b *testing.B) {
 	globalContains = contains
 }
 
+func BenchmarkIntIntegerArraySet(b *testing.B) {
+	var contains bool
+	for n := 0; n < b.N; n++ {
+		for _, i := range inputInts {
+			contains = intIntegerArraySet.Contains(i)
+		}
+	}
+	globalContains = contains
+}
+
 func BenchmarkIntHashSet(b *testing.B) {
 	var contains bool
 	for n := 0; n < b.N; n++ {
@@ -143,3 +154,250 @@ func createRandomStructSlice(length int) []testStruct {
 
 	return structs
 }
+
+func BenchmarkLargeHashSetUnion(b *testing.B) {
+	set1 := set.HashSetFromSlice(createRandomIntSlice(10000))
+	set2 := set.HashSetFromSlice(createRandomIntSlice(10000))
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		set1.UnionHashSet(set2)
+	}
+}
+
+func BenchmarkLargeHashSetEquals(b *testing.B) {
+	set1 := set.HashSetFromSlice(createRandomIntSlice(10000))
+	set2 := set1.CopyHashSet()
+
+	var equals bool
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		equals = set1.Equals(set2)
+	}
+	globalContains = equals
+}
+
+func BenchmarkHashSetAddFromLargeSet(b *testing.B) {
+	largeSet := set.HashSetFromSlice(createRandomIntSlice(100000))
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		smallSet := set.HashSetOf(-1, -2, -3)
+		smallSet.AddFromSet(largeSet)
+	}
+}
+
+func BenchmarkArraySetFromLargeSlice(b *testing.B) {
+	ints := createRandomIntSlice(5000)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		set.ArraySetFromSlice(ints)
+	}
+}
+
+func BenchmarkHashSetPerRequest(b *testing.B) {
+	ints := createRandomIntSlice(100)
+
+	b.Run("New", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			hashSet := set.NewHashSet[int]()
+			hashSet.AddFromSlice(ints)
+		}
+	})
+
+	b.Run("Pooled", func(b *testing.B) {
+		var pool set.HashSetPool[int]
+		for n := 0; n < b.N; n++ {
+			hashSet := pool.Get()
+			hashSet.AddFromSlice(ints)
+			pool.Put(hashSet)
+		}
+	})
+}
+
+// benchmarkSizes are the set sizes used by BenchmarkOperations, chosen to be below, around and
+// above DefaultDynamicSetSizeThreshold.
+var benchmarkSizes = []int{5, 20, 100}
+
+// BenchmarkOperations benchmarks mutation, algebra and iteration operations on ArraySet, HashSet
+// and DynamicSet, for each element type and size. Results are reported as
+// BenchmarkOperations/<element type>/<set type>/<size>/<operation>.
+func BenchmarkOperations(b *testing.B) {
+	b.Run("Int", func(b *testing.B) { benchmarkOperations(b, createRandomIntSlice) })
+	b.Run("String", func(b *testing.B) { benchmarkOperations(b, createRandomStringSlice) })
+	b.Run("Struct", func(b *testing.B) { benchmarkOperations(b, createRandomStructSlice) })
+}
+
+func benchmarkOperations[E comparable](b *testing.B, createElements func(length int) []E) {
+	setTypes := []struct {
+		name   string
+		newSet func() set.Set[E]
+	}{
+		{name: "ArraySet", newSet: func() set.Set[E] { return &set.ArraySet[E]{} }},
+		{name: "HashSet", newSet: func() set.Set[E] { return &set.HashSet[E]{} }},
+		{name: "DynamicSet", newSet: func() set.Set[E] { return &set.DynamicSet[E]{} }},
+	}
+
+	for _, size := range benchmarkSizes {
+		// The other set used for Union and Intersection overlaps with half of the set's elements.
+		elements := createElements(size * 2)
+		setElements := elements[:size]
+		otherElements := elements[size/2 : size/2+size]
+
+		for _, setType := range setTypes {
+			b.Run(fmt.Sprintf("%s/%d", setType.name, size), func(b *testing.B) {
+				benchmarkSetType(b, setType.newSet, setElements, otherElements)
+			})
+		}
+	}
+}
+
+func benchmarkSetType[E comparable](
+	b *testing.B,
+	newSet func() set.Set[E],
+	setElements []E,
+	otherElements []E,
+) {
+	populatedSet := newSet()
+	populatedSet.AddFromSlice(setElements)
+	otherSet := newSet()
+	otherSet.AddFromSlice(otherElements)
+
+	b.Run("Add", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			s := newSet()
+			for _, element := range setElements {
+				s.Add(element)
+			}
+		}
+	})
+
+	b.Run("AddFromSlice", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			s := newSet()
+			s.AddFromSlice(setElements)
+		}
+	})
+
+	b.Run("Remove", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			b.StopTimer()
+			s := populatedSet.Copy()
+			b.StartTimer()
+
+			for _, element := range setElements {
+				s.Remove(element)
+			}
+		}
+	})
+
+	b.Run("Union", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			populatedSet.Union(otherSet)
+		}
+	})
+
+	b.Run("Intersection", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			populatedSet.Intersection(otherSet)
+		}
+	})
+
+	b.Run("Iterate", func(b *testing.B) {
+		b.ReportAllocs()
+		count := 0
+		for n := 0; n < b.N; n++ {
+			for range populatedSet.All() {
+				count++
+			}
+		}
+		globalContains = count > 0
+	})
+}
+
+// BenchmarkDynamicSetTransformation measures the overhead of DynamicSet transforming between
+// ArraySet and HashSet, by repeatedly growing the set past its size threshold and shrinking it
+// below its downgrade threshold. The HashSet case does the same operations without transformation.
+func BenchmarkDynamicSetTransformation(b *testing.B) {
+	ints := createRandomIntSlice(set.DefaultDynamicSetSizeThreshold + 1)
+
+	benchmark := func(b *testing.B, s set.Set[int]) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			for _, i := range ints {
+				s.Add(i)
+			}
+			for _, i := range ints {
+				s.Remove(i)
+			}
+		}
+	}
+
+	b.Run("DynamicSet", func(b *testing.B) {
+		dynamicSet := set.NewDynamicSet[int]()
+		benchmark(b, &dynamicSet)
+	})
+	b.Run("HashSet", func(b *testing.B) {
+		hashSet := set.NewHashSet[int]()
+		benchmark(b, &hashSet)
+	})
+}
+
+func BenchmarkSortedArraySetIntersectionSkewed(b *testing.B) {
+	large := set.SortedArraySetFromSlice(createRandomIntSlice(100000))
+	small := set.SortedArraySetFromSlice(createRandomIntSlice(20))
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		large.IntersectionSortedArraySet(small)
+	}
+}
+
+func BenchmarkSortedArraySetMergeJoin(b *testing.B) {
+	set1 := set.SortedArraySetFromSlice(createRandomIntSlice(10000))
+	set2 := set.SortedArraySetFromSlice(createRandomIntSlice(10000))
+	hashSet2 := set.HashSetFromSlice(set2.AsSlice())
+
+	for _, other := range []struct {
+		name string
+		set  set.ComparableSet[int]
+	}{
+		{"SortedArraySet", set2},
+		{"HashSet", hashSet2},
+	} {
+		b.Run("Union/"+other.name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				set1.UnionSortedArraySet(other.set)
+			}
+		})
+		b.Run("Intersection/"+other.name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				set1.IntersectionSortedArraySet(other.set)
+			}
+		})
+		b.Run("Difference/"+other.name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				set1.DifferenceSortedArraySet(other.set)
+			}
+		})
+	}
+}
+
+func BenchmarkOpenHashSetChurnAtFullLoad(b *testing.B) {
+	openHashSet := set.OpenHashSetWithCapacity[int](7168)
+	for i := range openHashSet.Cap() - 1 {
+		openHashSet.Add(i)
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		openHashSet.Remove(n)
+		openHashSet.Add(n + 7168)
+	}
+}
@@ -0,0 +1,314 @@
+package set
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// The binary format used by MarshalBinary and UnmarshalBinary on the set types is a uvarint with
+// the number of elements, followed by the elements, encoded as follows:
+//   - Element types implementing [encoding.BinaryMarshaler] (with pointers to the element type
+//     implementing [encoding.BinaryUnmarshaler]): uvarint length prefix, then the marshaled bytes
+//   - Strings: uvarint length prefix, then the string bytes
+//   - Signed integers: zig-zag encoded varint
+//   - Unsigned integers: uvarint
+//   - Booleans: a single byte
+//   - Floats: IEEE 754 bits, little-endian
+//   - Other fixed-size types (see [binary.Size]): little-endian, as encoded by [binary.Write]
+
+type binaryElementCodec[E comparable] struct {
+	encode func(buffer []byte, element E) ([]byte, error)
+	decode func(data []byte) (element E, bytesRead int, err error)
+}
+
+var errBinaryDataTooShort = errors.New("unexpected end of data")
+
+func marshalSetBinary[E comparable](set ComparableSet[E]) ([]byte, error) {
+	codec, err := newBinaryElementCodec[E]()
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := binary.AppendUvarint(nil, uint64(set.Size()))
+
+	for element := range set.All() {
+		buffer, err = codec.encode(buffer, element)
+		if err != nil {
+			return nil, fmt.Errorf("set: failed to marshal element: %w", err)
+		}
+	}
+
+	return buffer, nil
+}
+
+// unmarshalSetBinary clears the given set, and adds the elements decoded from the given data.
+func unmarshalSetBinary[E comparable](data []byte, set Set[E]) error {
+	elements, err := unmarshalBinaryElements[E](data)
+	if err != nil {
+		return err
+	}
+
+	set.Clear()
+	set.AddFromSlice(elements)
+	return nil
+}
+
+func unmarshalBinaryElements[E comparable](data []byte) ([]E, error) {
+	codec, err := newBinaryElementCodec[E]()
+	if err != nil {
+		return nil, err
+	}
+
+	size, bytesRead := binary.Uvarint(data)
+	if bytesRead <= 0 {
+		return nil, errors.New("set: failed to unmarshal set size: invalid uvarint")
+	}
+	data = data[bytesRead:]
+
+	// Every element takes at least 1 byte, except for zero-size types, which have only one possible
+	// value, so a valid set cannot have more elements than the remaining bytes (or 1). Checking this
+	// up front avoids huge allocations and loops from a corrupt size.
+	if size > max(uint64(len(data)), 1) {
+		return nil, fmt.Errorf(
+			"set: invalid set size %d for %d bytes of element data",
+			size,
+			len(data),
+		)
+	}
+	elements := make([]E, 0, size)
+
+	for i := uint64(0); i < size; i++ {
+		element, bytesRead, err := codec.decode(data)
+		if err != nil {
+			return nil, fmt.Errorf("set: failed to unmarshal element %d: %w", i, err)
+		}
+
+		elements = append(elements, element)
+		data = data[bytesRead:]
+	}
+
+	if len(data) != 0 {
+		return nil, fmt.Errorf("set: %d unexpected trailing bytes after set elements", len(data))
+	}
+
+	return elements, nil
+}
+
+func newBinaryElementCodec[E comparable]() (binaryElementCodec[E], error) {
+	var zero E
+
+	if _, ok := any(zero).(encoding.BinaryMarshaler); ok {
+		if _, ok := any(&zero).(encoding.BinaryUnmarshaler); ok {
+			return binaryMarshalerCodec[E](), nil
+		}
+	}
+
+	elementType := reflect.TypeOf(&zero).Elem()
+
+	switch elementType.Kind() {
+	case reflect.String:
+		return binaryElementCodec[E]{
+			encode: func(buffer []byte, element E) ([]byte, error) {
+				value := reflect.ValueOf(element).String()
+				buffer = binary.AppendUvarint(buffer, uint64(len(value)))
+				return append(buffer, value...), nil
+			},
+			decode: func(data []byte) (element E, bytesRead int, err error) {
+				value, bytesRead, err := decodeLengthPrefixed(data)
+				if err != nil {
+					return element, 0, err
+				}
+				reflect.ValueOf(&element).Elem().SetString(string(value))
+				return element, bytesRead, nil
+			},
+		}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binaryElementCodec[E]{
+			encode: func(buffer []byte, element E) ([]byte, error) {
+				return binary.AppendVarint(buffer, reflect.ValueOf(element).Int()), nil
+			},
+			decode: func(data []byte) (element E, bytesRead int, err error) {
+				value, bytesRead := binary.Varint(data)
+				if bytesRead <= 0 {
+					return element, 0, errors.New("invalid varint")
+				}
+				elementValue := reflect.ValueOf(&element).Elem()
+				if elementValue.OverflowInt(value) {
+					return element, 0, fmt.Errorf("value %d overflows %v", value, elementType)
+				}
+				elementValue.SetInt(value)
+				return element, bytesRead, nil
+			},
+		}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		return binaryElementCodec[E]{
+			encode: func(buffer []byte, element E) ([]byte, error) {
+				return binary.AppendUvarint(buffer, reflect.ValueOf(element).Uint()), nil
+			},
+			decode: func(data []byte) (element E, bytesRead int, err error) {
+				value, bytesRead := binary.Uvarint(data)
+				if bytesRead <= 0 {
+					return element, 0, errors.New("invalid uvarint")
+				}
+				elementValue := reflect.ValueOf(&element).Elem()
+				if elementValue.OverflowUint(value) {
+					return element, 0, fmt.Errorf("value %d overflows %v", value, elementType)
+				}
+				elementValue.SetUint(value)
+				return element, bytesRead, nil
+			},
+		}, nil
+	case reflect.Bool:
+		return binaryElementCodec[E]{
+			encode: func(buffer []byte, element E) ([]byte, error) {
+				if reflect.ValueOf(element).Bool() {
+					return append(buffer, 1), nil
+				}
+				return append(buffer, 0), nil
+			},
+			decode: func(data []byte) (element E, bytesRead int, err error) {
+				if len(data) < 1 {
+					return element, 0, errBinaryDataTooShort
+				}
+				if data[0] > 1 {
+					return element, 0, fmt.Errorf("invalid bool byte %d", data[0])
+				}
+				reflect.ValueOf(&element).Elem().SetBool(data[0] == 1)
+				return element, 1, nil
+			},
+		}, nil
+	case reflect.Float32:
+		return binaryElementCodec[E]{
+			encode: func(buffer []byte, element E) ([]byte, error) {
+				bits := math.Float32bits(float32(reflect.ValueOf(element).Float()))
+				return binary.LittleEndian.AppendUint32(buffer, bits), nil
+			},
+			decode: func(data []byte) (element E, bytesRead int, err error) {
+				if len(data) < 4 {
+					return element, 0, errBinaryDataTooShort
+				}
+				value := math.Float32frombits(binary.LittleEndian.Uint32(data))
+				reflect.ValueOf(&element).Elem().SetFloat(float64(value))
+				return element, 4, nil
+			},
+		}, nil
+	case reflect.Float64:
+		return binaryElementCodec[E]{
+			encode: func(buffer []byte, element E) ([]byte, error) {
+				bits := math.Float64bits(reflect.ValueOf(element).Float())
+				return binary.LittleEndian.AppendUint64(buffer, bits), nil
+			},
+			decode: func(data []byte) (element E, bytesRead int, err error) {
+				if len(data) < 8 {
+					return element, 0, errBinaryDataTooShort
+				}
+				value := math.Float64frombits(binary.LittleEndian.Uint64(data))
+				reflect.ValueOf(&element).Elem().SetFloat(value)
+				return element, 8, nil
+			},
+		}, nil
+	}
+
+	if size := binary.Size(zero); size >= 0 {
+		// binary.Read panics when setting unexported fields, so such types can not be decoded.
+		if hasUnexportedFields(elementType) {
+			return binaryElementCodec[E]{}, fmt.Errorf(
+				"set: element type %v does not support binary encoding (has unexported fields, "+
+					"and does not implement encoding.BinaryMarshaler/BinaryUnmarshaler)",
+				elementType,
+			)
+		}
+
+		return fixedSizeBinaryCodec[E](size), nil
+	}
+
+	return binaryElementCodec[E]{}, fmt.Errorf(
+		"set: element type %v does not support binary encoding (must implement "+
+			"encoding.BinaryMarshaler/BinaryUnmarshaler, or be a string, number, bool or fixed-size type)",
+		elementType,
+	)
+}
+
+func binaryMarshalerCodec[E comparable]() binaryElementCodec[E] {
+	return binaryElementCodec[E]{
+		encode: func(buffer []byte, element E) ([]byte, error) {
+			value, err := any(element).(encoding.BinaryMarshaler).MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			buffer = binary.AppendUvarint(buffer, uint64(len(value)))
+			return append(buffer, value...), nil
+		},
+		decode: func(data []byte) (element E, bytesRead int, err error) {
+			value, bytesRead, err := decodeLengthPrefixed(data)
+			if err != nil {
+				return element, 0, err
+			}
+			if err := any(&element).(encoding.BinaryUnmarshaler).UnmarshalBinary(value); err != nil {
+				return element, 0, err
+			}
+			return element, bytesRead, nil
+		},
+	}
+}
+
+func fixedSizeBinaryCodec[E comparable](size int) binaryElementCodec[E] {
+	return binaryElementCodec[E]{
+		encode: func(buffer []byte, element E) ([]byte, error) {
+			writer := bytes.NewBuffer(buffer)
+			if err := binary.Write(writer, binary.LittleEndian, element); err != nil {
+				return nil, err
+			}
+			return writer.Bytes(), nil
+		},
+		decode: func(data []byte) (element E, bytesRead int, err error) {
+			if len(data) < size {
+				return element, 0, errBinaryDataTooShort
+			}
+			if err := binary.Read(
+				bytes.NewReader(data[:size]),
+				binary.LittleEndian,
+				&element,
+			); err != nil {
+				return element, 0, err
+			}
+			return element, size, nil
+		},
+	}
+}
+
+// hasUnexportedFields checks if the given type is a struct with unexported fields, or contains
+// such a struct. Blank (_) fields are allowed, since encoding/binary skips them.
+func hasUnexportedFields(elementType reflect.Type) bool {
+	switch elementType.Kind() {
+	case reflect.Struct:
+		for i := range elementType.NumField() {
+			field := elementType.Field(i)
+			if (!field.IsExported() && field.Name != "_") || hasUnexportedFields(field.Type) {
+				return true
+			}
+		}
+	case reflect.Array:
+		return hasUnexportedFields(elementType.Elem())
+	}
+	return false
+}
+
+func decodeLengthPrefixed(data []byte) (value []byte, bytesRead int, err error) {
+	length, prefixLength := binary.Uvarint(data)
+	if prefixLength <= 0 {
+		return nil, 0, errors.New("invalid uvarint length prefix")
+	}
+	if length > uint64(len(data)-prefixLength) {
+		return nil, 0, errBinaryDataTooShort
+	}
+
+	end := prefixLength + int(length)
+	return data[prefixLength:end], end, nil
+}
@@ -0,0 +1,101 @@
+package set_test
+
+import (
+	"encoding"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+type binaryTestStruct struct {
+	ID    uint32
+	Score float64
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	testBinaryRoundTrip[int](t, set.ArraySetOf(-1, 0, 1, 1000000), &set.ArraySet[int]{})
+	testBinaryRoundTrip[string](t, set.HashSetOf("a", "", "hello"), &set.HashSet[string]{})
+	testBinaryRoundTrip[binaryTestStruct](
+		t,
+		set.DynamicSetOf(binaryTestStruct{1, 0.5}, binaryTestStruct{2, -3}),
+		&set.DynamicSet[binaryTestStruct]{},
+	)
+}
+
+func TestBinaryUnmarshalReplacesElements(t *testing.T) {
+	data, err := set.ArraySetOf(1, 2).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target := set.HashSetOf(3, 4)
+	if err := target.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	assertSize(t, target, 2)
+	assertContains(t, target, 1, 2)
+}
+
+func TestBinaryUnmarshalInvalidData(t *testing.T) {
+	data, err := set.ArraySetOf("a", "b").MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var target set.ArraySet[string]
+	if err := target.UnmarshalBinary(data[:len(data)-1]); err == nil {
+		t.Errorf("expected error when unmarshaling truncated data")
+	}
+}
+
+func TestBinaryUnsupportedElementType(t *testing.T) {
+	type unsupported struct{ s string }
+
+	if _, err := set.HashSetOf(unsupported{"a"}).MarshalBinary(); err == nil {
+		t.Errorf("expected error when marshaling set of structs with non-fixed-size fields")
+	}
+}
+
+func TestBinaryUnexportedFields(t *testing.T) {
+	type unexported struct{ x, y int32 }
+
+	if _, err := set.HashSetOf(unexported{1, 2}).MarshalBinary(); err == nil {
+		t.Errorf("expected error when marshaling set of structs with unexported fields")
+	}
+
+	var target set.HashSet[[2]unexported]
+	if err := target.UnmarshalBinary([]byte{1, 0, 0, 0, 0}); err == nil {
+		t.Errorf("expected error when unmarshaling set of structs with unexported fields")
+	}
+}
+
+func TestBinaryZeroSizeElements(t *testing.T) {
+	testBinaryRoundTrip[struct{}](t, set.ArraySetOf(struct{}{}), &set.ArraySet[struct{}]{})
+
+	var target set.ArraySet[struct{}]
+	if err := target.UnmarshalBinary([]byte{0xff, 0xff, 0xff, 0xff, 0x0f}); err == nil {
+		t.Errorf("expected error when unmarshaling corrupt size of zero-size elements")
+	}
+}
+
+func testBinaryRoundTrip[E comparable](
+	t *testing.T,
+	original set.ComparableSet[E],
+	target set.Set[E],
+) {
+	t.Helper()
+
+	data, err := original.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal %v: %v", original, err)
+	}
+
+	if err := target.(encoding.BinaryUnmarshaler).UnmarshalBinary(data); err != nil {
+		t.Fatalf("failed to unmarshal %v: %v", original, err)
+	}
+
+	if !target.Equals(original) {
+		t.Errorf("expected %v to equal %v after binary round trip", target, original)
+	}
+}
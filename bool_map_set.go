@@ -0,0 +1,315 @@
+package set
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// A BoolMapSet adapts a map[E]bool into a [Set], so that code using maps of bools as sets can
+// interoperate with this package without copying. Only keys with a true value are members of the
+// set - keys with a false value are ignored.
+//
+// Since false values must be skipped, Size and IsEmpty are O(n) rather than O(1).
+//
+// The zero value for a BoolMapSet is ready to use. It must not be copied after first use.
+//
+// BoolMapSet implements [Set] when passed by pointer, and [ComparableSet] when passed by value.
+type BoolMapSet[E comparable] struct {
+	elements map[E]bool
+}
+
+// NewBoolMapSet creates a new [BoolMapSet] for elements of type E, backed by a new map.
+// It must not be copied after first use.
+func NewBoolMapSet[E comparable]() BoolMapSet[E] {
+	return BoolMapSet[E]{elements: make(map[E]bool)}
+}
+
+// BoolMapSetFromMap creates a new [BoolMapSet] that uses the given map as its backing storage,
+// without copying it. Mutating the set mutates the map, and vice versa.
+// It must not be copied after first use.
+func BoolMapSetFromMap[E comparable](m map[E]bool) BoolMapSet[E] {
+	return BoolMapSet[E]{elements: m}
+}
+
+// BoolMap returns the map that backs the set. Mutating the map will also mutate the set.
+func (set BoolMapSet[E]) BoolMap() map[E]bool {
+	return set.elements
+}
+
+// Add adds the given element to the set, by setting its value in the backing map to true.
+// If the element is already present in the set, Add is a no-op.
+//
+// If the set was not previously initialized through one of the constructors in this package, it
+// will be initialized here.
+func (set *BoolMapSet[E]) Add(element E) {
+	if set.elements == nil {
+		set.elements = make(map[E]bool)
+	}
+
+	set.elements[element] = true
+}
+
+// AddMultiple adds the given elements to the set. Duplicate elements are added only once, and
+// elements already present in the set are not added.
+//
+// If the set was not previously initialized through one of the constructors in this package, it
+// will be initialized here.
+func (set *BoolMapSet[E]) AddMultiple(elements ...E) {
+	set.AddFromSlice(elements)
+}
+
+// AddFromSlice adds the elements from the given slice to the set. Duplicate elements are added only
+// once, and elements already present in the set are not added.
+//
+// If the set was not previously initialized through one of the constructors in this package, it
+// will be initialized here.
+func (set *BoolMapSet[E]) AddFromSlice(elements []E) {
+	if set.elements == nil {
+		set.elements = make(map[E]bool, len(elements))
+	}
+
+	for _, element := range elements {
+		set.elements[element] = true
+	}
+}
+
+// AddFromSet adds elements from the given other set to the set.
+//
+// Unlike [HashSet.AddFromSet], this never replaces the backing map with a larger one up front, since
+// the set must keep mutating the map that it was created from.
+//
+// If the set was not previously initialized through one of the constructors in this package, it
+// will be initialized here.
+func (set *BoolMapSet[E]) AddFromSet(otherSet ComparableSet[E]) {
+	if set.elements == nil {
+		set.elements = make(map[E]bool, otherSet.Size())
+	}
+
+	for element := range otherSet.All() {
+		set.elements[element] = true
+	}
+}
+
+// AddFromSeq adds the elements from the given iterator to the set, without first collecting them
+// into a slice. Duplicate elements are added only once, and elements already present in the set are
+// not added.
+//
+// If the set was not previously initialized through one of the constructors in this package, it
+// will be initialized here.
+func (set *BoolMapSet[E]) AddFromSeq(seq iter.Seq[E]) {
+	if set.elements == nil {
+		set.elements = make(map[E]bool)
+	}
+
+	for element := range seq {
+		set.elements[element] = true
+	}
+}
+
+// Remove removes the given element from the set, by deleting it from the backing map.
+// If the element is not present in the set, Remove is a no-op.
+func (set *BoolMapSet[E]) Remove(element E) {
+	delete(set.elements, element)
+}
+
+// Clear removes all elements from the set, by deleting all keys from the backing map (including
+// keys with a false value).
+func (set *BoolMapSet[E]) Clear() {
+	for element := range set.elements {
+		delete(set.elements, element)
+	}
+}
+
+// Grow is a hint that another n elements will be added, but it only has an effect if the set has
+// not been initialized yet, in which case it is initialized with room for n elements. Otherwise,
+// Grow is a no-op, since the set must keep mutating the map passed to [BoolMapSetFromMap] or
+// returned by BoolMap, and Go maps cannot be grown in place.
+//
+// Panics if n is negative.
+func (set *BoolMapSet[E]) Grow(n int) {
+	checkGrowCount(n)
+
+	if set.elements == nil {
+		set.elements = make(map[E]bool, n)
+	}
+}
+
+// Contains checks if given element is present in the set, i.e. that its value in the backing map
+// is true.
+func (set BoolMapSet[E]) Contains(element E) bool {
+	return set.elements[element]
+}
+
+// Size returns the number of elements in the set, i.e. the number of true values in the backing
+// map.
+func (set BoolMapSet[E]) Size() int {
+	size := 0
+	for _, isMember := range set.elements {
+		if isMember {
+			size++
+		}
+	}
+	return size
+}
+
+// IsEmpty checks if there are 0 elements in the set, i.e. that there are no true values in the
+// backing map.
+func (set BoolMapSet[E]) IsEmpty() bool {
+	for _, isMember := range set.elements {
+		if isMember {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals checks if the set contains exactly the same elements as the other given set.
+func (set BoolMapSet[E]) Equals(otherSet ComparableSet[E]) bool {
+	return set.Size() == otherSet.Size() && set.IsSubsetOf(otherSet)
+}
+
+// IsSubsetOf checks if all of the elements in the set exist in the other given set.
+func (set BoolMapSet[E]) IsSubsetOf(otherSet ComparableSet[E]) bool {
+	for element, isMember := range set.elements {
+		if isMember && !otherSet.Contains(element) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsSupersetOf checks if the set contains all of the elements in the other given set.
+func (set BoolMapSet[E]) IsSupersetOf(otherSet ComparableSet[E]) bool {
+	return otherSet.IsSubsetOf(set)
+}
+
+// Union creates a new set that contains all the elements of the receiver set and the other given
+// set. The underlying type of the returned set is a *BoolMapSet, backed by a new map.
+func (set BoolMapSet[E]) Union(otherSet ComparableSet[E]) Set[E] {
+	union := set.CopyBoolMapSet()
+	union.AddFromSet(otherSet)
+	return &union
+}
+
+// Intersection creates a new set with only the elements that exist in both the receiver set and the
+// other given set. The underlying type of the returned set is a *BoolMapSet, backed by a new map.
+func (set BoolMapSet[E]) Intersection(otherSet ComparableSet[E]) Set[E] {
+	intersection := NewBoolMapSet[E]()
+
+	for element, isMember := range set.elements {
+		if isMember && otherSet.Contains(element) {
+			intersection.elements[element] = true
+		}
+	}
+
+	return &intersection
+}
+
+// ToSlice creates a slice with all the elements in the set.
+//
+// Since sets are unordered, the order of elements in the slice is non-deterministic, and may vary
+// even when called multiple times on the same set.
+func (set BoolMapSet[E]) ToSlice() []E {
+	slice := make([]E, 0, len(set.elements))
+
+	for element, isMember := range set.elements {
+		if isMember {
+			slice = append(slice, element)
+		}
+	}
+
+	return slice
+}
+
+// AppendTo appends the elements in the set to the given slice, and returns the extended slice,
+// like the append builtin. Unlike ToSlice, this lets the caller reuse a buffer across calls.
+func (set BoolMapSet[E]) AppendTo(slice []E) []E {
+	for element, isMember := range set.elements {
+		if isMember {
+			slice = append(slice, element)
+		}
+	}
+
+	return slice
+}
+
+// ToMap creates a map with all the set's elements as keys.
+func (set BoolMapSet[E]) ToMap() map[E]struct{} {
+	m := make(map[E]struct{}, len(set.elements))
+
+	for element, isMember := range set.elements {
+		if isMember {
+			m[element] = struct{}{}
+		}
+	}
+
+	return m
+}
+
+// Copy creates a new set with all the same elements as the original set, backed by a new map.
+// The underlying type of the returned set is a *BoolMapSet - to get a value type, use
+// [BoolMapSet.CopyBoolMapSet] instead.
+func (set BoolMapSet[E]) Copy() Set[E] {
+	newSet := set.CopyBoolMapSet()
+	return &newSet
+}
+
+// CopyBoolMapSet creates a new BoolMapSet with all the same elements as the original set, backed
+// by a new map. Keys with a false value in the original map are not copied.
+func (set BoolMapSet[E]) CopyBoolMapSet() BoolMapSet[E] {
+	newSet := BoolMapSet[E]{elements: make(map[E]bool, len(set.elements))}
+
+	for element, isMember := range set.elements {
+		if isMember {
+			newSet.elements[element] = true
+		}
+	}
+
+	return newSet
+}
+
+// String returns a string representation of the set, implementing [fmt.Stringer].
+//
+// Since sets are unordered, the order of elements in the string may differ each time it is called.
+//
+// A BoolMapSet of elements 1, 2 and 3 will be printed as: BoolMapSet{1, 2, 3} (though the order
+// may vary).
+func (set BoolMapSet[E]) String() string {
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString("BoolMapSet{")
+
+	first := true
+	for element, isMember := range set.elements {
+		if !isMember {
+			continue
+		}
+
+		if !first {
+			stringBuilder.WriteString(", ")
+		}
+		fmt.Fprint(&stringBuilder, element)
+		first = false
+	}
+
+	stringBuilder.WriteByte('}')
+	return stringBuilder.String()
+}
+
+// All returns an iterator over the elements in the set, which can be used in a for-range loop:
+//
+//	for element := range set.All() {
+//		fmt.Println(element)
+//	}
+//
+// Since sets are unordered, iteration order is non-deterministic.
+func (set BoolMapSet[E]) All() iter.Seq[E] {
+	return func(yield func(element E) bool) {
+		for element, isMember := range set.elements {
+			if isMember && !yield(element) {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package set_test
+
+import (
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestBoolMapSetIgnoresFalseValues(t *testing.T) {
+	m := map[string]bool{"a": true, "b": false, "c": true}
+	set := set.BoolMapSetFromMap(m)
+
+	assertSize(t, set, 2)
+	assertContains(t, set, "a", "c")
+	if set.Contains("b") {
+		t.Errorf("expected %v to not contain element with false value", set)
+	}
+
+	set.Add("b")
+	set.Remove("a")
+
+	if !m["b"] || m["a"] {
+		t.Errorf("expected mutations of %v to be reflected in backing map, got %v", set, m)
+	}
+}
+
+func TestBoolMapSetGrowKeepsBackingMap(t *testing.T) {
+	m := map[string]bool{"a": true, "b": false}
+	set := set.BoolMapSetFromMap(m)
+
+	set.Grow(100)
+	set.Add("c")
+	set.Clear()
+
+	if len(m) != 0 {
+		t.Errorf("expected Grow to keep %v backing map, got %v", set, m)
+	}
+}
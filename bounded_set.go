@@ -0,0 +1,389 @@
+package set
+
+import (
+	"container/list"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// A BoundedSet is a collection of unique elements of type E, with a maximum size. When adding an
+// element would bring the set above its max size, an existing element is evicted first, according
+// to the set's [EvictionPolicy]. This makes it suitable for deduplication caches, where unbounded
+// growth is not acceptable.
+//
+// A BoundedSet must be created with [NewBoundedSet]. It must not be copied after first use.
+// The zero value is an empty set with no room for elements: it can be read from, but adding to it
+// panics.
+//
+// BoundedSet implements [Set] when passed by pointer, and [ComparableSet] when passed by value.
+type BoundedSet[E comparable] struct {
+	maxSize        int
+	evictionPolicy EvictionPolicy
+	elements       map[E]*list.Element
+	order          *list.List
+}
+
+// EvictionPolicy determines which element a [BoundedSet] evicts when it is full.
+type EvictionPolicy int
+
+const (
+	// EvictLRU evicts the least recently used element. Both adding an element that is already
+	// present and checking if an element is present with [BoundedSet.Touch] count as a use.
+	// Contains does not count as a use, so that it does not modify the set.
+	EvictLRU EvictionPolicy = iota
+
+	// EvictFIFO evicts the element that was first added to the set.
+	EvictFIFO
+
+	// EvictRandom evicts an arbitrary element, using the randomized iteration order of Go maps.
+	EvictRandom
+)
+
+// String returns the name of the eviction policy, implementing [fmt.Stringer].
+func (policy EvictionPolicy) String() string {
+	switch policy {
+	case EvictLRU:
+		return "EvictLRU"
+	case EvictFIFO:
+		return "EvictFIFO"
+	case EvictRandom:
+		return "EvictRandom"
+	default:
+		return fmt.Sprintf("EvictionPolicy(%d)", int(policy))
+	}
+}
+
+// NewBoundedSet creates a new [BoundedSet] for elements of type E, which holds at most maxSize
+// elements, and evicts elements according to the given policy when full.
+// It must not be copied after first use.
+//
+// Panics if maxSize is less than 1.
+func NewBoundedSet[E comparable](maxSize int, evictionPolicy EvictionPolicy) BoundedSet[E] {
+	if maxSize < 1 {
+		panic(fmt.Sprintf("set: BoundedSet max size must be at least 1, got %d", maxSize))
+	}
+
+	return BoundedSet[E]{
+		maxSize:        maxSize,
+		evictionPolicy: evictionPolicy,
+		elements:       make(map[E]*list.Element),
+		order:          list.New(),
+	}
+}
+
+// MaxSize returns the maximum number of elements that the set holds before evicting elements.
+func (set BoundedSet[E]) MaxSize() int {
+	return set.maxSize
+}
+
+// EvictionPolicy returns the policy that the set uses to pick which element to evict when full.
+func (set BoundedSet[E]) EvictionPolicy() EvictionPolicy {
+	return set.evictionPolicy
+}
+
+// Add adds the given element to the set. If the set is full, an element is evicted first,
+// according to the set's eviction policy.
+//
+// If the element is already present in the set, nothing is added, but with [EvictLRU], the
+// element is marked as recently used.
+//
+// Panics if the set was not created with [NewBoundedSet].
+func (set *BoundedSet[E]) Add(element E) {
+	if set.order == nil {
+		panic("set: cannot add to zero-value BoundedSet, use NewBoundedSet to create it")
+	}
+
+	if listElement, contains := set.elements[element]; contains {
+		if set.evictionPolicy == EvictLRU {
+			set.order.MoveToBack(listElement)
+		}
+		return
+	}
+
+	if len(set.elements) >= set.maxSize {
+		set.evict()
+	}
+
+	set.elements[element] = set.order.PushBack(element)
+}
+
+// AddMultiple adds the given elements to the set. Duplicate elements are added only once, and
+// elements already present in the set are not added. If the set is full, elements are evicted
+// according to the set's eviction policy.
+func (set *BoundedSet[E]) AddMultiple(elements ...E) {
+	set.AddFromSlice(elements)
+}
+
+// AddFromSlice adds the elements from the given slice to the set. Duplicate elements are added only
+// once, and elements already present in the set are not added. If the set is full, elements are
+// evicted according to the set's eviction policy.
+func (set *BoundedSet[E]) AddFromSlice(elements []E) {
+	for _, element := range elements {
+		set.Add(element)
+	}
+}
+
+// AddFromSet adds elements from the given other set to the set. If the set is full, elements are
+// evicted according to the set's eviction policy.
+//
+// Unlike other set types, the set's backing map is not grown up front, since elements may be
+// evicted as they are added. Call [BoundedSet.Grow] first if the set is known to have room for
+// them.
+func (set *BoundedSet[E]) AddFromSet(otherSet ComparableSet[E]) {
+	for element := range otherSet.All() {
+		set.Add(element)
+	}
+}
+
+// AddFromSeq adds the elements from the given iterator to the set, without first collecting them
+// into a slice. If the set is full, elements are evicted according to the set's eviction policy.
+func (set *BoundedSet[E]) AddFromSeq(seq iter.Seq[E]) {
+	for element := range seq {
+		set.Add(element)
+	}
+}
+
+// Remove removes the given element from the set.
+// If the element is not present in the set, Remove is a no-op.
+func (set *BoundedSet[E]) Remove(element E) {
+	if listElement, contains := set.elements[element]; contains {
+		set.order.Remove(listElement)
+		delete(set.elements, element)
+	}
+}
+
+// Clear removes all elements from the set. The max size and eviction policy are retained.
+func (set *BoundedSet[E]) Clear() {
+	for element := range set.elements {
+		delete(set.elements, element)
+	}
+	if set.order != nil {
+		set.order.Init()
+	}
+}
+
+// Grow makes room for another n elements in the set's backing map, or as many as fit below its
+// max size. Since Go maps do not expose their capacity, Grow always reallocates the map (unless there
+// is no room to grow), so it should only be called ahead of adding many elements.
+//
+// Panics if n is negative.
+func (set *BoundedSet[E]) Grow(n int) {
+	checkGrowCount(n)
+
+	if remaining := set.maxSize - len(set.elements); n > remaining {
+		n = remaining
+	}
+	if n <= 0 {
+		return
+	}
+
+	grown := make(map[E]*list.Element, len(set.elements)+n)
+	for element, listElement := range set.elements {
+		grown[element] = listElement
+	}
+
+	set.elements = grown
+}
+
+// Contains checks if given element is present in the set. It does not mark the element as used for
+// [EvictLRU], so it is safe to call while other goroutines only read the set. To check for an
+// element and mark it as used, call [BoundedSet.Touch].
+func (set BoundedSet[E]) Contains(element E) bool {
+	_, contains := set.elements[element]
+	return contains
+}
+
+// Touch checks if given element is present in the set, like Contains, and if it is, marks it as
+// recently used for [EvictLRU]. With other eviction policies, Touch is the same as Contains.
+func (set *BoundedSet[E]) Touch(element E) bool {
+	listElement, contains := set.elements[element]
+	if contains && set.evictionPolicy == EvictLRU {
+		set.order.MoveToBack(listElement)
+	}
+	return contains
+}
+
+// Size returns the number of elements in the set.
+func (set BoundedSet[E]) Size() int {
+	return len(set.elements)
+}
+
+// IsEmpty checks if there are 0 elements in the set.
+func (set BoundedSet[E]) IsEmpty() bool {
+	return len(set.elements) == 0
+}
+
+// Equals checks if the set contains exactly the same elements as the other given set.
+func (set BoundedSet[E]) Equals(otherSet ComparableSet[E]) bool {
+	return set.Size() == otherSet.Size() && set.IsSubsetOf(otherSet)
+}
+
+// IsSubsetOf checks if all of the elements in the set exist in the other given set.
+func (set BoundedSet[E]) IsSubsetOf(otherSet ComparableSet[E]) bool {
+	for element := range set.elements {
+		if !otherSet.Contains(element) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsSupersetOf checks if the set contains all of the elements in the other given set.
+func (set BoundedSet[E]) IsSupersetOf(otherSet ComparableSet[E]) bool {
+	return otherSet.IsSubsetOf(set)
+}
+
+// Union creates a new set that contains all the elements of the receiver set and the other given
+// set. The underlying type of the returned set is a *BoundedSet with the same max size and eviction
+// policy as the receiver, so elements are evicted from the union if it exceeds the max size.
+func (set BoundedSet[E]) Union(otherSet ComparableSet[E]) Set[E] {
+	union := set.CopyBoundedSet()
+	union.AddFromSet(otherSet)
+	return &union
+}
+
+// Intersection creates a new set with only the elements that exist in both the receiver set and the
+// other given set. The underlying type of the returned set is a *BoundedSet with the same max size
+// and eviction policy as the receiver.
+func (set BoundedSet[E]) Intersection(otherSet ComparableSet[E]) Set[E] {
+	if set.order == nil {
+		return &BoundedSet[E]{}
+	}
+
+	intersection := NewBoundedSet[E](set.maxSize, set.evictionPolicy)
+
+	for listElement := set.front(); listElement != nil; listElement = listElement.Next() {
+		element := listElement.Value.(E)
+		if otherSet.Contains(element) {
+			intersection.Add(element)
+		}
+	}
+
+	return &intersection
+}
+
+// ToSlice creates a slice with all the elements in the set, ordered from the next element to be
+// evicted to the last (except for [EvictRandom], where the order is arbitrary).
+func (set BoundedSet[E]) ToSlice() []E {
+	slice := make([]E, 0, len(set.elements))
+
+	for listElement := set.front(); listElement != nil; listElement = listElement.Next() {
+		slice = append(slice, listElement.Value.(E))
+	}
+
+	return slice
+}
+
+// AppendTo appends the elements in the set to the given slice, and returns the extended slice,
+// like the append builtin. Elements are appended in the same order as in ToSlice.
+func (set BoundedSet[E]) AppendTo(slice []E) []E {
+	slice = slices.Grow(slice, len(set.elements))
+
+	for listElement := set.front(); listElement != nil; listElement = listElement.Next() {
+		slice = append(slice, listElement.Value.(E))
+	}
+
+	return slice
+}
+
+// ToMap creates a map with all the set's elements as keys.
+func (set BoundedSet[E]) ToMap() map[E]struct{} {
+	m := make(map[E]struct{}, len(set.elements))
+
+	for element := range set.elements {
+		m[element] = struct{}{}
+	}
+
+	return m
+}
+
+// Copy creates a new set with all the same elements, max size and eviction policy as the original
+// set. The underlying type of the returned set is a *BoundedSet - to get a value type, use
+// [BoundedSet.CopyBoundedSet] instead.
+func (set BoundedSet[E]) Copy() Set[E] {
+	newSet := set.CopyBoundedSet()
+	return &newSet
+}
+
+// CopyBoundedSet creates a new BoundedSet with all the same elements, max size and eviction policy
+// as the original set. Elements keep their order of eviction in the new set.
+func (set BoundedSet[E]) CopyBoundedSet() BoundedSet[E] {
+	if set.order == nil {
+		return BoundedSet[E]{}
+	}
+
+	newSet := NewBoundedSet[E](set.maxSize, set.evictionPolicy)
+
+	for listElement := set.front(); listElement != nil; listElement = listElement.Next() {
+		element := listElement.Value.(E)
+		newSet.elements[element] = newSet.order.PushBack(element)
+	}
+
+	return newSet
+}
+
+// String returns a string representation of the set, implementing [fmt.Stringer].
+//
+// Elements are printed in order from the next element to be evicted to the last (except for
+// [EvictRandom], where the order is arbitrary). A BoundedSet of elements 1, 2 and 3 will be printed
+// as: BoundedSet{1, 2, 3}
+func (set BoundedSet[E]) String() string {
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString("BoundedSet{")
+
+	for listElement := set.front(); listElement != nil; listElement = listElement.Next() {
+		fmt.Fprint(&stringBuilder, listElement.Value)
+
+		if listElement.Next() != nil {
+			stringBuilder.WriteString(", ")
+		}
+	}
+
+	stringBuilder.WriteByte('}')
+	return stringBuilder.String()
+}
+
+// All returns an iterator over the elements in the set, which can be used in a for-range loop:
+//
+//	for element := range set.All() {
+//		fmt.Println(element)
+//	}
+//
+// Elements are iterated in order from the next element to be evicted to the last (except for
+// [EvictRandom], where the order is arbitrary). Iteration does not mark elements as used.
+func (set BoundedSet[E]) All() iter.Seq[E] {
+	return func(yield func(element E) bool) {
+		for listElement := set.front(); listElement != nil; listElement = listElement.Next() {
+			if !yield(listElement.Value.(E)) {
+				break
+			}
+		}
+	}
+}
+
+// front returns the next element to be evicted, or nil if the set is empty (including the zero
+// value, where the list has not been initialized).
+func (set BoundedSet[E]) front() *list.Element {
+	if set.order == nil {
+		return nil
+	}
+	return set.order.Front()
+}
+
+func (set *BoundedSet[E]) evict() {
+	var element E
+
+	switch set.evictionPolicy {
+	case EvictRandom:
+		for element = range set.elements {
+			break
+		}
+	default:
+		element = set.front().Value.(E)
+	}
+
+	set.Remove(element)
+}
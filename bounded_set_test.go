@@ -0,0 +1,82 @@
+package set_test
+
+import (
+	"strings"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestBoundedSetEvictLRU(t *testing.T) {
+	set := set.NewBoundedSet[int](3, set.EvictLRU)
+	set.AddMultiple(1, 2, 3)
+
+	set.Add(1)
+	set.Touch(2)
+	set.Contains(3)
+	set.Add(4)
+
+	assertSize(t, set, 3)
+	assertContains(t, set, 1, 2, 4)
+	if set.Contains(3) {
+		t.Errorf("expected least recently used element 3 to be evicted from %v", set)
+	}
+}
+
+func TestBoundedSetEvictFIFO(t *testing.T) {
+	set := set.NewBoundedSet[int](3, set.EvictFIFO)
+	set.AddMultiple(1, 2, 3)
+
+	set.Add(1)
+	set.Touch(1)
+	set.Add(4)
+
+	assertSize(t, set, 3)
+	assertContains(t, set, 2, 3, 4)
+	if set.Contains(1) {
+		t.Errorf("expected first added element 1 to be evicted from %v", set)
+	}
+}
+
+func TestBoundedSetEvictRandom(t *testing.T) {
+	set := set.NewBoundedSet[int](3, set.EvictRandom)
+	set.AddMultiple(1, 2, 3, 4, 5)
+
+	assertSize(t, set, 3)
+	assertContains(t, set, 5)
+}
+
+func TestBoundedSetCopyKeepsEvictionOrder(t *testing.T) {
+	original := set.NewBoundedSet[int](3, set.EvictFIFO)
+	original.AddMultiple(1, 2, 3)
+
+	copied := original.CopyBoundedSet()
+	copied.Add(4)
+
+	assertContains(t, copied, 2, 3, 4)
+	assertContains(t, original, 1, 2, 3)
+}
+
+func TestBoundedSetZeroValue(t *testing.T) {
+	var zeroSet set.BoundedSet[int]
+
+	assertSize(t, zeroSet, 0)
+	if str := zeroSet.String(); str != "BoundedSet{}" {
+		t.Errorf("expected zero-value BoundedSet to print as BoundedSet{}, got %s", str)
+	}
+	for element := range zeroSet.All() {
+		t.Errorf("expected no elements in zero-value BoundedSet, got %d", element)
+	}
+	zeroSet.Clear()
+
+	defer func() {
+		message, _ := recover().(string)
+		if !strings.Contains(message, "NewBoundedSet") {
+			t.Errorf(
+				"expected Add on zero-value BoundedSet to panic mentioning NewBoundedSet, got %q",
+				message,
+			)
+		}
+	}()
+	zeroSet.Add(1)
+}
@@ -0,0 +1,288 @@
+package set
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// calibratedThresholds maps element types (reflect.Type) to the DynamicSet size thresholds
+// registered for them, either explicitly or through calibration.
+var calibratedThresholds sync.Map
+
+// calibrationSizes are the set sizes at which calibration compares ArraySet.Contains with
+// HashSet.Contains. The smallest size at which HashSet is faster becomes the size threshold.
+var calibrationSizes = []int{4, 8, 12, 16, 20, 24, 32, 48, 64, 96, 128}
+
+const (
+	// calibrationRounds is the number of times each element is looked up when measuring Contains
+	// cost for a given set size.
+	calibrationRounds = 64
+
+	// calibrationAttempts is the number of measurements taken for each set size, of which the
+	// fastest is used, to reduce noise from scheduling and GC.
+	calibrationAttempts = 3
+)
+
+// DynamicSetAutoTuned creates a new [DynamicSet] for elements of type E, with a size threshold
+// tuned for E, instead of the universal [DefaultDynamicSetSizeThreshold].
+// It must not be copied after first use.
+//
+// The threshold is taken from a previous call to [RegisterDynamicSetThreshold] or
+// [CalibrateDynamicSetThreshold] for E. If there was none, and E is a string, integer or float
+// type, the threshold is calibrated on first use by measuring Contains on ArraySets and HashSets of
+// generated elements, which takes around a millisecond. For other element types, the default
+// threshold is used. The result is cached, so later calls for the same E are cheap.
+func DynamicSetAutoTuned[E comparable]() DynamicSet[E] {
+	return DynamicSet[E]{
+		sizeThreshold: autoTunedSizeThreshold[E](),
+		array:         ArraySet[E]{elements: nil},
+		hash:          HashSet[E]{elements: nil},
+	}
+}
+
+// DynamicSetByElementSize creates a new [DynamicSet] for elements of type E, with a size threshold
+// chosen from the size of E in memory, instead of the universal [DefaultDynamicSetSizeThreshold].
+// It must not be copied after first use.
+//
+// ArraySet.Contains compares elements one by one, so the larger the elements, the sooner HashSet
+// becomes faster: elements of up to 8 bytes (such as ints) use the default threshold of 20, and
+// larger elements switch to a HashSet earlier, down to a threshold of 4 for elements above 64
+// bytes. Strings are treated as 16-byte elements, though comparing long strings with common
+// prefixes costs more. Unlike [DynamicSetAutoTuned], this takes no measurements, so it is free to
+// call, but it is only a rough estimate - for a threshold tuned to the element type on the current
+// machine, use DynamicSetAutoTuned or [MeasureDynamicSetThreshold].
+func DynamicSetByElementSize[E comparable]() DynamicSet[E] {
+	var zero E
+	return DynamicSet[E]{
+		sizeThreshold: sizeThresholdForElementSize(unsafe.Sizeof(zero)),
+		array:         ArraySet[E]{elements: nil},
+		hash:          HashSet[E]{elements: nil},
+	}
+}
+
+// sizeThresholdForElementSize returns the DynamicSet size threshold used by
+// DynamicSetByElementSize for elements of the given size in bytes. The thresholds are rounded
+// from calibration measurements of structs of different sizes.
+func sizeThresholdForElementSize(elementSize uintptr) int {
+	switch {
+	case elementSize <= 8:
+		return DefaultDynamicSetSizeThreshold
+	case elementSize <= 16:
+		return 16
+	case elementSize <= 32:
+		return 12
+	case elementSize <= 64:
+		return 8
+	default:
+		return 4
+	}
+}
+
+// RegisterDynamicSetThreshold registers the size threshold that [DynamicSetAutoTuned] uses for
+// element type E, replacing any previously registered or calibrated threshold. Use this to skip
+// calibration when the best threshold for E is already known, e.g. from benchmarks.
+//
+// Panics if sizeThreshold is less than 1.
+func RegisterDynamicSetThreshold[E comparable](sizeThreshold int) {
+	if sizeThreshold < 1 {
+		panic(fmt.Sprintf("set: DynamicSet size threshold must be at least 1, got %d", sizeThreshold))
+	}
+
+	calibratedThresholds.Store(reflect.TypeFor[E](), sizeThreshold)
+}
+
+// CalibrateDynamicSetThreshold measures the cost of Contains on ArraySets and HashSets of the given
+// sample elements, and registers the smallest size at which HashSet is faster as the size threshold
+// that [DynamicSetAutoTuned] uses for element type E. It returns the calibrated threshold.
+//
+// Use this for element types that DynamicSetAutoTuned cannot generate samples for, such as structs.
+// The samples should be representative of the elements that will be stored in the set. Duplicate
+// samples are ignored, and at most 128 samples are used. If HashSet is not faster at any of the
+// measured sizes, the threshold is set to the number of unique samples; if there are fewer than 4
+// unique samples, [DefaultDynamicSetSizeThreshold] is used.
+func CalibrateDynamicSetThreshold[E comparable](samples []E) int {
+	sizeThreshold := measureSizeThreshold(uniqueCalibrationSamples(samples))
+	calibratedThresholds.Store(reflect.TypeFor[E](), sizeThreshold)
+	return sizeThreshold
+}
+
+// A CalibrationReport is the result of [MeasureDynamicSetThreshold], with the cost of Contains on
+// ArraySets and HashSets at each measured set size.
+type CalibrationReport struct {
+	// SizeThreshold is the recommended DynamicSet size threshold: the smallest measured size at
+	// which HashSet.Contains was faster than ArraySet.Contains. It is chosen in the same way as by
+	// [CalibrateDynamicSetThreshold].
+	SizeThreshold int
+
+	// Measurements are the costs at each measured set size, in ascending order of size.
+	Measurements []CalibrationMeasurement
+}
+
+// A CalibrationMeasurement is the average cost of a single Contains call on an ArraySet and a
+// HashSet of the given size, as measured by [MeasureDynamicSetThreshold].
+type CalibrationMeasurement struct {
+	Size          int
+	ArrayContains time.Duration
+	HashContains  time.Duration
+}
+
+// MeasureDynamicSetThreshold measures the cost of Contains on ArraySets and HashSets of the given
+// sample elements, like [CalibrateDynamicSetThreshold], and reports the costs at every measured
+// size along with the recommended size threshold. Unlike CalibrateDynamicSetThreshold, it does not
+// stop at the first size where HashSet is faster, and does not register the threshold for
+// [DynamicSetAutoTuned]. Use this to inspect the crossover point on a given machine, e.g. to choose
+// a threshold for [DynamicSet.SetSizeThreshold] or [RegisterDynamicSetThreshold].
+//
+// Samples are handled as described on CalibrateDynamicSetThreshold.
+func MeasureDynamicSetThreshold[E comparable](samples []E) CalibrationReport {
+	samples = uniqueCalibrationSamples(samples)
+
+	report := CalibrationReport{SizeThreshold: 0, Measurements: nil}
+	for _, size := range calibrationSizes {
+		if size > len(samples) {
+			break
+		}
+
+		arrayCost, hashCost := measureCalibrationSize(samples[:size])
+		lookups := time.Duration(calibrationRounds * size)
+		report.Measurements = append(report.Measurements, CalibrationMeasurement{
+			Size:          size,
+			ArrayContains: arrayCost / lookups,
+			HashContains:  hashCost / lookups,
+		})
+
+		if report.SizeThreshold == 0 && hashCost < arrayCost {
+			report.SizeThreshold = size
+		}
+	}
+
+	if report.SizeThreshold == 0 {
+		if len(report.Measurements) == 0 {
+			report.SizeThreshold = DefaultDynamicSetSizeThreshold
+		} else {
+			report.SizeThreshold = report.Measurements[len(report.Measurements)-1].Size
+		}
+	}
+
+	return report
+}
+
+func autoTunedSizeThreshold[E comparable]() int {
+	elementType := reflect.TypeFor[E]()
+
+	if sizeThreshold, ok := calibratedThresholds.Load(elementType); ok {
+		return sizeThreshold.(int)
+	}
+
+	sizeThreshold := DefaultDynamicSetSizeThreshold
+	if samples, ok := generateCalibrationSamples[E](); ok {
+		sizeThreshold = measureSizeThreshold(samples)
+	}
+
+	// If another goroutine calibrated concurrently, we use its result, so that all DynamicSets for
+	// E get the same threshold.
+	actual, _ := calibratedThresholds.LoadOrStore(elementType, sizeThreshold)
+	return actual.(int)
+}
+
+func measureSizeThreshold[E comparable](samples []E) int {
+	measured := 0
+
+	for _, size := range calibrationSizes {
+		if size > len(samples) {
+			break
+		}
+		measured = size
+
+		arrayCost, hashCost := measureCalibrationSize(samples[:size])
+		if hashCost < arrayCost {
+			return size
+		}
+	}
+
+	if measured == 0 {
+		return DefaultDynamicSetSizeThreshold
+	}
+	return measured
+}
+
+// measureCalibrationSize measures the total cost of looking up each of the given elements
+// calibrationRounds times in an ArraySet and a HashSet of the elements.
+func measureCalibrationSize[E comparable](elements []E) (arrayCost, hashCost time.Duration) {
+	array := ArraySetFromSlice(elements)
+	hash := HashSetFromSlice(elements)
+
+	return measureContainsCost(array.Contains, elements), measureContainsCost(hash.Contains, elements)
+}
+
+func measureContainsCost[E comparable](contains func(element E) bool, elements []E) time.Duration {
+	var fastest time.Duration
+
+	for attempt := 0; attempt < calibrationAttempts; attempt++ {
+		start := time.Now()
+
+		for round := 0; round < calibrationRounds; round++ {
+			for _, element := range elements {
+				contains(element)
+			}
+		}
+
+		elapsed := time.Since(start)
+		if attempt == 0 || elapsed < fastest {
+			fastest = elapsed
+		}
+	}
+
+	return fastest
+}
+
+func uniqueCalibrationSamples[E comparable](samples []E) []E {
+	maxSamples := calibrationSizes[len(calibrationSizes)-1]
+
+	unique := HashSetWithCapacity[E](maxSamples)
+	uniqueSamples := make([]E, 0, maxSamples)
+
+	for _, sample := range samples {
+		if len(uniqueSamples) == maxSamples {
+			break
+		}
+
+		if !unique.Contains(sample) {
+			unique.Add(sample)
+			uniqueSamples = append(uniqueSamples, sample)
+		}
+	}
+
+	return uniqueSamples
+}
+
+// generateCalibrationSamples generates unique elements of type E for calibration, if E is a
+// string, integer or float type. Otherwise, it returns ok = false.
+func generateCalibrationSamples[E comparable]() (samples []E, ok bool) {
+	count := calibrationSizes[len(calibrationSizes)-1]
+	samples = make([]E, count)
+
+	for i := range samples {
+		value := reflect.ValueOf(&samples[i]).Elem()
+
+		switch value.Kind() {
+		case reflect.String:
+			// Uses a common prefix, so that comparisons do not end at the first byte.
+			value.SetString(fmt.Sprintf("calibration-sample-%d", i))
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			value.SetInt(int64(i))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Uintptr:
+			value.SetUint(uint64(i))
+		case reflect.Float32, reflect.Float64:
+			value.SetFloat(float64(i))
+		default:
+			return nil, false
+		}
+	}
+
+	return samples, true
+}
@@ -0,0 +1,93 @@
+package set_test
+
+import (
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestDynamicSetAutoTunedCalibratesOnFirstUse(t *testing.T) {
+	type calibratedInt int
+
+	dynamicSet := set.DynamicSetAutoTuned[calibratedInt]()
+	threshold := dynamicSet.SizeThreshold()
+	if threshold < 4 || threshold > 128 {
+		t.Errorf("expected calibrated threshold between 4 and 128, got %d", threshold)
+	}
+
+	if other := set.DynamicSetAutoTuned[calibratedInt](); other.SizeThreshold() != threshold {
+		t.Errorf(
+			"expected calibrated threshold %d to be reused, got %d",
+			threshold,
+			other.SizeThreshold(),
+		)
+	}
+}
+
+func TestDynamicSetAutoTunedUsesRegisteredThreshold(t *testing.T) {
+	type registeredString string
+
+	set.RegisterDynamicSetThreshold[registeredString](42)
+
+	dynamicSet := set.DynamicSetAutoTuned[registeredString]()
+	if threshold := dynamicSet.SizeThreshold(); threshold != 42 {
+		t.Errorf("expected registered threshold 42, got %d", threshold)
+	}
+}
+
+func TestDynamicSetAutoTunedFallsBackToDefault(t *testing.T) {
+	type unsupportedElement struct{ a, b int }
+
+	dynamicSet := set.DynamicSetAutoTuned[unsupportedElement]()
+	if threshold := dynamicSet.SizeThreshold(); threshold != set.DefaultDynamicSetSizeThreshold {
+		t.Errorf(
+			"expected default threshold %d for element type without generated samples, got %d",
+			set.DefaultDynamicSetSizeThreshold,
+			threshold,
+		)
+	}
+}
+
+func TestCalibrateDynamicSetThreshold(t *testing.T) {
+	threshold := set.CalibrateDynamicSetThreshold(createRandomStructSlice(200))
+	if threshold < 4 || threshold > 128 {
+		t.Errorf("expected calibrated threshold between 4 and 128, got %d", threshold)
+	}
+
+	dynamicSet := set.DynamicSetAutoTuned[testStruct]()
+	if dynamicSet.SizeThreshold() != threshold {
+		t.Errorf(
+			"expected DynamicSetAutoTuned to use calibrated threshold %d, got %d",
+			threshold,
+			dynamicSet.SizeThreshold(),
+		)
+	}
+
+	threshold = set.CalibrateDynamicSetThreshold([]bool{true, false, true})
+	if threshold != set.DefaultDynamicSetSizeThreshold {
+		t.Errorf("expected default threshold for too few samples, got %d", threshold)
+	}
+}
+
+func TestMeasureDynamicSetThreshold(t *testing.T) {
+	report := set.MeasureDynamicSetThreshold(createRandomIntSlice(200))
+
+	if report.SizeThreshold < 4 || report.SizeThreshold > 128 {
+		t.Errorf("expected recommended threshold between 4 and 128, got %d", report.SizeThreshold)
+	}
+	if len(report.Measurements) == 0 || report.Measurements[0].Size != 4 {
+		t.Errorf("expected measurements starting at size 4, got %v", report.Measurements)
+	}
+}
+
+func TestDynamicSetByElementSize(t *testing.T) {
+	if threshold := set.DynamicSetByElementSize[int]().SizeThreshold(); threshold !=
+		set.DefaultDynamicSetSizeThreshold {
+		t.Errorf("expected default threshold for int elements, got %d", threshold)
+	}
+
+	type largeStruct struct{ fields [16]int64 }
+	if threshold := set.DynamicSetByElementSize[largeStruct]().SizeThreshold(); threshold != 4 {
+		t.Errorf("expected threshold 4 for 128-byte elements, got %d", threshold)
+	}
+}
@@ -0,0 +1,106 @@
+// Command setbench measures the size at which HashSet.Contains becomes faster than
+// ArraySet.Contains on the current machine, and prints a recommended DynamicSet size threshold:
+//
+//	go run hermannm.dev/set/cmd/setbench -type struct48
+//
+// The -type flag selects the element type to measure: int, string, or a struct of 16, 48 or 128
+// bytes (struct16, struct48, struct128). To measure your own element type, call
+// [set.MeasureDynamicSetThreshold] with sample elements of that type, which is what this command
+// does for its built-in types.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"hermannm.dev/set"
+)
+
+const sampleCount = 128
+
+// Structs of different sizes, where only the last field differs between samples, so that
+// comparisons must look at the whole struct.
+type (
+	struct16 struct {
+		prefix int64
+		id     int64
+	}
+	struct48 struct {
+		prefix [5]int64
+		id     int64
+	}
+	struct128 struct {
+		prefix [15]int64
+		id     int64
+	}
+)
+
+func main() {
+	elementType := flag.String(
+		"type",
+		"int",
+		"element type: int, string, struct16, struct48 or struct128",
+	)
+	flag.Parse()
+
+	var report set.CalibrationReport
+	switch *elementType {
+	case "int":
+		report = set.MeasureDynamicSetThreshold(generateSamples(func(i int) int { return i }))
+	case "string":
+		report = set.MeasureDynamicSetThreshold(generateSamples(func(i int) string {
+			return "setbench-sample-" + strconv.Itoa(i)
+		}))
+	case "struct16":
+		report = set.MeasureDynamicSetThreshold(generateSamples(func(i int) struct16 {
+			return struct16{id: int64(i)}
+		}))
+	case "struct48":
+		report = set.MeasureDynamicSetThreshold(generateSamples(func(i int) struct48 {
+			return struct48{id: int64(i)}
+		}))
+	case "struct128":
+		report = set.MeasureDynamicSetThreshold(generateSamples(func(i int) struct128 {
+			return struct128{id: int64(i)}
+		}))
+	default:
+		fmt.Fprintf(os.Stderr, "setbench: unknown element type %q\n", *elementType)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	printReport(*elementType, report)
+}
+
+func generateSamples[E comparable](generate func(i int) E) []E {
+	samples := make([]E, sampleCount)
+	for i := range samples {
+		samples[i] = generate(i)
+	}
+	return samples
+}
+
+func printReport(elementType string, report set.CalibrationReport) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(writer, "Size\tArraySet.Contains\tHashSet.Contains\t")
+	for _, measurement := range report.Measurements {
+		fmt.Fprintf(
+			writer,
+			"%d\t%v\t%v\t\n",
+			measurement.Size,
+			measurement.ArrayContains,
+			measurement.HashContains,
+		)
+	}
+	writer.Flush()
+
+	fmt.Printf(
+		"\nRecommended DynamicSet size threshold for %s: %d (default is %d)\n",
+		elementType,
+		report.SizeThreshold,
+		set.DefaultDynamicSetSizeThreshold,
+	)
+}
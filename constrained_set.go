@@ -0,0 +1,302 @@
+package set
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// A ConstrainedSet wraps another [Set], and validates every element that is added to it. This keeps
+// domain invariants, such as non-empty strings or positive IDs, at the boundary of the set, instead
+// of checking them at every call site that adds to it.
+//
+// Elements are validated with the function given to [Constrain]. What the Set methods (Add,
+// AddFromSlice etc.) do with invalid elements is determined by the [InvalidElementPolicy] given to
+// Constrain. To get an error instead, use [ConstrainedSet.AddChecked] or
+// [ConstrainedSet.AddFromSliceChecked].
+//
+// ConstrainedSet is not safe for concurrent use - to make it so, pass it to [Synchronize]. The
+// wrapped set must not be modified directly after being passed to Constrain, as that would bypass
+// validation.
+//
+// ConstrainedSet implements [Set] and [ComparableSet] when passed by pointer.
+type ConstrainedSet[E comparable] struct {
+	inner    Set[E]
+	validate func(element E) error
+	policy   InvalidElementPolicy
+}
+
+// InvalidElementPolicy determines what the Set methods of a [ConstrainedSet] do when given an
+// element that fails validation.
+type InvalidElementPolicy int
+
+const (
+	// InvalidElementPanic makes the set panic with an [InvalidElementError], since invalid elements
+	// indicate a bug in the caller. Elements before the invalid one in a bulk add are still added.
+	InvalidElementPanic InvalidElementPolicy = iota
+
+	// InvalidElementSkip makes the set leave out invalid elements, and add the rest.
+	InvalidElementSkip
+)
+
+// String returns the name of the invalid element policy, implementing [fmt.Stringer].
+func (policy InvalidElementPolicy) String() string {
+	switch policy {
+	case InvalidElementPanic:
+		return "InvalidElementPanic"
+	case InvalidElementSkip:
+		return "InvalidElementSkip"
+	default:
+		return fmt.Sprintf("InvalidElementPolicy(%d)", int(policy))
+	}
+}
+
+// InvalidElementError is returned by [ConstrainedSet.AddChecked] and
+// [ConstrainedSet.AddFromSliceChecked], and used as the panic value with [InvalidElementPanic],
+// when an element fails validation. It wraps the error returned by the validation function.
+type InvalidElementError[E comparable] struct {
+	Element E
+	Err     error
+}
+
+// Error implements the error interface.
+func (err InvalidElementError[E]) Error() string {
+	return fmt.Sprintf("set: invalid element %v: %v", err.Element, err.Err)
+}
+
+// Unwrap returns the error from the validation function, for use with [errors.Is] and [errors.As].
+func (err InvalidElementError[E]) Unwrap() error {
+	return err.Err
+}
+
+// Constrain wraps the given set in a [ConstrainedSet], which validates added elements with the
+// given function, returning a non-nil error for invalid elements. Invalid elements given to the Set
+// methods are handled according to the given policy.
+//
+// Elements already in the set are not validated. The wrapped set must not be modified directly
+// afterwards, as that would bypass validation.
+func Constrain[E comparable](
+	set Set[E],
+	validate func(element E) error,
+	policy InvalidElementPolicy,
+) *ConstrainedSet[E] {
+	return &ConstrainedSet[E]{inner: set, validate: validate, policy: policy}
+}
+
+// Add adds the given element to the set, if it is valid. Invalid elements are handled according to
+// the set's [InvalidElementPolicy].
+// If the element is already present in the set, Add is a no-op.
+func (set *ConstrainedSet[E]) Add(element E) {
+	if set.check(element) {
+		set.inner.Add(element)
+	}
+}
+
+// AddChecked adds the given element to the set, or returns an [InvalidElementError] without adding
+// it if it is invalid. This ignores the set's [InvalidElementPolicy].
+func (set *ConstrainedSet[E]) AddChecked(element E) error {
+	if err := set.validate(element); err != nil {
+		return InvalidElementError[E]{Element: element, Err: err}
+	}
+
+	set.inner.Add(element)
+	return nil
+}
+
+// AddMultiple adds the given elements to the set. Duplicate elements are added only once, and
+// elements already present in the set are not added. Invalid elements are handled according to the
+// set's [InvalidElementPolicy].
+func (set *ConstrainedSet[E]) AddMultiple(elements ...E) {
+	set.AddFromSlice(elements)
+}
+
+// AddFromSlice adds the elements from the given slice to the set. Duplicate elements are added only
+// once, and elements already present in the set are not added. Invalid elements are handled
+// according to the set's [InvalidElementPolicy].
+func (set *ConstrainedSet[E]) AddFromSlice(elements []E) {
+	for _, element := range elements {
+		set.Add(element)
+	}
+}
+
+// AddFromSliceChecked adds the elements from the given slice to the set, if they are all valid.
+// Otherwise, it returns an [InvalidElementError] for the first invalid element, and adds none of
+// them. This ignores the set's [InvalidElementPolicy].
+func (set *ConstrainedSet[E]) AddFromSliceChecked(elements []E) error {
+	for _, element := range elements {
+		if err := set.validate(element); err != nil {
+			return InvalidElementError[E]{Element: element, Err: err}
+		}
+	}
+
+	set.inner.AddFromSlice(elements)
+	return nil
+}
+
+// AddFromSet adds elements from the given other set to the set. Invalid elements are handled
+// according to the set's [InvalidElementPolicy].
+func (set *ConstrainedSet[E]) AddFromSet(otherSet ComparableSet[E]) {
+	if set.isSameSet(otherSet) {
+		return
+	}
+
+	for element := range otherSet.All() {
+		set.Add(element)
+	}
+}
+
+// AddFromSeq adds the elements from the given iterator to the set. Invalid elements are handled
+// according to the set's [InvalidElementPolicy].
+func (set *ConstrainedSet[E]) AddFromSeq(seq iter.Seq[E]) {
+	for element := range seq {
+		set.Add(element)
+	}
+}
+
+// Remove removes the given element from the set.
+// If the element is not present in the set, Remove is a no-op.
+func (set *ConstrainedSet[E]) Remove(element E) {
+	set.inner.Remove(element)
+}
+
+// Clear removes all elements from the set.
+func (set *ConstrainedSet[E]) Clear() {
+	set.inner.Clear()
+}
+
+// Grow increases the set's capacity, if necessary, to guarantee room for another n elements.
+//
+// Panics if n is negative.
+func (set *ConstrainedSet[E]) Grow(n int) {
+	set.inner.Grow(n)
+}
+
+// Contains checks if given element is present in the set.
+func (set *ConstrainedSet[E]) Contains(element E) bool {
+	return set.inner.Contains(element)
+}
+
+// Size returns the number of elements in the set.
+func (set *ConstrainedSet[E]) Size() int {
+	return set.inner.Size()
+}
+
+// IsEmpty checks if there are 0 elements in the set.
+func (set *ConstrainedSet[E]) IsEmpty() bool {
+	return set.inner.IsEmpty()
+}
+
+// Equals checks if the set contains exactly the same elements as the other given set.
+func (set *ConstrainedSet[E]) Equals(otherSet ComparableSet[E]) bool {
+	if set.isSameSet(otherSet) {
+		return true
+	}
+	return set.inner.Equals(otherSet)
+}
+
+// IsSubsetOf checks if all of the elements in the set exist in the other given set.
+func (set *ConstrainedSet[E]) IsSubsetOf(otherSet ComparableSet[E]) bool {
+	if set.isSameSet(otherSet) {
+		return true
+	}
+	return set.inner.IsSubsetOf(otherSet)
+}
+
+// IsSupersetOf checks if the set contains all of the elements in the other given set.
+func (set *ConstrainedSet[E]) IsSupersetOf(otherSet ComparableSet[E]) bool {
+	if set.isSameSet(otherSet) {
+		return true
+	}
+	return otherSet.IsSubsetOf(set.inner)
+}
+
+// Union creates a new set that contains all the elements of the receiver set and the other given
+// set. The returned set is the result of Union on the wrapped set, and does not validate elements.
+func (set *ConstrainedSet[E]) Union(otherSet ComparableSet[E]) Set[E] {
+	if set.isSameSet(otherSet) {
+		return set.inner.Copy()
+	}
+	return set.inner.Union(otherSet)
+}
+
+// Intersection creates a new set with only the elements that exist in both the receiver set and the
+// other given set. The returned set is the result of Intersection on the wrapped set, and does not
+// validate elements.
+func (set *ConstrainedSet[E]) Intersection(otherSet ComparableSet[E]) Set[E] {
+	if set.isSameSet(otherSet) {
+		return set.inner.Copy()
+	}
+	return set.inner.Intersection(otherSet)
+}
+
+// ToSlice creates a slice with all the elements in the set.
+func (set *ConstrainedSet[E]) ToSlice() []E {
+	return set.inner.ToSlice()
+}
+
+// AppendTo appends the elements in the set to the given slice, and returns the extended slice,
+// like the append builtin. Unlike ToSlice, this lets the caller reuse a buffer across calls.
+func (set *ConstrainedSet[E]) AppendTo(slice []E) []E {
+	return set.inner.AppendTo(slice)
+}
+
+// ToMap creates a map with all the set's elements as keys.
+//
+// Unlike [HashSet.ToMap], the returned map is always a copy, since modifying the set's storage
+// directly would bypass validation.
+func (set *ConstrainedSet[E]) ToMap() map[E]struct{} {
+	m := make(map[E]struct{}, set.inner.Size())
+	for element := range set.inner.All() {
+		m[element] = struct{}{}
+	}
+	return m
+}
+
+// Copy creates a new set with all the same elements as the original set. The returned set is a
+// copy of the wrapped set, and does not validate elements.
+func (set *ConstrainedSet[E]) Copy() Set[E] {
+	return set.inner.Copy()
+}
+
+// String returns a string representation of the set, implementing [fmt.Stringer].
+//
+// The string is the string of the wrapped set, prefixed by "Constrained". A ConstrainedSet wrapping
+// an ArraySet of elements 1, 2 and 3 will be printed as: ConstrainedArraySet{1, 2, 3}
+func (set *ConstrainedSet[E]) String() string {
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString("Constrained")
+	stringBuilder.WriteString(set.inner.String())
+	return stringBuilder.String()
+}
+
+// All returns an iterator over the elements in the set, which can be used in a for-range loop:
+//
+//	for element := range set.All() {
+//		fmt.Println(element)
+//	}
+//
+// Since sets are unordered, iteration order is non-deterministic.
+func (set *ConstrainedSet[E]) All() iter.Seq[E] {
+	return set.inner.All()
+}
+
+// check validates the given element, and returns whether it should be added, handling invalid
+// elements according to the set's policy.
+func (set *ConstrainedSet[E]) check(element E) bool {
+	err := set.validate(element)
+	if err == nil {
+		return true
+	}
+
+	if set.policy == InvalidElementSkip {
+		return false
+	}
+	panic(InvalidElementError[E]{Element: element, Err: err})
+}
+
+// isSameSet checks if the given other set is the receiver itself, in which case bulk operations
+// must not iterate over the other set while modifying the receiver.
+func (set *ConstrainedSet[E]) isSameSet(otherSet ComparableSet[E]) bool {
+	other, ok := otherSet.(*ConstrainedSet[E])
+	return ok && other == set
+}
@@ -0,0 +1,99 @@
+package set_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+var errNotPositive = errors.New("must be positive")
+
+func validatePositive(element int) error {
+	if element <= 0 {
+		return errNotPositive
+	}
+	return nil
+}
+
+func TestConstrainedSetPanicsOnInvalidElement(t *testing.T) {
+	constrainedSet := set.Constrain[int](
+		&set.HashSet[int]{},
+		validatePositive,
+		set.InvalidElementPanic,
+	)
+	constrainedSet.AddMultiple(1, 2)
+
+	defer func() {
+		var invalidErr set.InvalidElementError[int]
+		err, _ := recover().(error)
+		if !errors.As(err, &invalidErr) || invalidErr.Element != -1 ||
+			!errors.Is(err, errNotPositive) {
+			t.Errorf("expected panic with InvalidElementError for -1, got %v", err)
+		}
+		assertSize(t, constrainedSet, 2)
+	}()
+
+	constrainedSet.Add(-1)
+}
+
+func TestConstrainedSetSkipsInvalidElements(t *testing.T) {
+	constrainedSet := set.Constrain[int](
+		&set.ArraySet[int]{},
+		validatePositive,
+		set.InvalidElementSkip,
+	)
+	constrainedSet.AddMultiple(1, -1, 2, 0)
+	constrainedSet.AddFromSet(set.HashSetOf(-5, 3))
+
+	assertSize(t, constrainedSet, 3)
+	assertContains(t, constrainedSet, 1, 2, 3)
+}
+
+func TestConstrainedSetChecked(t *testing.T) {
+	constrainedSet := set.Constrain[int](
+		&set.HashSet[int]{},
+		validatePositive,
+		set.InvalidElementPanic,
+	)
+
+	if err := constrainedSet.AddChecked(1); err != nil {
+		t.Fatalf("unexpected error from AddChecked: %v", err)
+	}
+	if err := constrainedSet.AddChecked(-1); !errors.Is(err, errNotPositive) {
+		t.Errorf("expected error from AddChecked(-1), got %v", err)
+	}
+
+	// None of the elements are added if any is invalid.
+	err := constrainedSet.AddFromSliceChecked([]int{2, -3, 4})
+	var invalidErr set.InvalidElementError[int]
+	if !errors.As(err, &invalidErr) || invalidErr.Element != -3 {
+		t.Errorf("expected InvalidElementError for -3 from AddFromSliceChecked, got %v", err)
+	}
+	assertSize(t, constrainedSet, 1)
+
+	if err := constrainedSet.AddFromSliceChecked([]int{2, 3}); err != nil {
+		t.Fatalf("unexpected error from AddFromSliceChecked: %v", err)
+	}
+	assertContains(t, constrainedSet, 1, 2, 3)
+}
+
+func TestConstrainFloatsRejectsNaN(t *testing.T) {
+	floats := set.ConstrainFloats[float64](&set.HashSet[float64]{})
+	floats.AddMultiple(1.5, 0, math.Copysign(0, -1))
+	assertSize(t, floats, 2)
+
+	err := floats.AddChecked(math.NaN())
+	if !errors.Is(err, set.ErrNaN) {
+		t.Errorf("expected ErrNaN from adding NaN, got %v", err)
+	}
+
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, set.ErrNaN) {
+			t.Errorf("expected panic with ErrNaN from adding NaN, got %v", err)
+		}
+		assertSize(t, floats, 2)
+	}()
+	floats.Add(math.NaN())
+}
@@ -0,0 +1,60 @@
+package set
+
+// ContainsBatch checks if each of the given elements is present in the given set, returning a slice
+// with the result for the element at each index. This is useful for probing a set with many
+// candidates at once, and is faster than calling Contains in a loop for some set types, as it can
+// avoid the overhead of calling Contains through the [ComparableSet] interface for each element.
+func ContainsBatch[E comparable](set ComparableSet[E], elements []E) []bool {
+	contained := make([]bool, len(elements))
+
+	switch set := set.(type) {
+	case HashSet[E]:
+		containsBatchMap(set.elements, elements, contained)
+	case *HashSet[E]:
+		containsBatchMap(set.elements, elements, contained)
+	default:
+		for i, element := range elements {
+			contained[i] = set.Contains(element)
+		}
+	}
+
+	return contained
+}
+
+// CountContained returns the number of the given elements that are present in the given set. Like
+// [ContainsBatch], this can be faster than calling Contains in a loop. Elements that occur multiple
+// times in the given slice are counted each time.
+func CountContained[E comparable](set ComparableSet[E], elements []E) int {
+	count := 0
+
+	switch set := set.(type) {
+	case HashSet[E]:
+		count = countContainedMap(set.elements, elements)
+	case *HashSet[E]:
+		count = countContainedMap(set.elements, elements)
+	default:
+		for _, element := range elements {
+			if set.Contains(element) {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+func containsBatchMap[E comparable](m map[E]struct{}, elements []E, contained []bool) {
+	for i, element := range elements {
+		_, contained[i] = m[element]
+	}
+}
+
+func countContainedMap[E comparable](m map[E]struct{}, elements []E) int {
+	count := 0
+	for _, element := range elements {
+		if _, ok := m[element]; ok {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,30 @@
+package set_test
+
+import (
+	"slices"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestContainsBatch(t *testing.T) {
+	elements := []int{0, 1, 2, 4, 3, 1}
+	expected := []bool{false, true, true, false, true, true}
+
+	check := func(intSet set.ComparableSet[int]) {
+		t.Helper()
+
+		if actual := set.ContainsBatch(intSet, elements); !slices.Equal(actual, expected) {
+			t.Errorf("expected ContainsBatch on %v to give %v, got %v", intSet, expected, actual)
+		}
+		if actual := set.CountContained(intSet, elements); actual != 4 {
+			t.Errorf("expected CountContained on %v to give 4, got %d", intSet, actual)
+		}
+	}
+
+	testAllSetTypes(func(intSet set.Set[int], setName string) {
+		intSet.AddMultiple(1, 2, 3)
+		check(intSet)
+	})
+	check(set.HashSetOf(1, 2, 3))
+}
@@ -0,0 +1,155 @@
+package set
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// contextCheckInterval is the number of elements that context-aware operations process between
+// checks for cancellation. Checking a context takes a lock, so checking for every element would
+// slow down operations that are not canceled.
+const contextCheckInterval = 1024
+
+// PartialResultError is returned by context-aware operations, such as [UnionContext], when their
+// context is canceled before they finish. The operation's result then contains only the elements
+// that were processed before cancellation. The error wraps the context's error, so
+// errors.Is(err, context.Canceled) and errors.Is(err, context.DeadlineExceeded) work as expected.
+type PartialResultError struct {
+	// Processed is the number of input elements that were processed before cancellation.
+	Processed int
+
+	// Err is the context's error.
+	Err error
+}
+
+// Error implements the error interface.
+func (err PartialResultError) Error() string {
+	return fmt.Sprintf("set: operation stopped after %d elements: %v", err.Processed, err.Err)
+}
+
+// Unwrap returns the context's error, for use with [errors.Is].
+func (err PartialResultError) Unwrap() error {
+	return err.Err
+}
+
+// AllContext returns an iterator over the elements in the given set, which stops early if the
+// given context is canceled. When stopping early, it yields the zero value and a
+// [PartialResultError] as its last pair. This lets iteration over a huge set be aborted, such as
+// when the request it serves is canceled:
+//
+//	for element, err := range set.AllContext(ctx, hugeSet) {
+//		if err != nil {
+//			return err
+//		}
+//		// ...
+//	}
+func AllContext[E comparable](ctx context.Context, set ComparableSet[E]) iter.Seq2[E, error] {
+	return func(yield func(element E, err error) bool) {
+		processed := 0
+		for element := range set.All() {
+			if err := checkContext(ctx, processed); err != nil {
+				var zero E
+				yield(zero, err)
+				return
+			}
+
+			if !yield(element, nil) {
+				return
+			}
+			processed++
+		}
+	}
+}
+
+// UnionContext adds all the elements of the given sets to the given destination set, like [Union]
+// but for any number of sets, and returns the destination. If the given context is canceled before
+// all elements have been added, it stops early and returns a [PartialResultError], leaving the
+// destination with the elements added so far.
+func UnionContext[E comparable, S Set[E]](
+	ctx context.Context,
+	dst S,
+	sets ...ComparableSet[E],
+) (S, error) {
+	processed := 0
+	for _, set := range sets {
+		for element := range set.All() {
+			if err := checkContext(ctx, processed); err != nil {
+				return dst, err
+			}
+
+			dst.Add(element)
+			processed++
+		}
+	}
+
+	return dst, nil
+}
+
+// IntersectionContext adds the elements that exist in both of the sets a and b to the given
+// destination set, like [Intersection], and returns the destination. If the given context is
+// canceled before it finishes, it stops early and returns a [PartialResultError], leaving the
+// destination with the elements added so far.
+func IntersectionContext[E comparable, S Set[E]](
+	ctx context.Context,
+	dst S,
+	a ComparableSet[E],
+	b ComparableSet[E],
+) (S, error) {
+	smaller, larger := a, b
+	if smaller.Size() > larger.Size() {
+		smaller, larger = larger, smaller
+	}
+
+	processed := 0
+	for element := range smaller.All() {
+		if err := checkContext(ctx, processed); err != nil {
+			return dst, err
+		}
+
+		if larger.Contains(element) {
+			dst.Add(element)
+		}
+		processed++
+	}
+
+	return dst, nil
+}
+
+// DifferenceContext adds the elements of set a that do not exist in set b to the given destination
+// set, like [Difference], and returns the destination. If the given context is canceled before it
+// finishes, it stops early and returns a [PartialResultError], leaving the destination with the
+// elements added so far.
+func DifferenceContext[E comparable, S Set[E]](
+	ctx context.Context,
+	dst S,
+	a ComparableSet[E],
+	b ComparableSet[E],
+) (S, error) {
+	processed := 0
+	for element := range a.All() {
+		if err := checkContext(ctx, processed); err != nil {
+			return dst, err
+		}
+
+		if !b.Contains(element) {
+			dst.Add(element)
+		}
+		processed++
+	}
+
+	return dst, nil
+}
+
+// checkContext returns a PartialResultError if the given context is done, checking only every
+// contextCheckInterval elements.
+func checkContext(ctx context.Context, processed int) error {
+	if processed%contextCheckInterval != 0 {
+		return nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return PartialResultError{Processed: processed, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package set_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestContextOperations(t *testing.T) {
+	a := set.HashSetFromSlice(createRandomIntSlice(5000))
+	b := set.HashSetFromSlice(createRandomIntSlice(5000))
+
+	union, err := set.UnionContext[int](context.Background(), &set.HashSet[int]{}, a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSize(t, union, set.UnionSize[int](a, b))
+
+	intersection, err := set.IntersectionContext[int](context.Background(), &set.HashSet[int]{}, a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSize(t, intersection, set.IntersectionSize[int](a, b))
+
+	difference, err := set.DifferenceContext[int](context.Background(), &set.HashSet[int]{}, a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSize(t, difference, set.DifferenceSize[int](a, b))
+
+	iterated := 0
+	for _, err := range set.AllContext[int](context.Background(), a) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		iterated++
+	}
+	if iterated != a.Size() {
+		t.Errorf("expected AllContext to iterate %d elements, got %d", a.Size(), iterated)
+	}
+}
+
+func TestContextOperationsCanceled(t *testing.T) {
+	a := set.HashSetFromSlice(createRandomIntSlice(5000))
+	b := set.HashSetFromSlice(createRandomIntSlice(5000))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, unionErr := set.UnionContext[int](ctx, &set.HashSet[int]{}, a, b)
+	_, intersectionErr := set.IntersectionContext[int](ctx, &set.HashSet[int]{}, a, b)
+	_, differenceErr := set.DifferenceContext[int](ctx, &set.HashSet[int]{}, a, b)
+
+	var iterationErr error
+	for _, err := range set.AllContext[int](ctx, a) {
+		iterationErr = err
+	}
+
+	for _, err := range []error{unionErr, intersectionErr, differenceErr, iterationErr} {
+		var partialErr set.PartialResultError
+		if !errors.Is(err, context.Canceled) || !errors.As(err, &partialErr) {
+			t.Errorf("expected PartialResultError wrapping context.Canceled, got %v", err)
+		}
+	}
+}
+
+func TestSpillSetContextCanceled(t *testing.T) {
+	spillSet, err := set.NewSpillSet[int](t.TempDir(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer spillSet.Close()
+
+	if _, err := spillSet.AddBatch(createRandomIntSlice(100)); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Looks up enough elements that some of them are in partitions that have been spilled to disk.
+	newElements := make([]int, 1000)
+	for i := range newElements {
+		newElements[i] = 1000 + i
+	}
+
+	added, err := spillSet.AddBatchContext(ctx, newElements)
+	if !errors.Is(err, context.Canceled) || len(added) != 0 {
+		t.Errorf("expected canceled AddBatchContext to add nothing, got %v, %v", added, err)
+	}
+	if _, err := spillSet.ContainsBatchContext(ctx, newElements); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected canceled ContainsBatchContext to fail, got %v", err)
+	}
+}
+
+func TestSpillSetContextCanceledInMemory(t *testing.T) {
+	spillSet, err := set.NewSpillSet[int](t.TempDir(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer spillSet.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Nothing has been spilled to disk, so no partition needs to be read.
+	added, err := spillSet.AddBatchContext(ctx, []int{1, 2, 3})
+	if !errors.Is(err, context.Canceled) || len(added) != 0 {
+		t.Errorf("expected canceled AddBatchContext to add nothing, got %v, %v", added, err)
+	}
+	if size := spillSet.Size(); size != 0 {
+		t.Errorf("expected canceled AddBatchContext to leave set empty, got size %d", size)
+	}
+}
@@ -0,0 +1,282 @@
+package set
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// A CopyOnWriteSet is a thread-safe collection of unique elements of type E, optimized for
+// read-heavy workloads. It holds an immutable [HashSet], which readers access without locking.
+// Writers copy the current set, modify the copy, and then atomically swap it in as the new
+// version. This makes reads as fast as on a plain HashSet, at the cost of every write copying the
+// whole set. To apply many changes with a single copy, use [CopyOnWriteSet.Update].
+//
+// The zero value for a CopyOnWriteSet is ready to use. It must not be copied after first use.
+//
+// CopyOnWriteSet implements [Set] and [ComparableSet] when passed by pointer.
+type CopyOnWriteSet[E comparable] struct {
+	snapshot   atomic.Pointer[HashSet[E]]
+	writerLock sync.Mutex
+}
+
+// NewCopyOnWriteSet creates a new [CopyOnWriteSet] for elements of type E.
+// It must not be copied after first use.
+func NewCopyOnWriteSet[E comparable]() *CopyOnWriteSet[E] {
+	return &CopyOnWriteSet[E]{}
+}
+
+// CopyOnWriteSetOf creates a new [CopyOnWriteSet] from the given elements.
+// It must not be copied after first use.
+// Duplicate elements are added only once.
+func CopyOnWriteSetOf[E comparable](elements ...E) *CopyOnWriteSet[E] {
+	return CopyOnWriteSetFromSlice(elements)
+}
+
+// CopyOnWriteSetFromSlice creates a new [CopyOnWriteSet] from the elements in the given slice.
+// It must not be copied after first use.
+// Duplicate elements in the slice are added only once.
+func CopyOnWriteSetFromSlice[E comparable](elements []E) *CopyOnWriteSet[E] {
+	hashSet := HashSetFromSlice(elements)
+
+	set := &CopyOnWriteSet[E]{}
+	set.snapshot.Store(&hashSet)
+	return set
+}
+
+// Update copies the current version of the set, calls the given function to modify the copy, and
+// then swaps in the copy as the new version. Use this to apply many changes at the cost of a single
+// copy.
+//
+// Concurrent writers wait for each other, while readers keep seeing the previous version until the
+// update completes. The given function must not keep a reference to the HashSet after returning.
+func (set *CopyOnWriteSet[E]) Update(update func(set *HashSet[E])) {
+	set.updateWithCapacity(0, update)
+}
+
+// updateWithCapacity is like Update, but gives the copy room for the given number of additional
+// elements, so that bulk adds do not rehash the copy.
+func (set *CopyOnWriteSet[E]) updateWithCapacity(
+	additionalCapacity int,
+	update func(set *HashSet[E]),
+) {
+	set.writerLock.Lock()
+	defer set.writerLock.Unlock()
+
+	current := set.load()
+	newVersion := HashSetWithCapacity[E](current.Size() + additionalCapacity)
+	for element := range current.elements {
+		newVersion.elements[element] = struct{}{}
+	}
+
+	update(&newVersion)
+	set.snapshot.Store(&newVersion)
+}
+
+// Add adds the given element to the set.
+// If the element is already present in the set, Add is a no-op, and does not copy the set.
+func (set *CopyOnWriteSet[E]) Add(element E) {
+	if set.Contains(element) {
+		return
+	}
+
+	set.Update(func(set *HashSet[E]) {
+		set.Add(element)
+	})
+}
+
+// AddMultiple adds the given elements to the set. Duplicate elements are added only once, and
+// elements already present in the set are not added.
+func (set *CopyOnWriteSet[E]) AddMultiple(elements ...E) {
+	set.AddFromSlice(elements)
+}
+
+// AddFromSlice adds the elements from the given slice to the set. Duplicate elements are added only
+// once, and elements already present in the set are not added.
+func (set *CopyOnWriteSet[E]) AddFromSlice(elements []E) {
+	set.updateWithCapacity(len(elements), func(set *HashSet[E]) {
+		for _, element := range elements {
+			set.Add(element)
+		}
+	})
+}
+
+// AddFromSet adds elements from the given other set to the set, at the cost of a single copy, with
+// room for the other set's elements.
+func (set *CopyOnWriteSet[E]) AddFromSet(otherSet ComparableSet[E]) {
+	set.updateWithCapacity(otherSet.Size(), func(set *HashSet[E]) {
+		for element := range otherSet.All() {
+			set.Add(element)
+		}
+	})
+}
+
+// AddFromSeq adds the elements from the given iterator to the set, at the cost of a single copy.
+// Other writers are blocked until the iterator is exhausted.
+func (set *CopyOnWriteSet[E]) AddFromSeq(seq iter.Seq[E]) {
+	set.Update(func(set *HashSet[E]) {
+		set.AddFromSeq(seq)
+	})
+}
+
+// Remove removes the given element from the set.
+// If the element is not present in the set, Remove is a no-op, and does not copy the set.
+func (set *CopyOnWriteSet[E]) Remove(element E) {
+	if !set.Contains(element) {
+		return
+	}
+
+	set.Update(func(set *HashSet[E]) {
+		set.Remove(element)
+	})
+}
+
+// Clear removes all elements from the set. Readers that are currently using the previous version
+// are unaffected.
+func (set *CopyOnWriteSet[E]) Clear() {
+	set.writerLock.Lock()
+	defer set.writerLock.Unlock()
+
+	set.snapshot.Store(nil)
+}
+
+// Grow is a no-op for a CopyOnWriteSet, since every write copies the set anyway. To add many
+// elements at the cost of a single copy, use AddFromSlice, AddFromSet or [CopyOnWriteSet.Update].
+//
+// Panics if n is negative.
+func (set *CopyOnWriteSet[E]) Grow(n int) {
+	checkGrowCount(n)
+}
+
+// Contains checks if given element is present in the set. It does not lock.
+func (set *CopyOnWriteSet[E]) Contains(element E) bool {
+	return set.load().Contains(element)
+}
+
+// Size returns the number of elements in the set.
+func (set *CopyOnWriteSet[E]) Size() int {
+	return set.load().Size()
+}
+
+// IsEmpty checks if there are 0 elements in the set.
+func (set *CopyOnWriteSet[E]) IsEmpty() bool {
+	return set.load().IsEmpty()
+}
+
+// Equals checks if the set contains exactly the same elements as the other given set.
+func (set *CopyOnWriteSet[E]) Equals(otherSet ComparableSet[E]) bool {
+	return set.load().Equals(otherSet)
+}
+
+// IsSubsetOf checks if all of the elements in the set exist in the other given set.
+func (set *CopyOnWriteSet[E]) IsSubsetOf(otherSet ComparableSet[E]) bool {
+	return set.load().IsSubsetOf(otherSet)
+}
+
+// IsSupersetOf checks if the set contains all of the elements in the other given set.
+func (set *CopyOnWriteSet[E]) IsSupersetOf(otherSet ComparableSet[E]) bool {
+	return otherSet.IsSubsetOf(set.load())
+}
+
+// Union creates a new set that contains all the elements of the receiver set and the other given
+// set. The underlying type of the returned set is a *CopyOnWriteSet.
+func (set *CopyOnWriteSet[E]) Union(otherSet ComparableSet[E]) Set[E] {
+	union := set.load().UnionHashSet(otherSet)
+
+	newSet := &CopyOnWriteSet[E]{}
+	newSet.snapshot.Store(&union)
+	return newSet
+}
+
+// Intersection creates a new set with only the elements that exist in both the receiver set and the
+// other given set. The underlying type of the returned set is a *CopyOnWriteSet.
+func (set *CopyOnWriteSet[E]) Intersection(otherSet ComparableSet[E]) Set[E] {
+	intersection := set.load().IntersectionHashSet(otherSet)
+
+	newSet := &CopyOnWriteSet[E]{}
+	newSet.snapshot.Store(&intersection)
+	return newSet
+}
+
+// ToSlice creates a slice with all the elements in the set.
+//
+// Since sets are unordered, the order of elements in the slice is non-deterministic, and may vary
+// even when called multiple times on the same set.
+func (set *CopyOnWriteSet[E]) ToSlice() []E {
+	return set.load().ToSlice()
+}
+
+// AppendTo appends the elements in the set to the given slice, and returns the extended slice,
+// like the append builtin. Unlike ToSlice, this lets the caller reuse a buffer across calls.
+func (set *CopyOnWriteSet[E]) AppendTo(slice []E) []E {
+	return set.load().AppendTo(slice)
+}
+
+// ToMap creates a map with all the set's elements as keys.
+//
+// Unlike [HashSet.ToMap], the returned map is a copy, since the current version of the set must
+// not be mutated.
+func (set *CopyOnWriteSet[E]) ToMap() map[E]struct{} {
+	return set.load().ToMapCopy()
+}
+
+// Copy creates a new set with all the same elements as the original set. Since versions of a
+// CopyOnWriteSet are immutable, the new set shares the current version with the original set, and
+// Copy does not copy any elements. The underlying type of the returned set is a *CopyOnWriteSet.
+func (set *CopyOnWriteSet[E]) Copy() Set[E] {
+	newSet := &CopyOnWriteSet[E]{}
+	newSet.snapshot.Store(set.snapshot.Load())
+	return newSet
+}
+
+// String returns a string representation of the set, implementing [fmt.Stringer].
+//
+// Since sets are unordered, the order of elements in the string may differ each time it is called.
+//
+// A CopyOnWriteSet of elements 1, 2 and 3 will be printed as: CopyOnWriteSet{1, 2, 3} (though the
+// order may vary).
+func (set *CopyOnWriteSet[E]) String() string {
+	snapshot := set.load()
+
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString("CopyOnWriteSet{")
+
+	i := 0
+	for element := range snapshot.elements {
+		fmt.Fprint(&stringBuilder, element)
+
+		if i < len(snapshot.elements)-1 {
+			stringBuilder.WriteString(", ")
+		}
+
+		i++
+	}
+
+	stringBuilder.WriteByte('}')
+	return stringBuilder.String()
+}
+
+// All returns an iterator over the elements in the set, which can be used in a for-range loop:
+//
+//	for element := range set.All() {
+//		fmt.Println(element)
+//	}
+//
+// Iteration uses the version of the set that was current when All was called, so it is unaffected
+// by concurrent writes: every element in that version is yielded exactly once, and the loop body
+// may modify the set without affecting the iteration. No lock is held during iteration.
+//
+// Since sets are unordered, iteration order is non-deterministic.
+func (set *CopyOnWriteSet[E]) All() iter.Seq[E] {
+	return set.load().All()
+}
+
+func (set *CopyOnWriteSet[E]) load() HashSet[E] {
+	snapshot := set.snapshot.Load()
+	if snapshot == nil {
+		return HashSet[E]{elements: nil}
+	}
+	return *snapshot
+}
@@ -0,0 +1,47 @@
+package set_test
+
+import (
+	"sync"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestCopyOnWriteSetConcurrentAccess(t *testing.T) {
+	set := set.CopyOnWriteSetOf(0)
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 10; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+			set.Add(i)
+		}(i)
+
+		go func() {
+			defer wg.Done()
+			if !set.Contains(0) {
+				t.Errorf("expected %v to contain 0 during concurrent writes", set)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assertSize(t, set, 11)
+}
+
+func TestCopyOnWriteSetUpdate(t *testing.T) {
+	cowSet := set.CopyOnWriteSetOf(1, 2, 3)
+	snapshot := cowSet.Copy()
+
+	cowSet.Update(func(hashSet *set.HashSet[int]) {
+		hashSet.Remove(1)
+		hashSet.AddMultiple(4, 5)
+	})
+
+	assertSize(t, cowSet, 4)
+	assertContains(t, cowSet, 2, 3, 4, 5)
+	assertSize(t, snapshot, 3)
+	assertContains(t, snapshot, 1, 2, 3)
+}
@@ -0,0 +1,533 @@
+package set
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// This file contains conflict-free replicated data types (CRDTs) for sets, for replicating set
+// membership between nodes without coordination. Each node modifies its own replica, and replicas
+// are synchronized by sending their state to other nodes (using MarshalBinary and UnmarshalBinary),
+// which merge it into their own replica. Merging is commutative, associative and idempotent, so all
+// replicas end up with the same elements once they have seen each other's changes, regardless of
+// the order in which states are merged, or whether a state is merged more than once.
+//
+// The types differ in what removals they support:
+//   - [GSet] only supports adding elements
+//   - [TwoPhaseSet] supports removing elements, but removed elements can never be added again
+//   - [ORSet] supports removing and re-adding elements, at the cost of more metadata
+//
+// The CRDT types do not implement [Set], since their methods for modification and merging differ
+// from the other set types.
+
+// A GSet (grow-only set) is a CRDT set where elements can only be added. Merging takes the union of
+// the replicas. See the comment at the top of crdt.go for how CRDTs are used.
+//
+// The zero value for a GSet is ready to use. It must not be copied after first use.
+type GSet[E comparable] struct {
+	elements HashSet[E]
+}
+
+// NewGSet creates a new [GSet] for elements of type E.
+// It must not be copied after first use.
+func NewGSet[E comparable]() GSet[E] {
+	return GSet[E]{elements: NewHashSet[E]()}
+}
+
+// Add adds the given element to the set.
+// If the element is already present in the set, Add is a no-op.
+func (set *GSet[E]) Add(element E) {
+	set.elements.Add(element)
+}
+
+// Merge adds the elements of the other given replica to the set.
+func (set *GSet[E]) Merge(other GSet[E]) {
+	set.elements.AddFromSet(other.elements)
+}
+
+// Contains checks if the given element is present in the set.
+func (set GSet[E]) Contains(element E) bool {
+	return set.elements.Contains(element)
+}
+
+// Size returns the number of elements in the set.
+func (set GSet[E]) Size() int {
+	return set.elements.Size()
+}
+
+// IsEmpty checks if there are 0 elements in the set.
+func (set GSet[E]) IsEmpty() bool {
+	return set.elements.IsEmpty()
+}
+
+// All returns an iterator over the elements in the set.
+//
+// Since sets are unordered, iteration order is non-deterministic.
+func (set GSet[E]) All() iter.Seq[E] {
+	return set.elements.All()
+}
+
+// ToSlice creates a slice with all the elements in the set.
+func (set GSet[E]) ToSlice() []E {
+	return set.elements.ToSlice()
+}
+
+// String returns a string representation of the set, implementing [fmt.Stringer].
+//
+// A GSet of elements 1, 2 and 3 will be printed as: GSet{1, 2, 3} (though the order may vary).
+func (set GSet[E]) String() string {
+	return formatCRDT("GSet", set.elements.All())
+}
+
+// MarshalBinary encodes the replica's state in the same format as [HashSet.MarshalBinary],
+// implementing [encoding.BinaryMarshaler].
+func (set GSet[E]) MarshalBinary() ([]byte, error) {
+	return set.elements.MarshalBinary()
+}
+
+// UnmarshalBinary replaces the replica's state with the state decoded from the given data, which
+// must be in the format produced by [GSet.MarshalBinary]. It implements
+// [encoding.BinaryUnmarshaler]. To combine the decoded state with the current state instead,
+// unmarshal into a new GSet, and pass it to Merge.
+func (set *GSet[E]) UnmarshalBinary(data []byte) error {
+	return set.elements.UnmarshalBinary(data)
+}
+
+// A TwoPhaseSet (2P-set) is a CRDT set where elements can be added and removed, but an element that
+// has been removed can never be added again. It consists of a set of added elements, and a set of
+// removed elements (tombstones), and merging takes the union of each. Removing an element wins over
+// concurrently adding it. See the comment at the top of crdt.go for how CRDTs are used.
+//
+// Removed elements are kept as tombstones, so the memory used by the set does not shrink when
+// removing elements.
+//
+// The zero value for a TwoPhaseSet is ready to use. It must not be copied after first use.
+type TwoPhaseSet[E comparable] struct {
+	added   HashSet[E]
+	removed HashSet[E]
+}
+
+// NewTwoPhaseSet creates a new [TwoPhaseSet] for elements of type E.
+// It must not be copied after first use.
+func NewTwoPhaseSet[E comparable]() TwoPhaseSet[E] {
+	return TwoPhaseSet[E]{added: NewHashSet[E](), removed: NewHashSet[E]()}
+}
+
+// Add adds the given element to the set. It returns false if the element was previously removed,
+// in which case it cannot be added again.
+func (set *TwoPhaseSet[E]) Add(element E) (added bool) {
+	if set.removed.Contains(element) {
+		return false
+	}
+
+	set.added.Add(element)
+	return true
+}
+
+// Remove removes the given element from the set, permanently. If the element is not present in the
+// set, Remove is a no-op.
+func (set *TwoPhaseSet[E]) Remove(element E) {
+	if set.added.Contains(element) {
+		set.removed.Add(element)
+	}
+}
+
+// Merge combines the added and removed elements of the other given replica with the set's own.
+func (set *TwoPhaseSet[E]) Merge(other TwoPhaseSet[E]) {
+	set.added.AddFromSet(other.added)
+	set.removed.AddFromSet(other.removed)
+}
+
+// Contains checks if the given element has been added to the set, and not removed.
+func (set TwoPhaseSet[E]) Contains(element E) bool {
+	return set.added.Contains(element) && !set.removed.Contains(element)
+}
+
+// Size returns the number of elements in the set, not counting removed elements.
+func (set TwoPhaseSet[E]) Size() int {
+	// Elements are only removed after being added, so removed is a subset of added.
+	return set.added.Size() - set.removed.Size()
+}
+
+// IsEmpty checks if there are 0 elements in the set.
+func (set TwoPhaseSet[E]) IsEmpty() bool {
+	return set.Size() == 0
+}
+
+// All returns an iterator over the elements in the set, not including removed elements.
+//
+// Since sets are unordered, iteration order is non-deterministic.
+func (set TwoPhaseSet[E]) All() iter.Seq[E] {
+	return func(yield func(element E) bool) {
+		for element := range set.added.All() {
+			if set.removed.Contains(element) {
+				continue
+			}
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice creates a slice with all the elements in the set, not including removed elements.
+func (set TwoPhaseSet[E]) ToSlice() []E {
+	slice := make([]E, 0, set.Size())
+	for element := range set.All() {
+		slice = append(slice, element)
+	}
+	return slice
+}
+
+// String returns a string representation of the set, implementing [fmt.Stringer]. Removed elements
+// are not included.
+//
+// A TwoPhaseSet of elements 1, 2 and 3 will be printed as: TwoPhaseSet{1, 2, 3} (though the order
+// may vary).
+func (set TwoPhaseSet[E]) String() string {
+	return formatCRDT("TwoPhaseSet", set.All())
+}
+
+// MarshalBinary encodes the replica's state, implementing [encoding.BinaryMarshaler]. The state is
+// the added and the removed elements, each as a uvarint length prefix followed by the elements in
+// the format of [HashSet.MarshalBinary].
+func (set TwoPhaseSet[E]) MarshalBinary() ([]byte, error) {
+	added, err := set.added.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	removed, err := set.removed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := binary.AppendUvarint(nil, uint64(len(added)))
+	buffer = append(buffer, added...)
+	buffer = binary.AppendUvarint(buffer, uint64(len(removed)))
+	return append(buffer, removed...), nil
+}
+
+// UnmarshalBinary replaces the replica's state with the state decoded from the given data, which
+// must be in the format produced by [TwoPhaseSet.MarshalBinary]. It implements
+// [encoding.BinaryUnmarshaler].
+func (set *TwoPhaseSet[E]) UnmarshalBinary(data []byte) error {
+	added, bytesRead, err := decodeLengthPrefixed(data)
+	if err != nil {
+		return fmt.Errorf("set: failed to unmarshal added elements: %w", err)
+	}
+	removed, removedBytesRead, err := decodeLengthPrefixed(data[bytesRead:])
+	if err != nil {
+		return fmt.Errorf("set: failed to unmarshal removed elements: %w", err)
+	}
+	if bytesRead+removedBytesRead != len(data) {
+		return errors.New("set: unexpected trailing bytes after TwoPhaseSet state")
+	}
+
+	var newState TwoPhaseSet[E]
+	if err := newState.added.UnmarshalBinary(added); err != nil {
+		return err
+	}
+	if err := newState.removed.UnmarshalBinary(removed); err != nil {
+		return err
+	}
+
+	*set = newState
+	return nil
+}
+
+// An ORSet (observed-remove set) is a CRDT set where elements can be added, removed and added
+// again. Every add is given a unique tag, and removing an element only removes the tags that the
+// replica has observed, so adding an element wins over concurrently removing it on another replica.
+// See the comment at the top of crdt.go for how CRDTs are used.
+//
+// Tags of removed elements are kept as tombstones, so that merging does not bring back removed
+// elements, and so the memory used by the set grows with the number of removals.
+//
+// An ORSet must be created with [NewORSet]. It must not be copied after first use.
+type ORSet[E comparable] struct {
+	replicaID  string
+	counter    uint64
+	elements   map[E]*HashSet[orSetTag]
+	tombstones HashSet[orSetTag]
+}
+
+// orSetTag uniquely identifies an add in an ORSet, by the replica that made it and a counter that
+// is incremented for every add on that replica.
+type orSetTag struct {
+	replicaID string
+	counter   uint64
+}
+
+// NewORSet creates a new [ORSet] for elements of type E, for the replica with the given ID. Every
+// replica must have a unique ID, since the ID is used to make tags for adds unique across replicas.
+// It must not be copied after first use.
+func NewORSet[E comparable](replicaID string) ORSet[E] {
+	return ORSet[E]{
+		replicaID:  replicaID,
+		counter:    0,
+		elements:   make(map[E]*HashSet[orSetTag]),
+		tombstones: NewHashSet[orSetTag](),
+	}
+}
+
+// ReplicaID returns the ID of the replica, as given to [NewORSet].
+func (set ORSet[E]) ReplicaID() string {
+	return set.replicaID
+}
+
+// Add adds the given element to the set, with a new tag.
+func (set *ORSet[E]) Add(element E) {
+	set.counter++
+	set.addTag(element, orSetTag{replicaID: set.replicaID, counter: set.counter})
+}
+
+// Remove removes the given element from the set, by removing all the tags for it that the replica
+// has observed. If the element is not present in the set, Remove is a no-op.
+func (set *ORSet[E]) Remove(element E) {
+	tags, ok := set.elements[element]
+	if !ok {
+		return
+	}
+
+	set.tombstones.AddFromSet(tags)
+	delete(set.elements, element)
+}
+
+// Merge combines the state of the other given replica with the set's own: elements are added with
+// the tags that the set has not removed, and tags removed by the other replica are removed.
+func (set *ORSet[E]) Merge(other ORSet[E]) {
+	set.tombstones.AddFromSet(other.tombstones)
+
+	for element, tags := range set.elements {
+		for tag := range tags.All() {
+			if other.tombstones.Contains(tag) {
+				tags.Remove(tag)
+			}
+		}
+		if tags.IsEmpty() {
+			delete(set.elements, element)
+		}
+	}
+
+	for element, otherTags := range other.elements {
+		for tag := range otherTags.All() {
+			if !set.tombstones.Contains(tag) {
+				set.addTag(element, tag)
+			}
+		}
+	}
+}
+
+// Contains checks if the given element is present in the set.
+func (set ORSet[E]) Contains(element E) bool {
+	_, ok := set.elements[element]
+	return ok
+}
+
+// Size returns the number of elements in the set.
+func (set ORSet[E]) Size() int {
+	return len(set.elements)
+}
+
+// IsEmpty checks if there are 0 elements in the set.
+func (set ORSet[E]) IsEmpty() bool {
+	return len(set.elements) == 0
+}
+
+// All returns an iterator over the elements in the set.
+//
+// Since sets are unordered, iteration order is non-deterministic.
+func (set ORSet[E]) All() iter.Seq[E] {
+	return func(yield func(element E) bool) {
+		for element := range set.elements {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice creates a slice with all the elements in the set.
+func (set ORSet[E]) ToSlice() []E {
+	slice := make([]E, 0, len(set.elements))
+	for element := range set.elements {
+		slice = append(slice, element)
+	}
+	return slice
+}
+
+// String returns a string representation of the set, implementing [fmt.Stringer].
+//
+// An ORSet of elements 1, 2 and 3 will be printed as: ORSet{1, 2, 3} (though the order may vary).
+func (set ORSet[E]) String() string {
+	return formatCRDT("ORSet", set.All())
+}
+
+// MarshalBinary encodes the replica's state, implementing [encoding.BinaryMarshaler]. The replica
+// ID is not included, so that the state can be unmarshaled by other replicas. The state is:
+//   - The tombstones, as a uvarint length prefix followed by the tags in the format of
+//     [HashSet.MarshalBinary]
+//   - The number of elements as a uvarint, then for each element, the element in the format of
+//     HashSet.MarshalBinary, followed by its tags like the tombstones
+//
+// Tags are encoded as the replica ID (uvarint length prefix followed by the ID) followed by the
+// counter as a uvarint.
+func (set ORSet[E]) MarshalBinary() ([]byte, error) {
+	codec, err := newBinaryElementCodec[E]()
+	if err != nil {
+		return nil, err
+	}
+
+	tombstones, err := set.tombstones.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	buffer := binary.AppendUvarint(nil, uint64(len(tombstones)))
+	buffer = append(buffer, tombstones...)
+
+	buffer = binary.AppendUvarint(buffer, uint64(len(set.elements)))
+	for element, tags := range set.elements {
+		buffer, err = codec.encode(buffer, element)
+		if err != nil {
+			return nil, fmt.Errorf("set: failed to marshal element: %w", err)
+		}
+
+		encodedTags, err := tags.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		buffer = binary.AppendUvarint(buffer, uint64(len(encodedTags)))
+		buffer = append(buffer, encodedTags...)
+	}
+
+	return buffer, nil
+}
+
+// UnmarshalBinary replaces the replica's state with the state decoded from the given data, which
+// must be in the format produced by [ORSet.MarshalBinary]. It implements
+// [encoding.BinaryUnmarshaler]. The replica ID is kept, and the replica's counter is raised above
+// the counters of its own tags in the decoded state, so that new adds get unique tags.
+func (set *ORSet[E]) UnmarshalBinary(data []byte) error {
+	codec, err := newBinaryElementCodec[E]()
+	if err != nil {
+		return err
+	}
+
+	newState := NewORSet[E](set.replicaID)
+	newState.counter = set.counter
+
+	tombstones, bytesRead, err := decodeLengthPrefixed(data)
+	if err != nil {
+		return fmt.Errorf("set: failed to unmarshal ORSet tombstones: %w", err)
+	}
+	if err := newState.tombstones.UnmarshalBinary(tombstones); err != nil {
+		return err
+	}
+	data = data[bytesRead:]
+
+	size, bytesRead := binary.Uvarint(data)
+	if bytesRead <= 0 {
+		return errors.New("set: failed to unmarshal ORSet size: invalid uvarint")
+	}
+	data = data[bytesRead:]
+
+	for i := uint64(0); i < size; i++ {
+		element, bytesRead, err := codec.decode(data)
+		if err != nil {
+			return fmt.Errorf("set: failed to unmarshal element %d: %w", i, err)
+		}
+		data = data[bytesRead:]
+
+		encodedTags, bytesRead, err := decodeLengthPrefixed(data)
+		if err != nil {
+			return fmt.Errorf("set: failed to unmarshal tags of element %d: %w", i, err)
+		}
+		data = data[bytesRead:]
+
+		var tags HashSet[orSetTag]
+		if err := tags.UnmarshalBinary(encodedTags); err != nil {
+			return err
+		}
+		for tag := range tags.All() {
+			newState.addTag(element, tag)
+		}
+	}
+
+	if len(data) != 0 {
+		return fmt.Errorf("set: %d unexpected trailing bytes after ORSet state", len(data))
+	}
+
+	for tag := range newState.tombstones.All() {
+		newState.observeTag(tag)
+	}
+
+	*set = newState
+	return nil
+}
+
+// addTag adds the given tag for the given element, and makes sure that the replica's counter is
+// above it if the tag is from this replica.
+func (set *ORSet[E]) addTag(element E, tag orSetTag) {
+	tags, ok := set.elements[element]
+	if !ok {
+		newTags := NewHashSet[orSetTag]()
+		tags = &newTags
+		set.elements[element] = tags
+	}
+
+	tags.Add(tag)
+	set.observeTag(tag)
+}
+
+func (set *ORSet[E]) observeTag(tag orSetTag) {
+	if tag.replicaID == set.replicaID {
+		set.counter = max(set.counter, tag.counter)
+	}
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler], so that tags can be encoded with the binary
+// set format.
+func (tag orSetTag) MarshalBinary() ([]byte, error) {
+	buffer := binary.AppendUvarint(nil, uint64(len(tag.replicaID)))
+	buffer = append(buffer, tag.replicaID...)
+	return binary.AppendUvarint(buffer, tag.counter), nil
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler], for decoding tags encoded by
+// MarshalBinary.
+func (tag *orSetTag) UnmarshalBinary(data []byte) error {
+	replicaID, bytesRead, err := decodeLengthPrefixed(data)
+	if err != nil {
+		return err
+	}
+
+	counter, counterBytesRead := binary.Uvarint(data[bytesRead:])
+	if counterBytesRead <= 0 || bytesRead+counterBytesRead != len(data) {
+		return errors.New("invalid ORSet tag counter")
+	}
+
+	tag.replicaID = string(replicaID)
+	tag.counter = counter
+	return nil
+}
+
+func formatCRDT[E comparable](typeName string, elements iter.Seq[E]) string {
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString(typeName)
+	stringBuilder.WriteByte('{')
+
+	first := true
+	for element := range elements {
+		if !first {
+			stringBuilder.WriteString(", ")
+		}
+		fmt.Fprint(&stringBuilder, element)
+		first = false
+	}
+
+	stringBuilder.WriteByte('}')
+	return stringBuilder.String()
+}
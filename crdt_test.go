@@ -0,0 +1,122 @@
+package set_test
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestGSet(t *testing.T) {
+	replica1 := set.NewGSet[int]()
+	replica2 := set.NewGSet[int]()
+	replica1.Add(1)
+	replica1.Add(2)
+	replica2.Add(2)
+	replica2.Add(3)
+
+	replica1.Merge(replica2)
+	replica1.Merge(replica2)
+	assertCRDTElements(t, replica1.ToSlice(), 1, 2, 3)
+
+	data, err := replica1.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded set.GSet[int]
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	assertCRDTElements(t, decoded.ToSlice(), 1, 2, 3)
+}
+
+func TestTwoPhaseSet(t *testing.T) {
+	replica1 := set.NewTwoPhaseSet[string]()
+	replica2 := set.NewTwoPhaseSet[string]()
+	replica1.Add("a")
+	replica1.Add("b")
+	replica2.Merge(replica1)
+
+	replica1.Remove("a")
+	replica2.Add("c")
+
+	replica1.Merge(replica2)
+	replica2.Merge(replica1)
+	assertCRDTElements(t, replica1.ToSlice(), "b", "c")
+	assertCRDTElements(t, replica2.ToSlice(), "b", "c")
+
+	if replica1.Add("a") {
+		t.Errorf("expected removed element to not be added again")
+	}
+	if replica1.Contains("a") || replica1.Size() != 2 {
+		t.Errorf("expected set without removed element, got %v", replica1)
+	}
+
+	data, err := replica1.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded set.TwoPhaseSet[string]
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	assertCRDTElements(t, decoded.ToSlice(), "b", "c")
+	if decoded.Add("a") {
+		t.Errorf("expected decoded set to keep removed elements")
+	}
+}
+
+func TestORSet(t *testing.T) {
+	replica1 := set.NewORSet[string]("replica1")
+	replica2 := set.NewORSet[string]("replica2")
+	replica1.Add("a")
+	replica1.Add("b")
+	replica2.Merge(replica1)
+
+	// Concurrent remove on replica1 and add on replica2: the add wins.
+	replica1.Remove("a")
+	replica2.Add("a")
+	replica2.Remove("b")
+
+	replica1.Merge(replica2)
+	replica2.Merge(replica1)
+	assertCRDTElements(t, replica1.ToSlice(), "a")
+	assertCRDTElements(t, replica2.ToSlice(), "a")
+
+	// Removed elements can be added again.
+	replica1.Add("b")
+	replica2.Merge(replica1)
+	assertCRDTElements(t, replica2.ToSlice(), "a", "b")
+
+	data, err := replica1.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := set.NewORSet[string]("replica1")
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	assertCRDTElements(t, decoded.ToSlice(), "a", "b")
+
+	// The decoded replica must not reuse tags of its earlier adds.
+	for range 3 {
+		decoded.Add("c")
+	}
+	decoded.Remove("c")
+	replica2.Merge(decoded)
+	assertCRDTElements(t, replica2.ToSlice(), "a", "b")
+
+	if err := decoded.UnmarshalBinary(data[:len(data)-1]); err == nil {
+		t.Errorf("expected error when unmarshaling truncated data")
+	}
+}
+
+func assertCRDTElements[E cmp.Ordered](t *testing.T, actual []E, expected ...E) {
+	t.Helper()
+
+	slices.Sort(actual)
+	if !slices.Equal(actual, expected) {
+		t.Errorf("expected elements %v, got %v", expected, actual)
+	}
+}
@@ -0,0 +1,279 @@
+package set
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/maphash"
+	"iter"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// A DeepSet is a collection of unique elements of type T, where elements are compared by deep
+// equality, as defined by [reflect.DeepEqual]. Unlike the other set types in this package, T may be
+// any type, including structs containing slices or maps, which cannot be used with == and so not as
+// elements of a [Set].
+//
+// Elements are stored in a hash table, keyed by a hash computed from the contents of each element,
+// so operations are O(1) on average, like for [HashSet]. However, both hashing and comparing
+// elements use reflection, so operations are much slower than for sets of comparable elements,
+// and cost in proportion to the size of each element. Nested values deeper than 16 levels do not
+// contribute to the hash.
+//
+// Since DeepEqual treats NaN floats as unequal to themselves, elements containing NaN are never
+// found by Contains or Remove, and are added again each time. Since elements are compared deeply,
+// they must not be modified after being added to the set.
+//
+// DeepSet does not implement [Set] or [ComparableSet], since their element type must be
+// comparable. The zero value for a DeepSet is ready to use. It must not be copied after first use.
+type DeepSet[T any] struct {
+	buckets map[uint64][]T
+	size    int
+	seed    maphash.Seed
+}
+
+// deepHashMaxDepth is the nesting depth below which values do not contribute to the hash of a
+// DeepSet element. This bounds the cost of hashing, and makes hashing of cyclic values terminate.
+// Values that are deeply equal are equal at every depth, so this keeps hashes consistent with
+// DeepEqual.
+const deepHashMaxDepth = 16
+
+// NewDeepSet creates a new [DeepSet] for elements of type T.
+// It must not be copied after first use.
+func NewDeepSet[T any]() DeepSet[T] {
+	return DeepSet[T]{buckets: make(map[uint64][]T), size: 0, seed: maphash.MakeSeed()}
+}
+
+// DeepSetOf creates a new [DeepSet] from the given elements.
+// It must not be copied after first use.
+// Deeply equal elements are added only once.
+func DeepSetOf[T any](elements ...T) DeepSet[T] {
+	set := NewDeepSet[T]()
+	for _, element := range elements {
+		set.Add(element)
+	}
+	return set
+}
+
+// Add adds the given element to the set.
+// If a deeply equal element is already present in the set, Add is a no-op.
+func (set *DeepSet[T]) Add(element T) {
+	if set.buckets == nil {
+		set.buckets = make(map[uint64][]T)
+		set.seed = maphash.MakeSeed()
+	}
+
+	hash := set.hash(element)
+	bucket := set.buckets[hash]
+	for _, candidate := range bucket {
+		if reflect.DeepEqual(candidate, element) {
+			return
+		}
+	}
+
+	set.buckets[hash] = append(bucket, element)
+	set.size++
+}
+
+// Remove removes the element that is deeply equal to the given element from the set.
+// If no such element is present in the set, Remove is a no-op.
+func (set *DeepSet[T]) Remove(element T) {
+	if set.size == 0 {
+		return
+	}
+
+	hash := set.hash(element)
+	bucket := set.buckets[hash]
+	for i, candidate := range bucket {
+		if reflect.DeepEqual(candidate, element) {
+			if len(bucket) == 1 {
+				delete(set.buckets, hash)
+			} else {
+				last := len(bucket) - 1
+				bucket[i] = bucket[last]
+				var zero T
+				bucket[last] = zero
+				set.buckets[hash] = bucket[:last]
+			}
+
+			set.size--
+			return
+		}
+	}
+}
+
+// Clear removes all elements from the set.
+func (set *DeepSet[T]) Clear() {
+	clear(set.buckets)
+	set.size = 0
+}
+
+// Contains checks if an element that is deeply equal to the given element is present in the set.
+func (set DeepSet[T]) Contains(element T) bool {
+	if set.size == 0 {
+		return false
+	}
+
+	for _, candidate := range set.buckets[set.hash(element)] {
+		if reflect.DeepEqual(candidate, element) {
+			return true
+		}
+	}
+	return false
+}
+
+// Size returns the number of elements in the set.
+func (set DeepSet[T]) Size() int {
+	return set.size
+}
+
+// IsEmpty checks if there are 0 elements in the set.
+func (set DeepSet[T]) IsEmpty() bool {
+	return set.size == 0
+}
+
+// ToSlice creates a slice with all the elements in the set.
+//
+// Since sets are unordered, the order of elements in the slice is non-deterministic, and may vary
+// even when called multiple times on the same set.
+func (set DeepSet[T]) ToSlice() []T {
+	slice := make([]T, 0, set.size)
+	for _, bucket := range set.buckets {
+		slice = append(slice, bucket...)
+	}
+	return slice
+}
+
+// All returns an iterator over the elements in the set, which can be used in a for-range loop:
+//
+//	for element := range set.All() {
+//		fmt.Println(element)
+//	}
+//
+// Since sets are unordered, iteration order is non-deterministic.
+func (set DeepSet[T]) All() iter.Seq[T] {
+	return func(yield func(element T) bool) {
+		for _, bucket := range set.buckets {
+			for _, element := range bucket {
+				if !yield(element) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// String returns a string representation of the set, implementing [fmt.Stringer].
+//
+// Since sets are unordered, the order of elements in the string may differ each time it is called.
+//
+// A DeepSet of elements [1 2] and [3] will be printed as: DeepSet{[1 2], [3]} (though the order may
+// vary).
+func (set DeepSet[T]) String() string {
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString("DeepSet{")
+
+	i := 0
+	for element := range set.All() {
+		fmt.Fprint(&stringBuilder, element)
+
+		if i < set.size-1 {
+			stringBuilder.WriteString(", ")
+		}
+
+		i++
+	}
+
+	stringBuilder.WriteByte('}')
+	return stringBuilder.String()
+}
+
+func (set DeepSet[T]) hash(element T) uint64 {
+	var hash maphash.Hash
+	hash.SetSeed(set.seed)
+	// Passing a pointer, so that interface element types are hashed by their dynamic value.
+	writeDeepHash(&hash, reflect.ValueOf(&element).Elem(), 0)
+	return hash.Sum64()
+}
+
+// writeDeepHash writes the contents of the given value to the hash, such that values that are
+// deeply equal by reflect.DeepEqual write the same bytes.
+func writeDeepHash(hash *maphash.Hash, value reflect.Value, depth int) {
+	if depth > deepHashMaxDepth {
+		return
+	}
+
+	hash.WriteByte(byte(value.Kind()))
+
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			hash.WriteByte(1)
+		} else {
+			hash.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint64(hash, uint64(value.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		writeUint64(hash, value.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat(hash, value.Float())
+	case reflect.Complex64, reflect.Complex128:
+		writeFloat(hash, real(value.Complex()))
+		writeFloat(hash, imag(value.Complex()))
+	case reflect.String:
+		hash.WriteString(value.String())
+	case reflect.Array, reflect.Slice:
+		writeUint64(hash, uint64(value.Len()))
+		for i := range value.Len() {
+			writeDeepHash(hash, value.Index(i), depth+1)
+		}
+	case reflect.Struct:
+		for i := range value.NumField() {
+			writeDeepHash(hash, value.Field(i), depth+1)
+		}
+	case reflect.Map:
+		// Map iteration order is random, so entries are hashed separately and combined with an
+		// order-independent sum.
+		writeUint64(hash, uint64(value.Len()))
+
+		var entriesSum uint64
+		iterator := value.MapRange()
+		for iterator.Next() {
+			var entryHash maphash.Hash
+			entryHash.SetSeed(hash.Seed())
+			writeDeepHash(&entryHash, iterator.Key(), depth+1)
+			writeDeepHash(&entryHash, iterator.Value(), depth+1)
+			entriesSum += entryHash.Sum64()
+		}
+		writeUint64(hash, entriesSum)
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			hash.WriteByte(0)
+		} else {
+			hash.WriteByte(1)
+			writeDeepHash(hash, value.Elem(), depth+1)
+		}
+	case reflect.Chan, reflect.UnsafePointer:
+		// Channels and unsafe pointers are deeply equal only if they are identical.
+		writeUint64(hash, uint64(value.Pointer()))
+	default:
+		// Functions are deeply equal only if both are nil, so they do not contribute to the hash.
+	}
+}
+
+func writeUint64(hash *maphash.Hash, value uint64) {
+	var buffer [8]byte
+	binary.LittleEndian.PutUint64(buffer[:], value)
+	hash.Write(buffer[:])
+}
+
+func writeFloat(hash *maphash.Hash, value float64) {
+	// 0.0 and -0.0 are equal, but have different bits.
+	if value == 0 {
+		value = 0
+	}
+	writeUint64(hash, math.Float64bits(value))
+}
@@ -0,0 +1,87 @@
+package set_test
+
+import (
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+type deepElement struct {
+	name   string
+	tags   []string
+	labels map[string]int
+	parent *deepElement
+}
+
+func TestDeepSet(t *testing.T) {
+	newElement := func() deepElement {
+		return deepElement{
+			name:   "a",
+			tags:   []string{"x", "y"},
+			labels: map[string]int{"one": 1, "two": 2, "three": 3},
+			parent: &deepElement{name: "root"},
+		}
+	}
+
+	deepSet := set.NewDeepSet[deepElement]()
+	deepSet.Add(newElement())
+	deepSet.Add(newElement())
+
+	if size := deepSet.Size(); size != 1 {
+		t.Errorf("expected deeply equal elements to be added once, got size %d", size)
+	}
+	if !deepSet.Contains(newElement()) {
+		t.Errorf("expected %v to contain deeply equal element", deepSet)
+	}
+
+	different := newElement()
+	different.labels["two"] = 22
+	if deepSet.Contains(different) {
+		t.Errorf("expected %v to not contain element with different map value", deepSet)
+	}
+	deepSet.Add(different)
+
+	different = newElement()
+	different.parent.name = "other root"
+	deepSet.Add(different)
+
+	if size := deepSet.Size(); size != 3 {
+		t.Errorf("expected 3 elements, got %d", size)
+	}
+
+	deepSet.Remove(newElement())
+	if deepSet.Contains(newElement()) || deepSet.Size() != 2 {
+		t.Errorf("expected element to be removed from %v", deepSet)
+	}
+}
+
+func TestDeepSetCyclicElements(t *testing.T) {
+	type node struct {
+		value int
+		next  *node
+	}
+
+	newCycle := func() *node {
+		first := &node{value: 1}
+		first.next = &node{value: 2, next: first}
+		return first
+	}
+
+	var deepSet set.DeepSet[*node]
+	deepSet.Add(newCycle())
+	deepSet.Add(newCycle())
+
+	if size := deepSet.Size(); size != 1 {
+		t.Errorf("expected deeply equal cyclic elements to be added once, got size %d", size)
+	}
+}
+
+func TestDeepSetFloatZero(t *testing.T) {
+	negativeZero := 0.0
+	negativeZero = -negativeZero
+
+	deepSet := set.DeepSetOf([]float64{0})
+	if !deepSet.Contains([]float64{negativeZero}) {
+		t.Errorf("expected %v to contain -0.0, which is equal to 0.0", deepSet)
+	}
+}
@@ -0,0 +1,330 @@
+package set
+
+import (
+	"bufio"
+	"bytes"
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// The delta format, written by MarshalDelta and WriteDelta, stores a set of integers compactly by
+// sorting the elements, and storing each element as its difference from the previous element:
+//   - A byte that is 0 for an empty set, and 1 otherwise
+//   - The first (smallest) element, as a zig-zag encoded varint for signed integer types, or as a
+//     uvarint for unsigned types
+//   - For each following element, its difference from the previous element as a uvarint (always 1
+//     or more, since the elements are unique)
+//   - A 0 byte marking the end of the set
+//
+// Since the differences between sorted IDs are typically much smaller than the IDs themselves,
+// most elements then take 1 or 2 bytes. The end is marked instead of storing the number of elements
+// up front, so that sets can be written as a stream without counting their elements first (see
+// MergeDelta).
+
+// MarshalDelta encodes the given set of integers in the compact delta format: the elements are
+// sorted, and each element is stored as a varint of its difference from the previous element. For
+// sets of IDs or other clustered integers, this is typically several times smaller than the format
+// of MarshalBinary. The set can be decoded with [UnmarshalDelta] or [ReadDelta].
+func MarshalDelta[E Integer](set ComparableSet[E]) []byte {
+	elements := set.ToSlice()
+	slices.Sort(elements)
+
+	var encoder deltaEncoder[E]
+	buffer := make([]byte, 0, len(elements)+2)
+	for _, element := range elements {
+		buffer = encoder.appendElement(buffer, element)
+	}
+	return encoder.appendEnd(buffer)
+}
+
+// WriteDelta writes the given set of integers to the given writer, in the delta format described
+// on [MarshalDelta].
+func WriteDelta[E Integer](writer io.Writer, set ComparableSet[E]) error {
+	if _, err := writer.Write(MarshalDelta(set)); err != nil {
+		return fmt.Errorf("set: failed to write delta-encoded set: %w", err)
+	}
+	return nil
+}
+
+// UnmarshalDelta clears the given set, and adds the elements decoded from the given data, which
+// must be in the delta format produced by [MarshalDelta]. If the data is invalid, the set is left
+// unchanged.
+func UnmarshalDelta[E Integer](data []byte, set Set[E]) error {
+	reader := bytes.NewReader(data)
+	decoder := newDeltaDecoder[E](reader)
+
+	var elements []E
+	for {
+		element, ok, err := decoder.next()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		elements = append(elements, element)
+	}
+
+	if reader.Len() != 0 {
+		return fmt.Errorf("set: %d unexpected trailing bytes after delta-encoded set", reader.Len())
+	}
+
+	set.Clear()
+	set.AddFromSlice(elements)
+	return nil
+}
+
+// ReadDelta reads a set of integers in the delta format (see [MarshalDelta]) from the given
+// reader, and adds its elements to the given set one by one as they are decoded. This avoids
+// reading the whole encoded set into memory first. If decoding fails partway through, the elements
+// decoded up to that point remain in the set.
+//
+// If the reader does not implement [io.ByteReader], it is wrapped in a [bufio.Reader], which may
+// read past the end of the set. To read several sets from one stream, pass a bufio.Reader.
+func ReadDelta[E Integer](reader io.Reader, set Set[E]) error {
+	decoder := newDeltaDecoder[E](asByteReader(reader))
+	for {
+		element, ok, err := decoder.next()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		set.Add(element)
+	}
+}
+
+// MergeDelta reads sets of integers in the delta format (see [MarshalDelta]) from the given
+// readers, and writes their union to the given writer in the same format. Since the elements of
+// each set are stored in ascending order, the sets are merged as streams, holding only the current
+// element of each reader in memory. This makes MergeDelta suitable for compacting many partial sets
+// on disk, such as the outputs of map-reduce style jobs, into a single set. To merge the sets into
+// an in-memory set instead, call [ReadDelta] for each reader.
+//
+// Readers that do not implement [io.ByteReader] are wrapped in a [bufio.Reader]. Writes are
+// buffered, so there is no need to wrap the writer.
+func MergeDelta[E Integer](writer io.Writer, readers ...io.Reader) error {
+	heads := make(deltaMergeHeap[E], 0, len(readers))
+	for i, reader := range readers {
+		decoder := newDeltaDecoder[E](asByteReader(reader))
+		element, ok, err := decoder.next()
+		if err != nil {
+			return fmt.Errorf("set: failed to merge set %d: %w", i, err)
+		}
+		if ok {
+			heads = append(heads, deltaMergeHead[E]{decoder: decoder, element: element, index: i})
+		}
+	}
+	heap.Init(&heads)
+
+	bufferedWriter := bufio.NewWriter(writer)
+	var encoder deltaEncoder[E]
+	var buffer []byte
+
+	for len(heads) > 0 {
+		head := &heads[0]
+
+		// Elements present in several sets come out of the heap one after another, so skipping the
+		// element written last is enough to deduplicate them.
+		if !encoder.started || head.element != encoder.previous {
+			buffer = encoder.appendElement(buffer[:0], head.element)
+			if _, err := bufferedWriter.Write(buffer); err != nil {
+				return fmt.Errorf("set: failed to write merged set: %w", err)
+			}
+		}
+
+		element, ok, err := head.decoder.next()
+		if err != nil {
+			return fmt.Errorf("set: failed to merge set %d: %w", head.index, err)
+		}
+		if ok {
+			head.element = element
+			heap.Fix(&heads, 0)
+		} else {
+			heap.Pop(&heads)
+		}
+	}
+
+	if _, err := bufferedWriter.Write(encoder.appendEnd(buffer[:0])); err != nil {
+		return fmt.Errorf("set: failed to write merged set: %w", err)
+	}
+	if err := bufferedWriter.Flush(); err != nil {
+		return fmt.Errorf("set: failed to write merged set: %w", err)
+	}
+	return nil
+}
+
+// deltaEncoder encodes elements in the delta format. Elements must be given in ascending order.
+type deltaEncoder[E Integer] struct {
+	previous E
+	started  bool
+}
+
+func (encoder *deltaEncoder[E]) appendElement(buffer []byte, element E) []byte {
+	if encoder.started {
+		buffer = binary.AppendUvarint(buffer, uint64(element)-uint64(encoder.previous))
+	} else {
+		encoder.started = true
+		buffer = append(buffer, 1)
+		if isSignedInteger[E]() {
+			buffer = binary.AppendVarint(buffer, int64(element))
+		} else {
+			buffer = binary.AppendUvarint(buffer, uint64(element))
+		}
+	}
+
+	encoder.previous = element
+	return buffer
+}
+
+// appendEnd appends the end of the set. For an empty set, the 0 byte marking an empty set is also
+// the end.
+func (encoder *deltaEncoder[E]) appendEnd(buffer []byte) []byte {
+	return append(buffer, 0)
+}
+
+// deltaDecoder decodes elements in the delta format, one at a time.
+type deltaDecoder[E Integer] struct {
+	reader   io.ByteReader
+	previous E
+	started  bool
+	done     bool
+}
+
+func newDeltaDecoder[E Integer](reader io.ByteReader) deltaDecoder[E] {
+	return deltaDecoder[E]{reader: reader}
+}
+
+// next returns the next element of the set, or ok = false if the end of the set has been reached.
+func (decoder *deltaDecoder[E]) next() (element E, ok bool, err error) {
+	if decoder.done {
+		return element, false, nil
+	}
+
+	if !decoder.started {
+		decoder.started = true
+		element, ok, err = decoder.readFirst()
+	} else {
+		element, ok, err = decoder.readNext()
+	}
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		decoder.done = true
+		return element, false, fmt.Errorf("set: failed to decode delta-encoded set: %w", err)
+	}
+	if !ok {
+		decoder.done = true
+		return element, false, nil
+	}
+
+	decoder.previous = element
+	return element, true, nil
+}
+
+func (decoder *deltaDecoder[E]) readFirst() (element E, ok bool, err error) {
+	flag, err := decoder.reader.ReadByte()
+	if err != nil {
+		return element, false, err
+	}
+	switch flag {
+	case 0:
+		return element, false, nil
+	case 1:
+	default:
+		return element, false, fmt.Errorf("invalid start byte %d", flag)
+	}
+
+	if isSignedInteger[E]() {
+		value, err := binary.ReadVarint(decoder.reader)
+		if err != nil {
+			return element, false, err
+		}
+		if element = E(value); int64(element) != value {
+			return element, false, fmt.Errorf("value %d overflows element type", value)
+		}
+	} else {
+		value, err := binary.ReadUvarint(decoder.reader)
+		if err != nil {
+			return element, false, err
+		}
+		if element = E(value); uint64(element) != value {
+			return element, false, fmt.Errorf("value %d overflows element type", value)
+		}
+	}
+
+	return element, true, nil
+}
+
+func (decoder *deltaDecoder[E]) readNext() (element E, ok bool, err error) {
+	delta, err := binary.ReadUvarint(decoder.reader)
+	if err != nil {
+		return element, false, err
+	}
+	if delta == 0 {
+		return element, false, nil
+	}
+
+	// The addition is done on the uint64 representation, which wraps around like the element type
+	// for negative values. The element overflowed if it does not convert back to the same value, or
+	// if it wrapped around to below the previous element.
+	value := uint64(decoder.previous) + delta
+	element = E(value)
+	if uint64(element) != value || element <= decoder.previous {
+		return element, false, fmt.Errorf("difference %d overflows element type", delta)
+	}
+
+	return element, true, nil
+}
+
+// deltaMergeHead is the current element of one of the sets merged by MergeDelta.
+type deltaMergeHead[E Integer] struct {
+	decoder deltaDecoder[E]
+	element E
+	// index is the position of the set's reader in the arguments to MergeDelta, for error messages.
+	index int
+}
+
+// deltaMergeHeap is a min-heap of the current elements of the sets merged by MergeDelta,
+// implementing [heap.Interface].
+type deltaMergeHeap[E Integer] []deltaMergeHead[E]
+
+func (heads deltaMergeHeap[E]) Len() int {
+	return len(heads)
+}
+
+func (heads deltaMergeHeap[E]) Less(i, j int) bool {
+	return heads[i].element < heads[j].element
+}
+
+func (heads deltaMergeHeap[E]) Swap(i, j int) {
+	heads[i], heads[j] = heads[j], heads[i]
+}
+
+func (heads *deltaMergeHeap[E]) Push(head any) {
+	*heads = append(*heads, head.(deltaMergeHead[E]))
+}
+
+func (heads *deltaMergeHeap[E]) Pop() any {
+	last := (*heads)[len(*heads)-1]
+	*heads = (*heads)[:len(*heads)-1]
+	return last
+}
+
+func isSignedInteger[E Integer]() bool {
+	var zero E
+	return zero-1 < zero
+}
+
+func asByteReader(reader io.Reader) io.ByteReader {
+	if byteReader, ok := reader.(io.ByteReader); ok {
+		return byteReader
+	}
+	return bufio.NewReader(reader)
+}
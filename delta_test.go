@@ -0,0 +1,158 @@
+package set_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestDeltaRoundTrip(t *testing.T) {
+	testDeltaRoundTrip(t, set.HashSetFromSlice(createRandomIntSlice(1000)))
+	testDeltaRoundTrip(t, set.ArraySetOf[int8](-128, -1, 0, 1, 127))
+	testDeltaRoundTrip(t, set.ArraySetOf[uint64](0, 1, math.MaxUint64))
+	testDeltaRoundTrip(t, set.ArraySetOf[int64](math.MinInt64, 0, math.MaxInt64))
+	testDeltaRoundTrip(t, set.NewArraySet[uint16]())
+}
+
+func testDeltaRoundTrip[E set.Integer, S set.ComparableSet[E]](t *testing.T, original S) {
+	t.Helper()
+
+	data := set.MarshalDelta[E](original)
+
+	decoded := set.NewHashSet[E]()
+	if err := set.UnmarshalDelta(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal %v: %v", original, err)
+	}
+	if !decoded.Equals(original) {
+		t.Errorf("expected %v to equal %v after delta round trip", decoded, original)
+	}
+
+	var buffer bytes.Buffer
+	if err := set.WriteDelta[E](&buffer, original); err != nil {
+		t.Fatal(err)
+	}
+	read := set.NewHashSet[E]()
+	if err := set.ReadDelta(&buffer, &read); err != nil {
+		t.Fatalf("failed to read %v: %v", original, err)
+	}
+	if !read.Equals(original) {
+		t.Errorf("expected %v to equal %v after delta read", read, original)
+	}
+}
+
+func TestDeltaIsCompact(t *testing.T) {
+	ids := set.NewHashSet[uint64]()
+	for i := range uint64(10_000) {
+		ids.Add(1_000_000_000 + i*3)
+	}
+
+	deltaSize := len(set.MarshalDelta[uint64](ids))
+	binaryData, err := ids.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deltaSize*4 > len(binaryData) {
+		t.Errorf("expected delta encoding (%d bytes) to be a fraction of binary (%d bytes)",
+			deltaSize, len(binaryData))
+	}
+}
+
+func TestDeltaInvalidData(t *testing.T) {
+	data := set.MarshalDelta[int](set.ArraySetOf(1, 2, 3))
+
+	target := set.ArraySetOf(4)
+	for _, invalid := range [][]byte{data[:len(data)-1], append(data, 0), {2}, {1, 2, 200}} {
+		if err := set.UnmarshalDelta(invalid, &target); err == nil {
+			t.Errorf("expected error when unmarshaling invalid data %v", invalid)
+		}
+	}
+	assertSize(t, &target, 1)
+	assertContains(t, &target, 4)
+
+	overflowing := set.MarshalDelta[int](set.ArraySetOf(0, 300))
+	var int8Set set.ArraySet[int8]
+	if err := set.UnmarshalDelta(overflowing, &int8Set); err == nil {
+		t.Errorf("expected error when unmarshaling elements that overflow int8")
+	}
+}
+
+func TestReadDeltaMultipleSets(t *testing.T) {
+	var buffer bytes.Buffer
+	for _, ints := range []set.ArraySet[int]{set.ArraySetOf(1, 2), set.ArraySetOf(3)} {
+		if err := set.WriteDelta[int](&buffer, ints); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reader := bufio.NewReader(&buffer)
+	first := set.NewArraySet[int]()
+	second := set.NewArraySet[int]()
+	if err := set.ReadDelta(reader, &first); err != nil {
+		t.Fatal(err)
+	}
+	if err := set.ReadDelta(reader, &second); err != nil {
+		t.Fatal(err)
+	}
+
+	assertSize(t, &first, 2)
+	assertContains(t, &first, 1, 2)
+	assertSize(t, &second, 1)
+	assertContains(t, &second, 3)
+}
+
+func TestMergeDelta(t *testing.T) {
+	union := set.NewHashSet[int]()
+	var inputs []io.Reader
+	for range 5 {
+		partial := set.HashSetFromSlice(createRandomIntSlice(200))
+		partial.Add(-5)
+		union.AddFromSet(partial)
+
+		inputs = append(inputs, bytes.NewReader(set.MarshalDelta[int](partial)))
+	}
+	inputs = append(inputs, bytes.NewReader(set.MarshalDelta[int](set.NewHashSet[int]())))
+
+	var output bytes.Buffer
+	if err := set.MergeDelta[int](&output, inputs...); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(output.Bytes(), set.MarshalDelta[int](union)) {
+		t.Errorf("expected merged sets to be encoded like their union")
+	}
+	merged := set.NewHashSet[int]()
+	if err := set.UnmarshalDelta(output.Bytes(), &merged); err != nil {
+		t.Fatal(err)
+	}
+	if !merged.Equals(union) {
+		t.Errorf("expected merged set %v to equal union %v", merged, union)
+	}
+}
+
+func TestMergeDeltaNoSets(t *testing.T) {
+	var output bytes.Buffer
+	if err := set.MergeDelta[uint32](&output); err != nil {
+		t.Fatal(err)
+	}
+
+	merged := set.ArraySetOf[uint32](1)
+	if err := set.UnmarshalDelta(output.Bytes(), &merged); err != nil {
+		t.Fatal(err)
+	}
+	assertSize(t, &merged, 0)
+}
+
+func TestMergeDeltaInvalidData(t *testing.T) {
+	valid := set.MarshalDelta[int](set.ArraySetOf(1, 2, 3))
+	truncated := valid[:len(valid)-1]
+
+	err := set.MergeDelta[int](io.Discard, bytes.NewReader(valid), bytes.NewReader(truncated))
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected unexpected EOF error when merging truncated set, got %v", err)
+	}
+}
@@ -1,7 +1,9 @@
 package set
 
 import (
+	"database/sql/driver"
 	"fmt"
+	"iter"
 	"strings"
 )
 
@@ -10,21 +12,47 @@ import (
 // threshold, it transforms itself to a [HashSet], optimized for large sets.
 //
 // The size threshold defaults to [DefaultDynamicSetSizeThreshold], but can be customized with
-// [DynamicSet.SetSizeThreshold].
+// [DynamicSet.SetSizeThreshold]. When elements are removed from a DynamicSet that has transformed
+// to a HashSet, it transforms back to an ArraySet once its size falls to the downgrade threshold,
+// which defaults to half the size threshold, but can be customized with
+// [DynamicSet.SetDowngradeThreshold], or disabled with [DynamicSet.SetDowngradeEnabled]. To make a
+// burst of changes with at most one transformation at the end, see [DynamicSet.BeginBatch].
 //
-// The zero value for a DynamicSet is ready to use. It must not be copied after first use.
+// The zero value for a DynamicSet is ready to use. It must not be copied after first use, since the
+// copy would share internal storage with the original, so that mutating one could corrupt the
+// other. To get an independent copy, use [DynamicSet.CopyDynamicSet].
 //
 // DynamicSet implements [Set] when passed by pointer, and [ComparableSet] when passed by value.
 type DynamicSet[E comparable] struct {
-	sizeThreshold int
-	array         ArraySet[E]
-	hash          HashSet[E]
+	sizeThreshold      int
+	downgradeThreshold int
+	downgradeDisabled  bool
+	transformations    int
+	onTransform        func(transformation DynamicSetTransformation)
+	batchDepth         int
+	array              ArraySet[E]
+	hash               HashSet[E]
+}
+
+// DynamicSetTransformation describes a [DynamicSet] transforming between an ArraySet and a HashSet,
+// passed to the callback registered with [DynamicSet.SetOnTransform].
+type DynamicSetTransformation struct {
+	// ToHashSet is true if the set transformed from an ArraySet to a HashSet, and false if it
+	// transformed from a HashSet back to an ArraySet.
+	ToHashSet bool
+
+	// Size is the number of elements in the set when it transformed.
+	Size int
+
+	// Count is the number of times the set has transformed, including this transformation.
+	Count int
 }
 
 // DefaultDynamicSetSizeThreshold is the default size at which a DynamicSet will transform from an
 // ArraySet to a HashSet. From the benchmarks in benchmark_test.go, it appears that 20 elements is
 // around where HashSet.Contains performs better than ArraySet.Contains, though this varies by the
-// element type of the set.
+// element type of the set. To use a threshold tuned for the element type, see
+// [DynamicSetAutoTuned] and [DynamicSetByElementSize].
 const DefaultDynamicSetSizeThreshold = 20
 
 // NewDynamicSet creates a new [DynamicSet] for elements of type E.
@@ -104,6 +132,61 @@ func (set *DynamicSet[E]) SetSizeThreshold(sizeThreshold int) {
 	}
 }
 
+// DowngradeThreshold returns the size at or below which the DynamicSet will transform from a
+// HashSet back to an ArraySet, when removing elements. It defaults to half the size threshold.
+func (set DynamicSet[E]) DowngradeThreshold() int {
+	sizeThreshold := set.SizeThreshold()
+
+	if set.downgradeThreshold == 0 || set.downgradeThreshold >= sizeThreshold {
+		return sizeThreshold / 2
+	} else {
+		return set.downgradeThreshold
+	}
+}
+
+// SetDowngradeThreshold sets the size at or below which the DynamicSet will transform from a
+// HashSet back to an ArraySet, when removing elements. Keeping a gap between this and the size
+// threshold avoids repeated transformations for sets whose size oscillates around the size
+// threshold.
+//
+// A downgrade threshold of 0 is ignored. A downgrade threshold at or above the size threshold is
+// not allowed, as the set would then transform back and forth on every add and remove - in that
+// case, the default of half the size threshold is used.
+//
+// If the set is a HashSet at or below the given downgrade threshold, it transforms to an ArraySet
+// immediately.
+func (set *DynamicSet[E]) SetDowngradeThreshold(downgradeThreshold int) {
+	if downgradeThreshold == 0 {
+		return
+	}
+
+	set.downgradeThreshold = downgradeThreshold
+
+	if set.IsHashSet() && set.hashSetReachedThreshold() {
+		set.transformToArraySet()
+	}
+}
+
+// DowngradeEnabled checks if the DynamicSet will transform from a HashSet back to an ArraySet when
+// removing elements brings it down to its downgrade threshold. This is enabled by default.
+func (set DynamicSet[E]) DowngradeEnabled() bool {
+	return !set.downgradeDisabled
+}
+
+// SetDowngradeEnabled sets whether the DynamicSet will transform from a HashSet back to an ArraySet
+// when removing elements brings it down to its downgrade threshold. Disabling this means that once
+// the set has become a HashSet, it stays a HashSet (including when cleared), which avoids the
+// overhead of transforming back and forth for sets that repeatedly shrink and grow.
+//
+// Calling [DynamicSet.SetSizeThreshold] may still transform the set to an ArraySet.
+func (set *DynamicSet[E]) SetDowngradeEnabled(enabled bool) {
+	set.downgradeDisabled = !enabled
+
+	if enabled && set.IsHashSet() && set.hashSetReachedThreshold() {
+		set.transformToArraySet()
+	}
+}
+
 // Add adds the given element to the set.
 // If the element is already present in the set, Add is a no-op.
 //
@@ -113,7 +196,7 @@ func (set *DynamicSet[E]) Add(element E) {
 	if set.IsArraySet() {
 		set.array.Add(element)
 
-		if set.arraySetReachedThreshold() {
+		if !set.inBatch() && set.arraySetReachedThreshold() {
 			set.transformToHashSet()
 		}
 	} else {
@@ -121,6 +204,34 @@ func (set *DynamicSet[E]) Add(element E) {
 	}
 }
 
+// AddStrict adds the given element to the set, or returns an [ElementExistsError] if the element
+// is already present. Unlike Add, this does not silently accept adding an element twice, which is
+// useful for enforcing uniqueness, such as when claiming IDs.
+func (set *DynamicSet[E]) AddStrict(element E) error {
+	if set.Contains(element) {
+		return ElementExistsError[E]{Element: element}
+	}
+
+	set.Add(element)
+	return nil
+}
+
+// TryAdd adds the given element to the set, unless the set already holds maxSize elements, in
+// which case it returns a [SetFullError] without adding it. Adding an element that is already
+// present is a no-op, and succeeds even when the set is full. This is useful for enforcing limits,
+// such as a maximum number of labels per tenant, without checking Size around every Add.
+func (set *DynamicSet[E]) TryAdd(element E, maxSize int) error {
+	if set.Contains(element) {
+		return nil
+	}
+	if set.Size() >= maxSize {
+		return SetFullError[E]{Element: element, MaxSize: maxSize}
+	}
+
+	set.Add(element)
+	return nil
+}
+
 // AddMultiple adds the given elements to the set. Duplicate elements are added only once, and
 // elements already present in the set are not added.
 //
@@ -133,62 +244,137 @@ func (set *DynamicSet[E]) AddMultiple(elements ...E) {
 // AddFromSlice adds the elements from the given slice to the set. Duplicate elements are added
 // only once, and elements already present in the set are not added.
 //
-// If the DynamicSet is an ArraySet, it transforms to a HashSet if adding the elements brings it
-// above the set's size threshold.
+// If the DynamicSet is an ArraySet, it transforms to a HashSet as soon as adding elements brings it
+// to the set's size threshold, with room for the rest of the elements, so that adding them is fast.
 func (set *DynamicSet[E]) AddFromSlice(elements []E) {
-	if set.IsArraySet() {
-		set.array.AddFromSlice(elements)
+	if set.IsHashSet() {
+		set.hash.AddFromSlice(elements)
+		return
+	}
 
-		if set.arraySetReachedThreshold() {
-			set.transformToHashSet()
+	for i, element := range elements {
+		set.array.Add(element)
+
+		if !set.inBatch() && set.arraySetReachedThreshold() {
+			remaining := elements[i+1:]
+			set.switchToHashSet(len(set.array.elements) + len(remaining))
+			set.hash.AddFromSlice(remaining)
+			set.recordTransformation(true)
+			return
 		}
-	} else {
-		set.hash.AddFromSlice(elements)
 	}
 }
 
 // AddFromSet adds elements from the given other set to the set.
 //
 // If the DynamicSet is an ArraySet, it transforms to a HashSet if adding the elements brings it
-// above the set's size threshold.
+// above the set's size threshold. If the other set alone is at the size threshold, the set
+// transforms up front, with room for the other set's elements.
 func (set *DynamicSet[E]) AddFromSet(otherSet ComparableSet[E]) {
-	if set.IsArraySet() {
-		set.array.AddFromSet(otherSet)
+	if set.IsHashSet() {
+		set.hash.AddFromSet(otherSet)
+		return
+	}
 
-		if set.arraySetReachedThreshold() {
-			set.transformToHashSet()
-		}
-	} else {
+	// The other set's elements are unique, so if there are enough of them, the set is certain to
+	// reach its size threshold, and can skip adding them to the ArraySet first.
+	if otherSize := otherSet.Size(); !set.inBatch() && otherSize >= set.SizeThreshold() {
+		set.switchToHashSet(len(set.array.elements) + otherSize)
 		set.hash.AddFromSet(otherSet)
+		set.recordTransformation(true)
+		return
+	}
+
+	set.array.AddFromSet(otherSet)
+	if !set.inBatch() && set.arraySetReachedThreshold() {
+		set.transformToHashSet()
+	}
+}
+
+// AddFromSeq adds the elements from the given iterator to the set, without first collecting them
+// into a slice. Duplicate elements are added only once, and elements already present in the set are
+// not added.
+//
+// If the DynamicSet is an ArraySet, it transforms to a HashSet as soon as adding elements brings it
+// above the set's size threshold, so that adding the rest of the elements is fast.
+func (set *DynamicSet[E]) AddFromSeq(seq iter.Seq[E]) {
+	for element := range seq {
+		set.Add(element)
 	}
 }
 
 // Remove removes the given element from the set.
 // If the element is not present in the set, Remove is a no-op.
 //
-// If the DynamicSet is a HashSet, it transforms to an ArraySet if adding the elements brings it
-// below half the set's size threshold.
+// If the DynamicSet is a HashSet, it transforms to an ArraySet if removing the element brings it
+// down to the set's downgrade threshold.
 func (set *DynamicSet[E]) Remove(element E) {
 	if set.IsArraySet() {
 		set.array.Remove(element)
 	} else {
 		set.hash.Remove(element)
 
-		if set.hashSetReachedThreshold() {
+		if !set.inBatch() && set.hashSetReachedThreshold() {
 			set.transformToArraySet()
 		}
 	}
 }
 
+// RemoveStrict removes the given element from the set, or returns an [ElementNotFoundError] if
+// the element is not present. Unlike Remove, this does not silently accept removing a missing
+// element.
+func (set *DynamicSet[E]) RemoveStrict(element E) error {
+	if !set.Contains(element) {
+		return ElementNotFoundError[E]{Element: element}
+	}
+
+	set.Remove(element)
+	return nil
+}
+
 // Clear removes all elements from the set.
+//
+// If the DynamicSet is a HashSet, it transforms to an empty ArraySet, unless downgrading has been
+// disabled with [DynamicSet.SetDowngradeEnabled], in which case it is cleared as a HashSet.
 func (set *DynamicSet[E]) Clear() {
 	if set.IsArraySet() {
 		set.array.Clear()
+	} else if set.downgradeDisabled {
+		set.hash.Clear()
 	} else {
 		set.hash.elements = nil
 	}
 }
 
+// Grow increases the set's capacity, if necessary, to make room for another n elements. If adding
+// n elements would bring the set to its size threshold, an ArraySet transforms to a HashSet
+// immediately, with room for the new elements, instead of growing its array first. Otherwise, it
+// grows the underlying set as described on [ArraySet.Grow] and [HashSet.Grow].
+//
+// Panics if n is negative.
+func (set *DynamicSet[E]) Grow(n int) {
+	checkGrowCount(n)
+
+	if set.IsArraySet() {
+		if len(set.array.elements)+n >= set.SizeThreshold() {
+			set.transformToHashSetWithCapacity(len(set.array.elements) + n)
+		} else {
+			set.array.Grow(n)
+		}
+	} else {
+		set.hash.Grow(n)
+	}
+}
+
+// Cap returns the capacity of the underlying set, as described on [ArraySet.Cap] and [HashSet.Cap].
+// Note that an ArraySet transforms to a HashSet at its size threshold, regardless of its capacity.
+func (set DynamicSet[E]) Cap() int {
+	if set.IsArraySet() {
+		return set.array.Cap()
+	}
+	return set.hash.Cap()
+}
+
 // Contains checks if given element is present in the set.
 func (set DynamicSet[E]) Contains(element E) bool {
 	if set.IsArraySet() {
@@ -254,7 +440,7 @@ func (set DynamicSet[E]) Union(otherSet ComparableSet[E]) Set[E] {
 // UnionDynamicSet creates a new DynamicSet that contains all the elements of the receiver set and
 // the other given set.
 func (set DynamicSet[E]) UnionDynamicSet(otherSet ComparableSet[E]) DynamicSet[E] {
-	union := DynamicSet[E]{sizeThreshold: set.sizeThreshold}
+	union := set.emptyWithSameConfig()
 
 	if set.IsArraySet() {
 		union.array = set.array.UnionArraySet(otherSet)
@@ -280,7 +466,7 @@ func (set DynamicSet[E]) Intersection(otherSet ComparableSet[E]) Set[E] {
 // IntersectionDynamicSet creates a new DynamicSet with only the elements that exist in both the
 // receiver set and the other given set.
 func (set DynamicSet[E]) IntersectionDynamicSet(otherSet ComparableSet[E]) DynamicSet[E] {
-	intersection := DynamicSet[E]{sizeThreshold: set.sizeThreshold}
+	intersection := set.emptyWithSameConfig()
 
 	if set.IsArraySet() {
 		intersection.array = set.array.IntersectionArraySet(otherSet)
@@ -298,10 +484,8 @@ func (set DynamicSet[E]) IntersectionDynamicSet(otherSet ComparableSet[E]) Dynam
 // ToSlice returns a slice with all the elements in the set.
 //
 // Since sets are unordered, the order of elements in the slice is non-deterministic, and may
-// vary even when called multiple times on the same set.
-//
-// If the underlying set type is an ArraySet, the returned slice uses the same backing storage,
-// so mutating it may invalidate the set. To avoid this, call CopyDynamicSet first.
+// vary even when called multiple times on the same set. The slice is a copy in both
+// representations, so it can be mutated freely.
 func (set DynamicSet[E]) ToSlice() []E {
 	if set.IsArraySet() {
 		return set.array.ToSlice()
@@ -310,10 +494,20 @@ func (set DynamicSet[E]) ToSlice() []E {
 	}
 }
 
+// AppendTo appends the elements in the set to the given slice, and returns the extended slice,
+// like the append builtin. Unlike ToSlice, this lets the caller reuse a buffer across calls.
+func (set DynamicSet[E]) AppendTo(slice []E) []E {
+	if set.IsArraySet() {
+		return set.array.AppendTo(slice)
+	} else {
+		return set.hash.AppendTo(slice)
+	}
+}
+
 // ToMap returns a map with all the set's elements as keys.
 //
 // If the underlying set type is a HashSet, the returned map is the backing storage for the set,
-// so mutating it will also mutate the set. To avoid this, call CopyDynamicSet first.
+// so mutating it will also mutate the set. To avoid this, use [DynamicSet.ToMapCopy] instead.
 func (set DynamicSet[E]) ToMap() map[E]struct{} {
 	if set.IsArraySet() {
 		return set.array.ToMap()
@@ -322,6 +516,16 @@ func (set DynamicSet[E]) ToMap() map[E]struct{} {
 	}
 }
 
+// ToMapCopy creates a new map with all the set's elements as keys. Unlike ToMap, the map never
+// aliases the set, so it is safe to mutate.
+func (set DynamicSet[E]) ToMapCopy() map[E]struct{} {
+	if set.IsArraySet() {
+		return set.array.ToMap()
+	} else {
+		return set.hash.ToMapCopy()
+	}
+}
+
 // Copy creates a new set with all the same elements and capacity as the original set.
 // The underlying type of the returned set is a *DynamicSet - to get a value type, use
 // [DynamicSet.CopyDynamicSet] instead.
@@ -333,7 +537,7 @@ func (set DynamicSet[E]) Copy() Set[E] {
 // CopyDynamicSet creates a new DynamicSet with all the same elements and capacity as the original
 // set.
 func (set DynamicSet[E]) CopyDynamicSet() DynamicSet[E] {
-	newSet := DynamicSet[E]{sizeThreshold: set.sizeThreshold}
+	newSet := set.emptyWithSameConfig()
 
 	if set.IsArraySet() {
 		newSet.array = set.array.CopyArraySet()
@@ -344,6 +548,22 @@ func (set DynamicSet[E]) CopyDynamicSet() DynamicSet[E] {
 	return newSet
 }
 
+// CopyCompact creates a new DynamicSet with all the same elements as the original set, but unlike
+// CopyDynamicSet, with capacity for only its current elements (see [ArraySet.CopyCompact]). If the
+// set is a HashSet, the copy is a HashSet sized for the current elements, since Go maps do not keep
+// the capacity of the map they are copied from anyway.
+func (set DynamicSet[E]) CopyCompact() DynamicSet[E] {
+	newSet := set.emptyWithSameConfig()
+
+	if set.IsArraySet() {
+		newSet.array = set.array.CopyCompact()
+	} else {
+		newSet.hash = set.hash.CopyHashSet()
+	}
+
+	return newSet
+}
+
 // String returns a string representation of the set, implementing [fmt.Stringer].
 //
 // Since sets are unordered, the order of elements in the string may differ each time it is
@@ -380,11 +600,14 @@ func (set DynamicSet[E]) String() string {
 	return stringBuilder.String()
 }
 
-// All returns an [Iterator] function, which when called will loop over the elements in the set and
-// call the given yield function on each element. If yield returns false, iteration stops.
+// All returns an iterator over the elements in the set, which can be used in a for-range loop:
+//
+//	for element := range set.All() {
+//		fmt.Println(element)
+//	}
 //
 // Since sets are unordered, iteration order is non-deterministic.
-func (set DynamicSet[E]) All() Iterator[E] {
+func (set DynamicSet[E]) All() iter.Seq[E] {
 	if set.IsArraySet() {
 		return set.array.All()
 	} else {
@@ -404,6 +627,98 @@ func (set DynamicSet[E]) IsHashSet() bool {
 	return set.hash.elements != nil
 }
 
+// UnderlyingArraySet returns a pointer to the ArraySet that the DynamicSet currently uses
+// internally, or nil if the DynamicSet is a HashSet (see [DynamicSet.IsArraySet]). This gives
+// performance-sensitive code direct access to the set's current representation, without the
+// overhead of checking the representation on every operation.
+//
+// The returned ArraySet aliases the DynamicSet's internal storage, so mutating it mutates the
+// DynamicSet. Such mutations bypass the DynamicSet's size threshold - the set does not transform
+// until its next mutation through the DynamicSet's own methods. The returned pointer is invalidated
+// when the DynamicSet transforms to a HashSet, and must not be used after that.
+func (set *DynamicSet[E]) UnderlyingArraySet() *ArraySet[E] {
+	if set.IsArraySet() {
+		return &set.array
+	} else {
+		return nil
+	}
+}
+
+// UnderlyingHashSet returns a pointer to the HashSet that the DynamicSet currently uses internally,
+// or nil if the DynamicSet is an ArraySet (see [DynamicSet.IsHashSet]). This gives
+// performance-sensitive code direct access to the set's current representation, without the
+// overhead of checking the representation on every operation.
+//
+// The returned HashSet aliases the DynamicSet's internal storage, so mutating it mutates the
+// DynamicSet. Such mutations bypass the DynamicSet's downgrade threshold - the set does not
+// transform until its next mutation through the DynamicSet's own methods. The returned pointer is
+// invalidated when the DynamicSet transforms to an ArraySet, and must not be used after that.
+func (set *DynamicSet[E]) UnderlyingHashSet() *HashSet[E] {
+	if set.IsHashSet() {
+		return &set.hash
+	} else {
+		return nil
+	}
+}
+
+// TransformationCount returns the number of times the DynamicSet has transformed between an
+// ArraySet and a HashSet, in either direction. A count that keeps growing relative to the number of
+// operations on the set indicates that its size oscillates around its thresholds, in which case
+// [DynamicSet.SetDowngradeThreshold] or [DynamicSet.SetDowngradeEnabled] may help.
+//
+// The count is not carried over to copies, unions or intersections of the set.
+func (set DynamicSet[E]) TransformationCount() int {
+	return set.transformations
+}
+
+// BeginBatch starts a batch of changes to the set, during which the set does not transform between
+// an ArraySet and a HashSet when adding and removing elements. Instead, [DynamicSet.EndBatch]
+// transforms the set at most once, to the representation that fits its size at the end of the
+// batch. This avoids repeated transformations for bursts of adds and removes that move the size
+// back and forth across the thresholds:
+//
+//	set.BeginBatch()
+//	defer set.EndBatch()
+//	for _, change := range changes { ... }
+//
+// Note that adding many elements to an ArraySet during a batch is O(n) per element, since the set
+// stays an ArraySet until the batch ends. If the number of elements to add is known up front, call
+// [DynamicSet.Grow] first, which may transform the set to a HashSet right away.
+//
+// Batches may be nested, in which case the set transforms when the outermost batch ends.
+func (set *DynamicSet[E]) BeginBatch() {
+	set.batchDepth++
+}
+
+// EndBatch ends a batch of changes started by [DynamicSet.BeginBatch], and transforms the set if
+// its size has crossed the size threshold or the downgrade threshold during the batch.
+//
+// Panics if there is no batch in progress.
+func (set *DynamicSet[E]) EndBatch() {
+	if set.batchDepth == 0 {
+		panic("set: DynamicSet.EndBatch called without a matching BeginBatch")
+	}
+	set.batchDepth--
+
+	if !set.inBatch() {
+		set.transformToFitSize()
+	}
+}
+
+func (set *DynamicSet[E]) inBatch() bool {
+	return set.batchDepth != 0
+}
+
+// SetOnTransform registers a callback that is called every time the DynamicSet transforms between
+// an ArraySet and a HashSet, after the transformation. This can be used to report transformations
+// to metrics or logs. A nil callback removes the previously registered callback.
+//
+// The callback is carried over to copies, unions and intersections of the set, and is also called
+// when such a set transforms while being created. It must not mutate the set.
+func (set *DynamicSet[E]) SetOnTransform(callback func(transformation DynamicSetTransformation)) {
+	set.onTransform = callback
+}
+
 func (set *DynamicSet[E]) arraySetReachedThreshold() bool {
 	if set.sizeThreshold == 0 {
 		set.sizeThreshold = DefaultDynamicSetSizeThreshold
@@ -413,14 +728,54 @@ func (set *DynamicSet[E]) arraySetReachedThreshold() bool {
 }
 
 func (set *DynamicSet[E]) hashSetReachedThreshold() bool {
+	if set.downgradeDisabled {
+		return false
+	}
+
 	if set.sizeThreshold == 0 {
 		set.sizeThreshold = DefaultDynamicSetSizeThreshold
 	}
 
-	return len(set.hash.elements) <= set.sizeThreshold/2
+	return len(set.hash.elements) <= set.DowngradeThreshold()
+}
+
+// emptyWithSameConfig returns an empty DynamicSet with the same thresholds and transformation
+// callback as the receiver.
+func (set DynamicSet[E]) emptyWithSameConfig() DynamicSet[E] {
+	return DynamicSet[E]{
+		sizeThreshold:      set.sizeThreshold,
+		downgradeThreshold: set.downgradeThreshold,
+		downgradeDisabled:  set.downgradeDisabled,
+		onTransform:        set.onTransform,
+	}
+}
+
+// transformToFitSize transforms the set to the representation that fits its current size, after
+// changes that did not transform it as they were made.
+func (set *DynamicSet[E]) transformToFitSize() {
+	if set.IsArraySet() {
+		if set.arraySetReachedThreshold() {
+			set.transformToHashSet()
+		}
+	} else if set.hashSetReachedThreshold() {
+		set.transformToArraySet()
+	}
 }
 
 func (set *DynamicSet[E]) transformToHashSet() {
+	set.transformToHashSetWithCapacity(len(set.array.elements))
+}
+
+func (set *DynamicSet[E]) transformToHashSetWithCapacity(capacity int) {
+	set.switchToHashSet(capacity)
+	set.recordTransformation(true)
+}
+
+// switchToHashSet moves the elements of the ArraySet to a new HashSet with room for the given
+// number of elements, without recording the transformation. This lets bulk adds record it once all
+// their elements are in, so that the reported size is the size after the add.
+func (set *DynamicSet[E]) switchToHashSet(capacity int) {
+	set.hash = HashSetWithCapacity[E](capacity)
 	set.hash.AddFromSet(set.array)
 	set.array.elements = nil
 }
@@ -428,4 +783,66 @@ func (set *DynamicSet[E]) transformToHashSet() {
 func (set *DynamicSet[E]) transformToArraySet() {
 	set.array.AddFromSet(set.hash)
 	set.hash.elements = nil
+	set.recordTransformation(false)
+}
+
+func (set *DynamicSet[E]) recordTransformation(toHashSet bool) {
+	set.transformations++
+
+	if set.onTransform != nil {
+		set.onTransform(DynamicSetTransformation{
+			ToHashSet: toHashSet,
+			Size:      set.Size(),
+			Count:     set.transformations,
+		})
+	}
+}
+
+// MarshalBinary encodes the set in a compact, length-prefixed binary format, implementing
+// [encoding.BinaryMarshaler]. Strings, numbers, bools and other fixed-size element types are
+// supported, as well as element types that implement encoding.BinaryMarshaler (with pointers to the
+// element type implementing [encoding.BinaryUnmarshaler]).
+func (set DynamicSet[E]) MarshalBinary() ([]byte, error) {
+	return marshalSetBinary[E](set)
+}
+
+// UnmarshalBinary replaces the elements of the set with the elements decoded from the given data,
+// which must be in the format produced by [DynamicSet.MarshalBinary]. It implements
+// [encoding.BinaryUnmarshaler].
+func (set *DynamicSet[E]) UnmarshalBinary(data []byte) error {
+	return unmarshalSetBinary[E](data, set)
+}
+
+// Value converts the set to a PostgreSQL array literal string (such as {1,2,3} or {"a","b"}),
+// implementing [driver.Valuer] so the set can be stored in an array or text column. Strings,
+// numbers, bools and element types implementing [encoding.TextMarshaler] are supported.
+func (set DynamicSet[E]) Value() (driver.Value, error) {
+	return sqlValueFromSet[E](set)
+}
+
+// Scan replaces the elements of the set with the elements parsed from the given PostgreSQL array
+// literal (as a string or []byte), implementing [database/sql.Scanner]. A NULL value results in an
+// empty set.
+func (set *DynamicSet[E]) Scan(src any) error {
+	return scanSQLValueIntoSet[E](src, set)
+}
+
+// Format implements [fmt.Formatter], supporting the following verbs:
+//   - %v and %s print the set like String (%+v formats elements with %+v)
+//   - %q quotes string elements
+//   - %#v prints the set in Go syntax, like [DynamicSet.GoString]
+//   - Other verbs are applied to each element, like fmt does for slices (e.g. %x prints elements in
+//     hex)
+//
+// Precision limits the number of printed elements (e.g. %.2v prints DynamicSet{1, 2, ...}), and
+// width pads the output.
+func (set DynamicSet[E]) Format(state fmt.State, verb rune) {
+	formatSet[E](state, verb, "DynamicSet", set)
+}
+
+// GoString returns a representation of the set in Go syntax, implementing [fmt.GoStringer]. This is
+// also used by the %#v verb. A DynamicSet of elements 1, 2 and 3 will be printed as:
+// set.DynamicSetOf[int](1, 2, 3)
+func (set DynamicSet[E]) GoString() string {
+	return goSyntaxString[E]("DynamicSet", set)
 }
@@ -0,0 +1,85 @@
+package set
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DuplicateElementError is returned by the strict set constructors, such as
+// [HashSetFromSliceStrict], when their input contains the same element more than once.
+type DuplicateElementError[E comparable] struct {
+	// Element is the element that occurred more than once.
+	Element E
+
+	// Index is the index in the input of the element's second occurrence.
+	Index int
+}
+
+// Error implements the error interface.
+func (err DuplicateElementError[E]) Error() string {
+	return fmt.Sprintf("set: duplicate element %v at index %d", err.Element, err.Index)
+}
+
+// ErrElementExists is matched by errors returned when adding an element that is already present in
+// a set, such as from [HashSet.AddStrict]. Use [errors.Is] to check for it, or [errors.As] with an
+// [ElementExistsError] to get the element.
+var ErrElementExists = errors.New("set: element already exists")
+
+// ErrElementNotFound is matched by errors returned when removing an element that is not present in
+// a set, such as from [HashSet.RemoveStrict]. Use [errors.Is] to check for it, or [errors.As] with
+// an [ElementNotFoundError] to get the element.
+var ErrElementNotFound = errors.New("set: element not found")
+
+// ElementExistsError is returned by AddStrict methods, such as [HashSet.AddStrict], when the added
+// element is already present in the set. It matches [ErrElementExists] with [errors.Is].
+type ElementExistsError[E comparable] struct {
+	Element E
+}
+
+// Error implements the error interface.
+func (err ElementExistsError[E]) Error() string {
+	return fmt.Sprintf("set: element %v already exists", err.Element)
+}
+
+// Is makes [errors.Is] match the error with [ErrElementExists].
+func (err ElementExistsError[E]) Is(target error) bool {
+	return target == ErrElementExists
+}
+
+// ElementNotFoundError is returned by RemoveStrict methods, such as [HashSet.RemoveStrict], when
+// the removed element is not present in the set. It matches [ErrElementNotFound] with [errors.Is].
+type ElementNotFoundError[E comparable] struct {
+	Element E
+}
+
+// Error implements the error interface.
+func (err ElementNotFoundError[E]) Error() string {
+	return fmt.Sprintf("set: element %v not found", err.Element)
+}
+
+// Is makes [errors.Is] match the error with [ErrElementNotFound].
+func (err ElementNotFoundError[E]) Is(target error) bool {
+	return target == ErrElementNotFound
+}
+
+// ErrSetFull is matched by errors returned when adding an element to a set that has reached its
+// maximum size, such as from [HashSet.TryAdd]. Use [errors.Is] to check for it, or [errors.As] with
+// a [SetFullError] to get the element and the maximum size.
+var ErrSetFull = errors.New("set: set is full")
+
+// SetFullError is returned by TryAdd methods, such as [HashSet.TryAdd], when the set already holds
+// the maximum number of elements. It matches [ErrSetFull] with [errors.Is].
+type SetFullError[E comparable] struct {
+	Element E
+	MaxSize int
+}
+
+// Error implements the error interface.
+func (err SetFullError[E]) Error() string {
+	return fmt.Sprintf("set: cannot add %v, set is full (max size %d)", err.Element, err.MaxSize)
+}
+
+// Is makes [errors.Is] match the error with [ErrSetFull].
+func (err SetFullError[E]) Is(target error) bool {
+	return target == ErrSetFull
+}
@@ -0,0 +1,56 @@
+package set
+
+import (
+	"expvar"
+)
+
+// ExpvarVar returns an [expvar.Var] that reports the size of the given set, so that long-lived
+// sets, such as registries of open connections, can be monitored through the /debug/vars endpoint
+// of the expvar package. The variable is a JSON object, which is computed each time it is read:
+//
+//	{"size": 42, "storage": "StorageHash", "capacity": 56, "loadFactor": 0.65625}
+//
+// If the set has a Stats method (see [Stats]), the object also includes its storage kind,
+// capacity and load factor, as well as the thresholds of a [DynamicSet].
+//
+// The variable is read from the goroutine that serves /debug/vars, so the set must be safe for
+// concurrent use, such as a [SynchronizedSet] or a [CopyOnWriteSet]. To publish the variable, use
+// [PublishExpvar] or [expvar.Publish].
+func ExpvarVar[E comparable](set ComparableSet[E]) expvar.Var {
+	return expvar.Func(func() any {
+		statsSet, ok := set.(interface{ Stats() Stats })
+		if !ok {
+			return expvarStats{Size: set.Size()}
+		}
+
+		stats := statsSet.Stats()
+		expvarStats := expvarStats{
+			Size:               stats.Size,
+			Capacity:           stats.Capacity,
+			LoadFactor:         stats.LoadFactor,
+			SizeThreshold:      stats.SizeThreshold,
+			DowngradeThreshold: stats.DowngradeThreshold,
+		}
+		if stats.Storage != 0 {
+			expvarStats.Storage = stats.Storage.String()
+		}
+		return expvarStats
+	})
+}
+
+// PublishExpvar publishes an [ExpvarVar] for the given set under the given name, so that it is
+// reported by the /debug/vars endpoint of the expvar package.
+//
+// Like [expvar.Publish], it panics if a variable with the given name is already published.
+func PublishExpvar[E comparable](name string, set ComparableSet[E]) {
+	expvar.Publish(name, ExpvarVar(set))
+}
+
+type expvarStats struct {
+	Size               int     `json:"size"`
+	Storage            string  `json:"storage,omitempty"`
+	Capacity           int     `json:"capacity,omitempty"`
+	LoadFactor         float64 `json:"loadFactor,omitempty"`
+	SizeThreshold      int     `json:"sizeThreshold,omitempty"`
+	DowngradeThreshold int     `json:"downgradeThreshold,omitempty"`
+}
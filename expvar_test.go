@@ -0,0 +1,42 @@
+package set_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestExpvarVar(t *testing.T) {
+	dynamicSet := set.DynamicSetOf(1, 2, 3)
+	connections := set.Synchronize[int](&dynamicSet)
+	connectionsVar := set.ExpvarVar[int](connections)
+
+	var stats struct {
+		Size          int    `json:"size"`
+		Storage       string `json:"storage"`
+		SizeThreshold int    `json:"sizeThreshold"`
+	}
+	if err := json.Unmarshal([]byte(connectionsVar.String()), &stats); err != nil {
+		t.Fatal(err)
+	}
+
+	if stats.Size != 3 || stats.Storage != "StorageArray" ||
+		stats.SizeThreshold != set.DefaultDynamicSetSizeThreshold {
+		t.Errorf("unexpected expvar stats %+v", stats)
+	}
+
+	// The variable is computed when read, so it reflects later changes.
+	connections.AddFromSlice(createRandomIntSlice(100))
+	if err := json.Unmarshal([]byte(connectionsVar.String()), &stats); err != nil {
+		t.Fatal(err)
+	}
+	if stats.Size != connections.Size() || stats.Storage != "StorageHash" {
+		t.Errorf("unexpected expvar stats after adding elements %+v", stats)
+	}
+
+	sliceVar := set.ExpvarVar[int](set.SliceSet[int]{1, 2})
+	if str := sliceVar.String(); str != `{"size":2}` {
+		t.Errorf("unexpected expvar for set without stats: %s", str)
+	}
+}
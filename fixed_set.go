@@ -0,0 +1,236 @@
+package set
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// A FixedSet is a collection of unique elements of type E, with a fixed capacity, stored in a
+// backing array given by the caller. Adding, removing and looking up elements never allocates,
+// which makes FixedSet suitable for code that must not touch the allocator, such as per-frame game
+// logic or embedded code. The backing array can be a local array, which stays on the stack if it
+// does not escape:
+//
+//	var storage [16]EntityID
+//	visible := set.NewFixedSet(storage[:])
+//	visible.Add(entity)
+//
+// Like [ArraySet], elements are looked up by scanning the array, so FixedSet is only efficient for
+// small capacities. When the set is full, Add ignores new elements - to get an error instead, use
+// [FixedSet.AddChecked]. Methods that create new sets (Union, Intersection and Copy) allocate.
+//
+// A FixedSet must be created with [NewFixedSet]. It must not be copied after first use.
+//
+// FixedSet implements [Set] when passed by pointer, and [ComparableSet] when passed by value.
+type FixedSet[E comparable] struct {
+	// elements has the given storage as its backing array, so its capacity is the set's capacity.
+	elements []E
+}
+
+// NewFixedSet creates a new, empty [FixedSet] that uses the given slice as its backing storage,
+// with a capacity of len(storage). The set takes ownership of the storage, so the caller must not
+// use it afterwards.
+// It must not be copied after first use.
+func NewFixedSet[E comparable](storage []E) FixedSet[E] {
+	clear(storage)
+	return FixedSet[E]{elements: storage[:0:len(storage)]}
+}
+
+// Add adds the given element to the set.
+// If the element is already present in the set, or the set is full, Add is a no-op.
+func (set *FixedSet[E]) Add(element E) {
+	_ = set.AddChecked(element)
+}
+
+// AddChecked adds the given element to the set, or returns a [SetFullError] without adding it if
+// the set is full. Adding an element that is already present is a no-op, and succeeds even when the
+// set is full.
+func (set *FixedSet[E]) AddChecked(element E) error {
+	if set.Contains(element) {
+		return nil
+	}
+	if len(set.elements) == cap(set.elements) {
+		return SetFullError[E]{Element: element, MaxSize: cap(set.elements)}
+	}
+
+	set.elements = append(set.elements, element)
+	return nil
+}
+
+// AddMultiple adds the given elements to the set. Duplicate elements are added only once, and
+// elements already present in the set are not added. Elements that do not fit are left out.
+func (set *FixedSet[E]) AddMultiple(elements ...E) {
+	set.AddFromSlice(elements)
+}
+
+// AddFromSlice adds the elements from the given slice to the set. Duplicate elements are added only
+// once, and elements already present in the set are not added. Elements that do not fit are left
+// out.
+func (set *FixedSet[E]) AddFromSlice(elements []E) {
+	for _, element := range elements {
+		set.Add(element)
+	}
+}
+
+// AddFromSet adds elements from the given other set to the set. Elements that do not fit are left
+// out.
+func (set *FixedSet[E]) AddFromSet(otherSet ComparableSet[E]) {
+	if other, ok := otherSet.(*FixedSet[E]); ok && other == set {
+		return
+	}
+
+	for element := range otherSet.All() {
+		set.Add(element)
+	}
+}
+
+// AddFromSeq adds the elements from the given iterator to the set. Elements that do not fit are
+// left out.
+func (set *FixedSet[E]) AddFromSeq(seq iter.Seq[E]) {
+	for element := range seq {
+		set.Add(element)
+	}
+}
+
+// Remove removes the given element from the set.
+// If the element is not present in the set, Remove is a no-op.
+func (set *FixedSet[E]) Remove(element E) {
+	view := set.arraySet()
+	view.Remove(element)
+	set.elements = view.elements
+}
+
+// Clear removes all elements from the set.
+func (set *FixedSet[E]) Clear() {
+	clear(set.elements)
+	set.elements = set.elements[:0]
+}
+
+// Grow is a no-op, since the capacity of a FixedSet cannot change. It is provided to implement
+// [Set].
+//
+// Panics if n is negative.
+func (set *FixedSet[E]) Grow(n int) {
+	checkGrowCount(n)
+}
+
+// Cap returns the number of elements that the set can hold.
+func (set FixedSet[E]) Cap() int {
+	return cap(set.elements)
+}
+
+// IsFull checks if the set holds as many elements as its capacity.
+func (set FixedSet[E]) IsFull() bool {
+	return len(set.elements) == cap(set.elements)
+}
+
+// Contains checks if given element is present in the set.
+func (set FixedSet[E]) Contains(element E) bool {
+	return set.arraySet().Contains(element)
+}
+
+// Size returns the number of elements in the set.
+func (set FixedSet[E]) Size() int {
+	return len(set.elements)
+}
+
+// IsEmpty checks if there are 0 elements in the set.
+func (set FixedSet[E]) IsEmpty() bool {
+	return len(set.elements) == 0
+}
+
+// Equals checks if the set contains exactly the same elements as the other given set.
+func (set FixedSet[E]) Equals(otherSet ComparableSet[E]) bool {
+	return set.arraySet().Equals(otherSet)
+}
+
+// IsSubsetOf checks if all of the elements in the set exist in the other given set.
+func (set FixedSet[E]) IsSubsetOf(otherSet ComparableSet[E]) bool {
+	return set.arraySet().IsSubsetOf(otherSet)
+}
+
+// IsSupersetOf checks if the set contains all of the elements in the other given set.
+func (set FixedSet[E]) IsSupersetOf(otherSet ComparableSet[E]) bool {
+	return set.arraySet().IsSupersetOf(otherSet)
+}
+
+// Union creates a new set that contains all the elements of the receiver set and the other given
+// set. Since the union may not fit in the set's capacity, the underlying type of the returned set
+// is an *ArraySet.
+func (set FixedSet[E]) Union(otherSet ComparableSet[E]) Set[E] {
+	return set.arraySet().Union(otherSet)
+}
+
+// Intersection creates a new set with only the elements that exist in both the receiver set and the
+// other given set. The underlying type of the returned set is an *ArraySet.
+func (set FixedSet[E]) Intersection(otherSet ComparableSet[E]) Set[E] {
+	return set.arraySet().Intersection(otherSet)
+}
+
+// ToSlice creates a slice with all the elements in the set.
+func (set FixedSet[E]) ToSlice() []E {
+	return set.arraySet().ToSlice()
+}
+
+// AppendTo appends the elements in the set to the given slice, and returns the extended slice,
+// like the append builtin. Unlike ToSlice, this does not allocate if the given slice has room.
+func (set FixedSet[E]) AppendTo(slice []E) []E {
+	return append(slice, set.elements...)
+}
+
+// ToMap creates a map with all the set's elements as keys.
+func (set FixedSet[E]) ToMap() map[E]struct{} {
+	return set.arraySet().ToMap()
+}
+
+// Copy creates a new set with all the same elements as the original set, with new backing storage
+// of the same capacity. The underlying type of the returned set is a *FixedSet.
+func (set FixedSet[E]) Copy() Set[E] {
+	newSet := NewFixedSet(make([]E, cap(set.elements)))
+	newSet.elements = append(newSet.elements, set.elements...)
+	return &newSet
+}
+
+// String returns a string representation of the set, implementing [fmt.Stringer].
+//
+// A FixedSet of elements 1, 2 and 3 will be printed as: FixedSet{1, 2, 3}
+func (set FixedSet[E]) String() string {
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString("FixedSet{")
+
+	for i, element := range set.elements {
+		fmt.Fprint(&stringBuilder, element)
+
+		if i < len(set.elements)-1 {
+			stringBuilder.WriteString(", ")
+		}
+	}
+
+	stringBuilder.WriteByte('}')
+	return stringBuilder.String()
+}
+
+// All returns an iterator over the elements in the set, which can be used in a for-range loop:
+//
+//	for element := range set.All() {
+//		fmt.Println(element)
+//	}
+//
+// Elements are iterated in the order they were added, except that Remove moves the last element
+// into the removed element's place.
+func (set FixedSet[E]) All() iter.Seq[E] {
+	return func(yield func(element E) bool) {
+		for _, element := range set.elements {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// arraySet returns an ArraySet view of the set's elements, sharing their storage, to reuse the
+// ArraySet implementation.
+func (set FixedSet[E]) arraySet() ArraySet[E] {
+	return ArraySet[E]{elements: set.elements}
+}
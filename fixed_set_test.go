@@ -0,0 +1,57 @@
+package set_test
+
+import (
+	"errors"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestFixedSetWhenFull(t *testing.T) {
+	var storage [3]int
+	fixedSet := set.NewFixedSet(storage[:])
+	fixedSet.AddMultiple(1, 2, 3, 4)
+
+	assertSize(t, &fixedSet, 3)
+	assertContains(t, &fixedSet, 1, 2, 3)
+	if !fixedSet.IsFull() {
+		t.Errorf("expected %v to be full", fixedSet)
+	}
+
+	err := fixedSet.AddChecked(4)
+	var fullErr set.SetFullError[int]
+	if !errors.Is(err, set.ErrSetFull) || !errors.As(err, &fullErr) || fullErr.MaxSize != 3 {
+		t.Errorf("expected SetFullError with max size 3, got %v", err)
+	}
+	if err := fixedSet.AddChecked(2); err != nil {
+		t.Errorf("expected AddChecked of present element on full set to succeed, got %v", err)
+	}
+
+	fixedSet.Remove(1)
+	fixedSet.Add(4)
+	assertSize(t, &fixedSet, 3)
+	assertContains(t, &fixedSet, 2, 3, 4)
+
+	copied := fixedSet.Copy()
+	copied.Remove(2)
+	assertContains(t, &fixedSet, 2)
+}
+
+func TestFixedSetDoesNotAllocate(t *testing.T) {
+	var storage [16]int
+	fixedSet := set.NewFixedSet(storage[:])
+
+	allocations := testing.AllocsPerRun(100, func() {
+		for i := range 20 {
+			fixedSet.Add(i)
+		}
+		for i := range 20 {
+			fixedSet.Contains(i)
+			fixedSet.Remove(i)
+		}
+		fixedSet.Clear()
+	})
+	if allocations != 0 {
+		t.Errorf("expected FixedSet to not allocate, got %v allocations per run", allocations)
+	}
+}
@@ -0,0 +1,39 @@
+package set
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrNaN is returned by [RejectNaN] for NaN (not-a-number) floats.
+var ErrNaN = errors.New("set: NaN cannot be a set element")
+
+// RejectNaN is a validation function for [Constrain], which returns [ErrNaN] if the given float is
+// NaN (not-a-number), and nil otherwise.
+//
+// NaN is not equal to itself, so NaN elements cannot be found in sets: Contains(NaN) is always
+// false, and Remove(NaN) is a no-op. Adding NaN to a set adds a new element every time, which can
+// never be removed except by Clear. To keep NaNs out of a set of floats, wrap it with Constrain
+// and RejectNaN, or use [ConstrainFloats]:
+//
+//	measurements := set.Constrain[float64](
+//		&set.HashSet[float64]{},
+//		set.RejectNaN,
+//		set.InvalidElementSkip,
+//	)
+//
+// Negative zero needs no special handling, since it is equal to positive zero, so sets treat the
+// two as the same element.
+func RejectNaN[F ~float32 | ~float64](element F) error {
+	if math.IsNaN(float64(element)) {
+		return ErrNaN
+	}
+	return nil
+}
+
+// ConstrainFloats wraps the given set of floats in a [ConstrainedSet] that rejects NaN elements
+// (see [RejectNaN] for why NaN is a problem in sets). Adding NaN with the Set methods panics, while
+// [ConstrainedSet.AddChecked] returns an [InvalidElementError] wrapping [ErrNaN].
+func ConstrainFloats[F ~float32 | ~float64](set Set[F]) *ConstrainedSet[F] {
+	return Constrain(set, RejectNaN[F], InvalidElementPanic)
+}
@@ -0,0 +1,293 @@
+package set
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// formatSet implements [fmt.Formatter] for the set types, printing the set with the given type name
+// in the same format as String. The verb is handled as follows:
+//   - %v and %s format elements with %v (%+v with the + flag)
+//   - %q quotes string elements, and formats other elements with %v
+//   - %#v prints the set in Go syntax, like GoString
+//   - Other verbs are applied to each element, like fmt does for slices (e.g. %x prints elements in
+//     hex)
+//
+// Precision limits the number of printed elements, with "..." appended when elements were left out
+// (e.g. %.2v prints HashSet{1, 2, ...}). Width pads the whole output, to the left by default, or to
+// the right with the - flag.
+func formatSet[E comparable](
+	state fmt.State,
+	verb rune,
+	typeName string,
+	set ComparableSet[E],
+) {
+	var stringBuilder strings.Builder
+
+	if verb == 'v' && state.Flag('#') {
+		stringBuilder.WriteString(goSyntaxString(typeName, set))
+	} else {
+		elementFormat := elementFormatForVerb[E](state, verb)
+		maxElements, hasMaxElements := state.Precision()
+
+		stringBuilder.WriteString(typeName)
+		stringBuilder.WriteByte('{')
+
+		i := 0
+		for element := range set.All() {
+			if hasMaxElements && i >= maxElements {
+				if i > 0 {
+					stringBuilder.WriteString(", ")
+				}
+				stringBuilder.WriteString("...")
+				break
+			}
+
+			if i > 0 {
+				stringBuilder.WriteString(", ")
+			}
+			fmt.Fprintf(&stringBuilder, elementFormat, element)
+
+			i++
+		}
+
+		stringBuilder.WriteByte('}')
+	}
+
+	writePadded(state, stringBuilder.String())
+}
+
+func elementFormatForVerb[E comparable](state fmt.State, verb rune) string {
+	switch verb {
+	case 'v', 's':
+		if state.Flag('+') {
+			return "%+v"
+		}
+		return "%v"
+	case 'q':
+		var zero E
+		if reflect.TypeOf(&zero).Elem().Kind() == reflect.String {
+			return "%q"
+		}
+		return "%v"
+	default:
+		var flags strings.Builder
+		for _, flag := range "+# " {
+			if state.Flag(int(flag)) {
+				flags.WriteRune(flag)
+			}
+		}
+		return "%" + flags.String() + string(verb)
+	}
+}
+
+// goSyntaxString implements [fmt.GoStringer] for the set types, printing a call to the set type's
+// "Of" constructor with the given type name, such as set.HashSetOf[int](1, 2, 3). The element type
+// is always included, so that the output is valid Go even when the element type can't be inferred
+// from the elements (e.g. a float64 element printed as 1).
+func goSyntaxString[E comparable](typeName string, set ComparableSet[E]) string {
+	var zero E
+	elementType := reflect.TypeOf(&zero).Elem()
+
+	var stringBuilder strings.Builder
+	fmt.Fprintf(&stringBuilder, "set.%sOf[%v](", typeName, elementType)
+
+	i := 0
+	for element := range set.All() {
+		if i > 0 {
+			stringBuilder.WriteString(", ")
+		}
+		fmt.Fprintf(&stringBuilder, "%#v", element)
+
+		i++
+	}
+
+	stringBuilder.WriteByte(')')
+	return stringBuilder.String()
+}
+
+// SortedString returns a string representation of the given set like its String method, but with
+// the elements in ascending order. Since sets are unordered, String may print elements in a
+// different order each time, which SortedString avoids, making its output suitable for logs that
+// are compared across runs, and for golden tests.
+//
+// A HashSet of elements 3, 1 and 2 will be printed as: HashSet{1, 2, 3}
+//
+// For element types that are not ordered, use [SortedStringFunc].
+func SortedString[E cmp.Ordered](set ComparableSet[E]) string {
+	return SortedStringFunc(set, cmp.Compare[E])
+}
+
+// SortedStringFunc returns a string representation of the given set like its String method, but
+// with the elements sorted by the given compare function, which returns a negative number if a < b,
+// a positive number if a > b, and 0 if they are equal. See [SortedString].
+//
+// For elements that implement [fmt.Stringer], elements can be sorted by their string:
+//
+//	set.SortedStringFunc(statuses, func(a, b Status) int {
+//		return strings.Compare(a.String(), b.String())
+//	})
+func SortedStringFunc[E comparable](set ComparableSet[E], compare func(a, b E) int) string {
+	typeName := setTypeName(set)
+
+	elements := set.ToSlice()
+	slices.SortFunc(elements, compare)
+
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString(typeName)
+	stringBuilder.WriteByte('{')
+
+	for i, element := range elements {
+		if i > 0 {
+			stringBuilder.WriteString(", ")
+		}
+		fmt.Fprint(&stringBuilder, element)
+	}
+
+	stringBuilder.WriteByte('}')
+	return stringBuilder.String()
+}
+
+// setTypeName returns the type name that the given set's String method prints before its elements,
+// without formatting the elements. Wrapper sets prefix the name of their wrapped set, like their
+// String methods do (e.g. ObservableHashSet). For other sets, the name is the set's Go type name,
+// without type parameters.
+func setTypeName[E comparable](set ComparableSet[E]) string {
+	switch set := set.(type) {
+	case *ObservableSet[E]:
+		return "Observable" + setTypeName[E](set.inner)
+	case *TrackedSet[E]:
+		return "Tracked" + setTypeName[E](set.inner)
+	case *UndoableSet[E]:
+		return "Undoable" + setTypeName[E](set.inner)
+	case *ConstrainedSet[E]:
+		return "Constrained" + setTypeName[E](set.inner)
+	case *NormalizedSet[E]:
+		return "Normalized" + setTypeName[E](set.inner)
+	case *SynchronizedSet[E]:
+		set.lock.RLock()
+		inner := set.inner
+		if set.observed != nil {
+			inner = set.observed.inner
+		}
+		set.lock.RUnlock()
+
+		return "Synchronized" + setTypeName[E](inner)
+	}
+
+	setType := reflect.TypeOf(set)
+	if setType.Kind() == reflect.Pointer {
+		setType = setType.Elem()
+	}
+
+	typeName, _, _ := strings.Cut(setType.Name(), "[")
+	return typeName
+}
+
+// FormatDiff returns a human-readable description of the difference between the expected and the
+// actual set, listing the elements that are missing from the actual set and the unexpected
+// elements that are not in the expected set, one per line. It returns "" if the sets are equal.
+// This is useful in test failures, where printing the two sets would leave it to the reader to
+// compare them:
+//
+//	if diff := set.FormatDiff(expected, actual); diff != "" {
+//		t.Errorf("unexpected users:\n%s", diff)
+//	}
+//
+// For two sets with elements 1, 2, 3 (expected) and 2, 3, 4, 5 (actual), the diff is:
+//
+//	missing 1 element:
+//	  - 1
+//	unexpected 2 elements:
+//	  + 4
+//	  + 5
+//
+// Elements of integer, float and string kinds are listed in ascending order. Other elements are
+// sorted by their formatting with fmt, so the output is deterministic as long as that is.
+func FormatDiff[E comparable](expected ComparableSet[E], actual ComparableSet[E]) string {
+	var missing []E
+	for element := range expected.All() {
+		if !actual.Contains(element) {
+			missing = append(missing, element)
+		}
+	}
+
+	var unexpected []E
+	for element := range actual.All() {
+		if !expected.Contains(element) {
+			unexpected = append(unexpected, element)
+		}
+	}
+
+	var stringBuilder strings.Builder
+	writeDiffSection(&stringBuilder, "missing", '-', missing)
+	writeDiffSection(&stringBuilder, "unexpected", '+', unexpected)
+	return strings.TrimSuffix(stringBuilder.String(), "\n")
+}
+
+func writeDiffSection[E comparable](
+	stringBuilder *strings.Builder,
+	heading string,
+	marker byte,
+	elements []E,
+) {
+	if len(elements) == 0 {
+		return
+	}
+
+	slices.SortFunc(elements, compareForDisplay[E])
+
+	noun := "elements"
+	if len(elements) == 1 {
+		noun = "element"
+	}
+	fmt.Fprintf(stringBuilder, "%s %d %s:\n", heading, len(elements), noun)
+
+	for _, element := range elements {
+		stringBuilder.WriteString("  ")
+		stringBuilder.WriteByte(marker)
+		stringBuilder.WriteByte(' ')
+		fmt.Fprint(stringBuilder, element)
+		stringBuilder.WriteByte('\n')
+	}
+}
+
+// compareForDisplay orders elements of ordered kinds by their value, and other elements by their
+// formatting with fmt.
+func compareForDisplay[E comparable](a E, b E) int {
+	aValue, bValue := reflect.ValueOf(a), reflect.ValueOf(b)
+	if aValue.IsValid() && bValue.IsValid() && aValue.Kind() == bValue.Kind() {
+		switch aValue.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return cmp.Compare(aValue.Int(), bValue.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Uintptr:
+			return cmp.Compare(aValue.Uint(), bValue.Uint())
+		case reflect.Float32, reflect.Float64:
+			return cmp.Compare(aValue.Float(), bValue.Float())
+		case reflect.String:
+			return strings.Compare(aValue.String(), bValue.String())
+		}
+	}
+
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func writePadded(state fmt.State, output string) {
+	width, hasWidth := state.Width()
+	padding := width - utf8.RuneCountInString(output)
+	if !hasWidth || padding <= 0 {
+		_, _ = state.Write([]byte(output))
+		return
+	}
+
+	if state.Flag('-') {
+		_, _ = state.Write([]byte(output + strings.Repeat(" ", padding)))
+	} else {
+		_, _ = state.Write([]byte(strings.Repeat(" ", padding) + output))
+	}
+}
@@ -0,0 +1,130 @@
+package set
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// FormatOptions configures the text representation used by [FormatSet] and [ParseSet].
+// The zero value formats elements separated by commas, with no surrounding brackets.
+type FormatOptions[E comparable] struct {
+	// Delimiter separates elements. If empty, "," is used.
+	//
+	// Elements are not escaped, so formatted elements must not contain the delimiter for the
+	// output to be parsed correctly.
+	Delimiter string
+
+	// Prefix and Suffix surround the elements, e.g. "[" and "]". They are optional.
+	Prefix string
+	Suffix string
+
+	// FormatElement formats an element for FormatSet. If nil, elements are formatted with
+	// [fmt.Sprint].
+	FormatElement func(element E) string
+
+	// ParseElement parses an element for ParseSet. If nil, strings, numbers, bools and element types
+	// implementing [encoding.TextUnmarshaler] (on their pointer type) are supported.
+	ParseElement func(text string) (E, error)
+
+	// Compare makes FormatSet sort elements, by returning a negative number if a < b, a positive
+	// number if a > b, and 0 if they are equal (e.g. cmp.Compare). If nil, elements are printed in
+	// the set's iteration order.
+	Compare func(a E, b E) int
+
+	// TrimSpace makes ParseSet trim whitespace around each element, and around the input as a whole.
+	TrimSpace bool
+}
+
+// FormatSet formats the given set as text, as configured by the given options.
+// For example, with Delimiter "|", the set {a, b, c} is formatted as "a|b|c".
+func FormatSet[E comparable](set ComparableSet[E], options FormatOptions[E]) string {
+	elements := make([]E, 0, set.Size())
+	for element := range set.All() {
+		elements = append(elements, element)
+	}
+
+	if options.Compare != nil {
+		slices.SortFunc(elements, options.Compare)
+	}
+
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString(options.Prefix)
+
+	for i, element := range elements {
+		if i > 0 {
+			stringBuilder.WriteString(options.delimiter())
+		}
+
+		if options.FormatElement != nil {
+			stringBuilder.WriteString(options.FormatElement(element))
+		} else {
+			fmt.Fprint(&stringBuilder, element)
+		}
+	}
+
+	stringBuilder.WriteString(options.Suffix)
+	return stringBuilder.String()
+}
+
+// ParseSet parses a set from the given text, as configured by the given options. It is the inverse
+// of [FormatSet]. Input with no elements between the prefix and suffix gives an empty set.
+//
+// The returned set is a DynamicSet, which picks its backing storage based on the number of
+// elements. If parsing fails, the returned set is empty.
+func ParseSet[E comparable](input string, options FormatOptions[E]) (DynamicSet[E], error) {
+	set := NewDynamicSet[E]()
+
+	if options.TrimSpace {
+		input = strings.TrimSpace(input)
+	}
+
+	if !strings.HasPrefix(input, options.Prefix) {
+		return set, fmt.Errorf("set: expected input to start with '%s'", options.Prefix)
+	}
+	input = input[len(options.Prefix):]
+
+	if !strings.HasSuffix(input, options.Suffix) {
+		return set, fmt.Errorf("set: expected input to end with '%s'", options.Suffix)
+	}
+	input = input[:len(input)-len(options.Suffix)]
+
+	if options.TrimSpace {
+		input = strings.TrimSpace(input)
+	}
+	if input == "" {
+		return set, nil
+	}
+
+	for _, elementText := range strings.Split(input, options.delimiter()) {
+		if options.TrimSpace {
+			elementText = strings.TrimSpace(elementText)
+		}
+
+		var element E
+		var err error
+		if options.ParseElement != nil {
+			element, err = options.ParseElement(elementText)
+		} else {
+			element, err = parseElementText[E](elementText)
+		}
+		if err != nil {
+			return NewDynamicSet[E](), fmt.Errorf(
+				"set: failed to parse element '%s': %w",
+				elementText,
+				err,
+			)
+		}
+
+		set.Add(element)
+	}
+
+	return set, nil
+}
+
+func (options FormatOptions[E]) delimiter() string {
+	if options.Delimiter == "" {
+		return ","
+	}
+	return options.Delimiter
+}
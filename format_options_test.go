@@ -0,0 +1,78 @@
+package set_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestFormatSetRoundTrip(t *testing.T) {
+	options := set.FormatOptions[string]{
+		Delimiter: "|",
+		Compare:   strings.Compare,
+	}
+
+	formatted := set.FormatSet[string](set.HashSetOf("c", "a", "b"), options)
+	if formatted != "a|b|c" {
+		t.Errorf("expected formatted set a|b|c, got %s", formatted)
+	}
+
+	parsed, err := set.ParseSet(formatted, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSize(t, parsed, 3)
+	assertContains(t, parsed, "a", "b", "c")
+}
+
+func TestFormatSetCustomElements(t *testing.T) {
+	options := set.FormatOptions[int]{
+		Delimiter:     "; ",
+		Prefix:        "[",
+		Suffix:        "]",
+		FormatElement: func(element int) string { return "#" + strconv.Itoa(element) },
+		ParseElement: func(text string) (int, error) {
+			return strconv.Atoi(strings.TrimPrefix(text, "#"))
+		},
+		Compare: func(a int, b int) int { return a - b },
+	}
+
+	formatted := set.FormatSet[int](set.ArraySetOf(2, 1), options)
+	if formatted != "[#1; #2]" {
+		t.Errorf("expected formatted set [#1; #2], got %s", formatted)
+	}
+
+	parsed, err := set.ParseSet(formatted, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSize(t, parsed, 2)
+	assertContains(t, parsed, 1, 2)
+}
+
+func TestParseSet(t *testing.T) {
+	options := set.FormatOptions[int]{Prefix: "{", Suffix: "}", TrimSpace: true}
+
+	parsed, err := set.ParseSet(" { 1, 2 ,3,3 } ", options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSize(t, parsed, 3)
+	assertContains(t, parsed, 1, 2, 3)
+
+	empty, err := set.ParseSet("{}", options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSize(t, empty, 0)
+
+	for _, invalid := range []string{"1,2}", "{1,2", "{1,a}"} {
+		parsed, err := set.ParseSet(invalid, options)
+		if err == nil {
+			t.Errorf("expected error when parsing invalid input %s", invalid)
+		}
+		assertSize(t, &parsed, 0)
+	}
+}
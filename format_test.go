@@ -0,0 +1,77 @@
+package set_test
+
+import (
+	"fmt"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestFormat(t *testing.T) {
+	for _, testCase := range []struct {
+		format   string
+		set      any
+		expected string
+	}{
+		{"%v", set.ArraySetOf(1, 2, 3), "ArraySet{1, 2, 3}"},
+		{"%s", set.ArraySetOf(1, 2, 3), "ArraySet{1, 2, 3}"},
+		{"%q", set.ArraySetOf("a", "b"), `ArraySet{"a", "b"}`},
+		{"%q", set.ArraySetOf(1, 2), "ArraySet{1, 2}"},
+		{"%x", set.ArraySetOf(10, 255), "ArraySet{a, ff}"},
+		{"%#x", set.ArraySetOf(10, 255), "ArraySet{0xa, 0xff}"},
+		{"%.2v", set.ArraySetOf(1, 2, 3), "ArraySet{1, 2, ...}"},
+		{"%.0v", set.ArraySetOf(1, 2, 3), "ArraySet{...}"},
+		{"%.5v", set.ArraySetOf(1, 2, 3), "ArraySet{1, 2, 3}"},
+		{"%14v", set.ArraySetOf(1), "   ArraySet{1}"},
+		{"%-14v|", set.ArraySetOf(1), "ArraySet{1}   |"},
+		{"%#v", set.ArraySetOf("a", "b"), `set.ArraySetOf[string]("a", "b")`},
+		{"%#v", set.HashSetOf[float64](1), "set.HashSetOf[float64](1)"},
+		{"%#v", set.DynamicSet[int]{}, "set.DynamicSetOf[int]()"},
+	} {
+		if actual := fmt.Sprintf(testCase.format, testCase.set); actual != testCase.expected {
+			t.Errorf(
+				"expected fmt.Sprintf(%q, set) == %s, got %s",
+				testCase.format,
+				testCase.expected,
+				actual,
+			)
+		}
+	}
+}
+
+func TestGoString(t *testing.T) {
+	type id int
+
+	for _, testCase := range []struct {
+		set      fmt.GoStringer
+		expected string
+	}{
+		{set.ArraySetOf(1, 2, 3), "set.ArraySetOf[int](1, 2, 3)"},
+		{set.HashSetOf[id](5), "set.HashSetOf[set_test.id](5)"},
+		{set.DynamicSetOf("a"), `set.DynamicSetOf[string]("a")`},
+	} {
+		if actual := testCase.set.GoString(); actual != testCase.expected {
+			t.Errorf("expected GoString() == %s, got %s", testCase.expected, actual)
+		}
+	}
+}
+
+func TestFormatDiff(t *testing.T) {
+	expected := set.ArraySetOf(1, 2, 3, 10)
+	actual := set.HashSetOf(2, 3, 9, 4)
+
+	diff := set.FormatDiff[int](expected, actual)
+	expectedDiff := "missing 2 elements:\n  - 1\n  - 10\nunexpected 2 elements:\n  + 4\n  + 9"
+	if diff != expectedDiff {
+		t.Errorf("expected diff:\n%s\ngot:\n%s", expectedDiff, diff)
+	}
+
+	if diff := set.FormatDiff[int](actual, actual.Copy()); diff != "" {
+		t.Errorf("expected empty diff for equal sets, got %q", diff)
+	}
+
+	onlyMissing := set.FormatDiff[string](set.ArraySetOf("a", "b"), set.ArraySetOf("b"))
+	if onlyMissing != "missing 1 element:\n  - a" {
+		t.Errorf("unexpected diff %q", onlyMissing)
+	}
+}
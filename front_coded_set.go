@@ -0,0 +1,283 @@
+package set
+
+import (
+	"encoding/binary"
+	"iter"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// A FrontCodedSet is a compact, read-only set of strings, for large dictionaries (such as URLs or
+// file paths) that take too much memory in a [HashSet]. Strings are stored sorted, in blocks of 16,
+// where the first string of each block is stored in full, and each following string only stores
+// the bytes that differ from the previous string (front coding). Since sorted strings with common
+// prefixes share most of their bytes, this typically takes a fraction of the memory of storing
+// each string separately, and avoids the per-string pointers that the garbage collector would
+// have to scan.
+//
+// The memory savings come at a cost for lookups: Contains finds the block that may hold the string
+// with binary search, and then decodes the strings in the block one by one, so it is O(log n), and
+// slower than a HashSet. Iteration decodes every string, and gives the strings in sorted order.
+//
+// A FrontCodedSet is created from all its strings at once with [NewFrontCodedSet], and cannot be
+// modified afterwards. It is safe for concurrent use, since it is read-only.
+//
+// FrontCodedSet implements [ComparableSet], but not [Set], since it is read-only.
+type FrontCodedSet[S ~string] struct {
+	// data holds the blocks: for the first string of a block, a uvarint length followed by the
+	// string's bytes, and for the other strings, a uvarint with the length of the prefix shared
+	// with the previous string, then a uvarint length and the bytes of the rest of the string.
+	data         []byte
+	blockOffsets []int
+	size         int
+}
+
+// The number of strings in each block of a FrontCodedSet. Larger blocks share more prefixes, but
+// make lookups decode more strings.
+const frontCodedBlockSize = 16
+
+// NewFrontCodedSet creates a new [FrontCodedSet] with the given strings. Duplicate strings are
+// added only once. The given slice is not modified, but a sorted copy of it is made while building
+// the set, so this temporarily uses the memory of the strings twice.
+func NewFrontCodedSet[S ~string](elements []S) FrontCodedSet[S] {
+	sorted := slices.Clone(elements)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+
+	set := FrontCodedSet[S]{
+		data:         nil,
+		blockOffsets: make([]int, 0, (len(sorted)+frontCodedBlockSize-1)/frontCodedBlockSize),
+		size:         len(sorted),
+	}
+
+	for i, element := range sorted {
+		if i%frontCodedBlockSize == 0 {
+			set.blockOffsets = append(set.blockOffsets, len(set.data))
+			set.data = binary.AppendUvarint(set.data, uint64(len(element)))
+			set.data = append(set.data, element...)
+			continue
+		}
+
+		previous := sorted[i-1]
+		shared := 0
+		for shared < len(previous) && shared < len(element) && previous[shared] == element[shared] {
+			shared++
+		}
+
+		set.data = binary.AppendUvarint(set.data, uint64(shared))
+		set.data = binary.AppendUvarint(set.data, uint64(len(element)-shared))
+		set.data = append(set.data, element[shared:]...)
+	}
+
+	set.data = slices.Clip(set.data)
+	return set
+}
+
+// Contains checks if given string is present in the set.
+func (set FrontCodedSet[S]) Contains(element S) bool {
+	if set.size == 0 {
+		return false
+	}
+
+	str := string(element)
+
+	// Finds the last block whose first string is <= the given string.
+	block := sort.Search(len(set.blockOffsets), func(block int) bool {
+		first, _ := set.decodeFirst(block)
+		return string(first) > str
+	}) - 1
+	if block < 0 {
+		return false
+	}
+
+	found := false
+	set.decodeBlock(block, func(current []byte) bool {
+		if string(current) >= str {
+			found = string(current) == str
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// Size returns the number of strings in the set.
+func (set FrontCodedSet[S]) Size() int {
+	return set.size
+}
+
+// IsEmpty checks if there are 0 strings in the set.
+func (set FrontCodedSet[S]) IsEmpty() bool {
+	return set.size == 0
+}
+
+// MemorySize returns the number of bytes used by the set's encoded strings and block index. This
+// can be compared with the total length of the strings, to see how much memory front coding saves.
+func (set FrontCodedSet[S]) MemorySize() int {
+	const intSize = 8
+	return len(set.data) + len(set.blockOffsets)*intSize
+}
+
+// Equals checks if the set contains exactly the same strings as the other given set.
+func (set FrontCodedSet[S]) Equals(otherSet ComparableSet[S]) bool {
+	return set.size == otherSet.Size() && set.IsSubsetOf(otherSet)
+}
+
+// IsSubsetOf checks if all of the strings in the set exist in the other given set.
+func (set FrontCodedSet[S]) IsSubsetOf(otherSet ComparableSet[S]) bool {
+	if set.size > otherSet.Size() {
+		return false
+	}
+
+	for element := range set.All() {
+		if !otherSet.Contains(element) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSupersetOf checks if the set contains all of the strings in the other given set.
+func (set FrontCodedSet[S]) IsSupersetOf(otherSet ComparableSet[S]) bool {
+	return otherSet.IsSubsetOf(set)
+}
+
+// Union creates a new set that contains all the strings of the receiver set and the other given
+// set. Since FrontCodedSet is read-only, the underlying type of the returned set is a *HashSet.
+func (set FrontCodedSet[S]) Union(otherSet ComparableSet[S]) Set[S] {
+	union := HashSetWithCapacity[S](set.size + otherSet.Size())
+	for element := range set.All() {
+		union.Add(element)
+	}
+	union.AddFromSet(otherSet)
+	return &union
+}
+
+// Intersection creates a new set with only the strings that exist in both the receiver set and the
+// other given set. Since FrontCodedSet is read-only, the underlying type of the returned set is a
+// *HashSet.
+func (set FrontCodedSet[S]) Intersection(otherSet ComparableSet[S]) Set[S] {
+	intersection := NewHashSet[S]()
+	for element := range otherSet.All() {
+		if set.Contains(element) {
+			intersection.Add(element)
+		}
+	}
+	return &intersection
+}
+
+// ToSlice creates a slice with all the strings in the set, in sorted order.
+func (set FrontCodedSet[S]) ToSlice() []S {
+	return set.AppendTo(make([]S, 0, set.size))
+}
+
+// AppendTo appends the strings in the set to the given slice in sorted order, and returns the
+// extended slice, like the append builtin.
+func (set FrontCodedSet[S]) AppendTo(slice []S) []S {
+	for element := range set.All() {
+		slice = append(slice, element)
+	}
+	return slice
+}
+
+// ToMap creates a map with all the set's strings as keys.
+func (set FrontCodedSet[S]) ToMap() map[S]struct{} {
+	m := make(map[S]struct{}, set.size)
+	for element := range set.All() {
+		m[element] = struct{}{}
+	}
+	return m
+}
+
+// Copy creates a new set with all the same strings as the original set. Since FrontCodedSet is
+// read-only, the underlying type of the returned set is a *HashSet.
+func (set FrontCodedSet[S]) Copy() Set[S] {
+	newSet := HashSetWithCapacity[S](set.size)
+	for element := range set.All() {
+		newSet.Add(element)
+	}
+	return &newSet
+}
+
+// String returns a string representation of the set, implementing [fmt.Stringer].
+//
+// A FrontCodedSet of strings "a", "b" and "c" will be printed as: FrontCodedSet{a, b, c}
+func (set FrontCodedSet[S]) String() string {
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString("FrontCodedSet{")
+
+	i := 0
+	for element := range set.All() {
+		stringBuilder.WriteString(string(element))
+
+		if i < set.size-1 {
+			stringBuilder.WriteString(", ")
+		}
+
+		i++
+	}
+
+	stringBuilder.WriteByte('}')
+	return stringBuilder.String()
+}
+
+// All returns an iterator over the strings in the set, which can be used in a for-range loop:
+//
+//	for element := range set.All() {
+//		fmt.Println(element)
+//	}
+//
+// Strings are iterated in sorted order.
+func (set FrontCodedSet[S]) All() iter.Seq[S] {
+	return func(yield func(element S) bool) {
+		for block := range set.blockOffsets {
+			stopped := false
+			set.decodeBlock(block, func(current []byte) bool {
+				stopped = !yield(S(current))
+				return !stopped
+			})
+			if stopped {
+				return
+			}
+		}
+	}
+}
+
+// decodeFirst returns the first string of the given block, as a slice of the set's data, and the
+// offset after it.
+func (set FrontCodedSet[S]) decodeFirst(block int) (first []byte, end int) {
+	offset := set.blockOffsets[block]
+	length, bytesRead := binary.Uvarint(set.data[offset:])
+	offset += bytesRead
+	return set.data[offset : offset+int(length)], offset + int(length)
+}
+
+// decodeBlock calls the given function with each string in the given block, in order, until it
+// returns false. The slice passed to the function is only valid until the function returns.
+func (set FrontCodedSet[S]) decodeBlock(block int, handle func(current []byte) bool) {
+	first, offset := set.decodeFirst(block)
+	current := append([]byte(nil), first...)
+	if !handle(current) {
+		return
+	}
+
+	end := len(set.data)
+	if block+1 < len(set.blockOffsets) {
+		end = set.blockOffsets[block+1]
+	}
+
+	for offset < end {
+		shared, bytesRead := binary.Uvarint(set.data[offset:])
+		offset += bytesRead
+		suffixLength, bytesRead := binary.Uvarint(set.data[offset:])
+		offset += bytesRead
+
+		current = append(current[:shared], set.data[offset:offset+int(suffixLength)]...)
+		offset += int(suffixLength)
+
+		if !handle(current) {
+			return
+		}
+	}
+}
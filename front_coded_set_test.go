@@ -0,0 +1,63 @@
+package set_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestFrontCodedSet(t *testing.T) {
+	var urls []string
+	for i := range 1000 {
+		urls = append(urls, fmt.Sprintf("https://example.com/articles/%d", i))
+	}
+	// Duplicates are added only once.
+	urls = append(urls, urls[:10]...)
+
+	frontCodedSet := set.NewFrontCodedSet(urls)
+
+	assertSize(t, frontCodedSet, 1000)
+	assertContains(t, frontCodedSet, urls...)
+	for _, missing := range []string{
+		"",
+		"a",
+		"https://example.com/articles/",
+		"https://example.com/articles/1000",
+		"zzz",
+	} {
+		if frontCodedSet.Contains(missing) {
+			t.Errorf("expected FrontCodedSet to not contain %q", missing)
+		}
+	}
+
+	sorted := slices.Sorted(slices.Values(urls[:1000]))
+	if !slices.Equal(frontCodedSet.ToSlice(), sorted) {
+		t.Errorf("expected ToSlice to return the strings in sorted order")
+	}
+
+	totalLength := 0
+	for _, url := range urls[:1000] {
+		totalLength += len(url)
+	}
+	if memorySize := frontCodedSet.MemorySize(); memorySize >= totalLength/2 {
+		t.Errorf("expected memory size less than half of %d bytes, got %d", totalLength, memorySize)
+	}
+
+	if !frontCodedSet.Equals(set.HashSetFromSlice(urls)) {
+		t.Errorf("expected FrontCodedSet to equal HashSet of the same strings")
+	}
+}
+
+func TestFrontCodedSetEmpty(t *testing.T) {
+	frontCodedSet := set.NewFrontCodedSet[string](nil)
+
+	assertSize(t, frontCodedSet, 0)
+	if frontCodedSet.Contains("") {
+		t.Errorf("expected empty FrontCodedSet to not contain empty string")
+	}
+	if actual := frontCodedSet.String(); actual != "FrontCodedSet{}" {
+		t.Errorf("expected FrontCodedSet{}, got %s", actual)
+	}
+}
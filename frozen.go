@@ -0,0 +1,120 @@
+package set
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// A Frozen is an immutable, comparable handle to the elements of a set, created with [Freeze].
+// Unlike sets, Frozen values can be compared with ==, used as map keys, and added to other sets.
+// Two Frozen values are equal exactly when the sets they were frozen from have the same elements,
+// regardless of set type and iteration order. This allows building sets of sets, or memoizing
+// computations keyed by an input set:
+//
+//	cache := make(map[set.Frozen[string]]Result)
+//	key, err := set.Freeze(inputSet)
+//	if err != nil { ... }
+//	if result, ok := cache[key]; ok { ... }
+//
+// A Frozen holds the elements in the canonical binary encoding used by [EncodeForFuzz], so it
+// supports the same element types as MarshalBinary. Elements are compared by their encoded bytes,
+// with -0.0 encoded as 0.0 (also in fields and arrays), so that floats compare like they do with
+// ==. Element types implementing [encoding.BinaryMarshaler] must encode equal elements to the same
+// bytes.
+//
+// The zero value for a Frozen is an empty set.
+type Frozen[E comparable] struct {
+	encoded string
+	size    int
+}
+
+// Freeze creates a [Frozen] handle to the elements currently in the given set. Later changes to
+// the set do not affect the returned handle. Freezing encodes and sorts the elements, which is
+// O(n log n).
+//
+// Returns an error if E is not supported by the binary encoding (see [Frozen]).
+func Freeze[E comparable](set ComparableSet[E]) (Frozen[E], error) {
+	codec, err := newBinaryElementCodec[E]()
+	if err != nil {
+		return Frozen[E]{}, err
+	}
+
+	encoded, err := encodeCanonical(codec, set)
+	if err != nil {
+		return Frozen[E]{}, fmt.Errorf("set: failed to encode element for freezing: %w", err)
+	}
+
+	return Frozen[E]{encoded: string(encoded), size: set.Size()}, nil
+}
+
+// Size returns the number of elements in the frozen set.
+func (frozen Frozen[E]) Size() int {
+	return frozen.size
+}
+
+// IsEmpty checks if there are 0 elements in the frozen set.
+func (frozen Frozen[E]) IsEmpty() bool {
+	return frozen.size == 0
+}
+
+// ToSlice decodes the elements in the frozen set into a new slice, which can be passed to a set
+// constructor such as [HashSetFromSlice] to get a mutable set again.
+func (frozen Frozen[E]) ToSlice() []E {
+	elements := make([]E, 0, frozen.size)
+	for element := range frozen.All() {
+		elements = append(elements, element)
+	}
+	return elements
+}
+
+// All returns an iterator over the elements in the frozen set, which can be used in a for-range
+// loop. Elements are decoded as they are iterated, in the order of their encoded bytes.
+func (frozen Frozen[E]) All() iter.Seq[E] {
+	return func(yield func(element E) bool) {
+		if frozen.encoded == "" {
+			return
+		}
+
+		// Freeze already checked that E is supported, and the encoding was produced by the same
+		// codec, so these cannot fail.
+		codec, err := newBinaryElementCodec[E]()
+		if err != nil {
+			return
+		}
+
+		data := []byte(frozen.encoded)
+		for len(data) != 0 {
+			element, bytesRead, err := codec.decode(data)
+			if err != nil || bytesRead <= 0 {
+				return
+			}
+
+			if !yield(element) {
+				return
+			}
+			data = data[bytesRead:]
+		}
+	}
+}
+
+// String returns a string representation of the frozen set, implementing [fmt.Stringer]. A Frozen
+// set of elements 1, 2 and 3 will be printed as: Frozen{1, 2, 3}
+func (frozen Frozen[E]) String() string {
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString("Frozen{")
+
+	i := 0
+	for element := range frozen.All() {
+		fmt.Fprint(&stringBuilder, element)
+
+		if i < frozen.size-1 {
+			stringBuilder.WriteString(", ")
+		}
+
+		i++
+	}
+
+	stringBuilder.WriteByte('}')
+	return stringBuilder.String()
+}
@@ -0,0 +1,95 @@
+package set_test
+
+import (
+	"math"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestFreeze(t *testing.T) {
+	arraySet := set.ArraySetOf("a", "b", "c")
+	hashSet := set.HashSetOf("c", "a", "b")
+
+	frozenArraySet, err := set.Freeze(arraySet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	frozenHashSet, err := set.Freeze(hashSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if frozenArraySet != frozenHashSet {
+		t.Errorf("expected frozen %v and %v to be equal", arraySet, hashSet)
+	}
+
+	hashSet.Add("d")
+	if frozenHashSet.Size() != 3 {
+		t.Errorf("expected frozen set to be unaffected by later Add, got %v", frozenHashSet)
+	}
+
+	frozenLarger, err := set.Freeze(hashSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if frozenLarger == frozenHashSet {
+		t.Errorf("expected frozen %v to differ from %v", frozenLarger, frozenHashSet)
+	}
+
+	setOfSets := set.HashSetOf(frozenArraySet, frozenHashSet, frozenLarger)
+	assertSize(t, setOfSets, 2)
+
+	thawed := set.HashSetFromSlice(frozenLarger.ToSlice())
+	if !thawed.Equals(hashSet) {
+		t.Errorf("expected thawed %v to equal %v", thawed, hashSet)
+	}
+
+	if str := frozenArraySet.String(); str != "Frozen{a, b, c}" {
+		t.Errorf("unexpected string of frozen set: %s", str)
+	}
+}
+
+func TestFreezeNegativeZero(t *testing.T) {
+	negativeZero := math.Copysign(0, -1)
+
+	testFreezeEqual(t, set.HashSetOf(0.0), set.HashSetOf(negativeZero))
+	testFreezeEqual(
+		t,
+		set.HashSetOf([2]float32{0, 1}),
+		set.HashSetOf([2]float32{float32(negativeZero), 1}),
+	)
+	testFreezeEqual(
+		t,
+		set.HashSetOf(binaryTestStruct{ID: 1, Score: 0}),
+		set.HashSetOf(binaryTestStruct{ID: 1, Score: negativeZero}),
+	)
+}
+
+func testFreezeEqual[E comparable](t *testing.T, a set.HashSet[E], b set.HashSet[E]) {
+	t.Helper()
+
+	if !a.Equals(b) {
+		t.Fatalf("expected %v to equal %v", a, b)
+	}
+
+	frozenA, err := set.Freeze[E](a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	frozenB, err := set.Freeze[E](b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if frozenA != frozenB {
+		t.Errorf("expected frozen %v to equal frozen %v, like the sets are equal", a, b)
+	}
+}
+
+func TestFreezeUnsupportedType(t *testing.T) {
+	type unsupported struct{ s string }
+
+	if _, err := set.Freeze(set.ArraySetOf(unsupported{s: "a"})); err == nil {
+		t.Error("expected error when freezing set of unsupported element type")
+	}
+}
@@ -0,0 +1,165 @@
+package set
+
+import (
+	"bytes"
+	"encoding"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// EncodeForFuzz encodes the elements of the given set as fuzzing input, for use as seed corpus
+// entries with [testing.F.Add]. Decode the fuzzed input with [DecodeForFuzz].
+//
+// Unlike MarshalBinary, the encoding is canonical: two sets with the same elements always encode to
+// the same bytes, regardless of set type and iteration order. Elements are encoded as described for
+// MarshalBinary, but without the size prefix, and sorted by their encoded bytes. Floats equal to
+// zero are always encoded as +0, since -0 == +0. The same element types as for MarshalBinary are
+// supported.
+func EncodeForFuzz[E comparable](set ComparableSet[E]) ([]byte, error) {
+	codec, err := newBinaryElementCodec[E]()
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := encodeCanonical(codec, set)
+	if err != nil {
+		return nil, fmt.Errorf("set: failed to encode element for fuzzing: %w", err)
+	}
+	return encoded, nil
+}
+
+// encodeCanonical encodes the elements of the given set with the given codec, sorted by their
+// encoded bytes, so that sets with the same elements always encode to the same bytes.
+func encodeCanonical[E comparable](
+	codec binaryElementCodec[E],
+	set ComparableSet[E],
+) ([]byte, error) {
+	canonicalize := floatZeroCanonicalizer[E]()
+
+	encodedElements := make([][]byte, 0, set.Size())
+	for element := range set.All() {
+		if canonicalize != nil {
+			element = canonicalize(element)
+		}
+
+		encoded, err := codec.encode(nil, element)
+		if err != nil {
+			return nil, err
+		}
+
+		encodedElements = append(encodedElements, encoded)
+	}
+
+	slices.SortFunc(encodedElements, bytes.Compare)
+	return bytes.Join(encodedElements, nil), nil
+}
+
+// floatZeroCanonicalizer returns a function that replaces -0 with +0 in float and complex
+// elements, including in fields and array elements of the element type. Since -0 and +0 are equal
+// by ==, a set never holds both, but they have different bit patterns, so they would otherwise get
+// different canonical encodings. Returns nil if the element type contains no floats, or is encoded
+// with its own MarshalBinary method.
+func floatZeroCanonicalizer[E comparable]() func(element E) E {
+	var zero E
+	if _, ok := any(zero).(encoding.BinaryMarshaler); ok {
+		if _, ok := any(&zero).(encoding.BinaryUnmarshaler); ok {
+			return nil
+		}
+	}
+
+	if !containsFloats(reflect.TypeFor[E]()) {
+		return nil
+	}
+
+	return func(element E) E {
+		canonicalizeFloatZeros(reflect.ValueOf(&element).Elem())
+		return element
+	}
+}
+
+func containsFloats(valueType reflect.Type) bool {
+	switch valueType.Kind() {
+	case reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	case reflect.Array:
+		return containsFloats(valueType.Elem())
+	case reflect.Struct:
+		for i := range valueType.NumField() {
+			if containsFloats(valueType.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func canonicalizeFloatZeros(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if value.Float() == 0 && value.CanSet() {
+			value.SetFloat(0)
+		}
+	case reflect.Complex64, reflect.Complex128:
+		if value.CanSet() {
+			complexValue := value.Complex()
+			realPart, imaginaryPart := real(complexValue), imag(complexValue)
+			if realPart == 0 {
+				realPart = 0
+			}
+			if imaginaryPart == 0 {
+				imaginaryPart = 0
+			}
+			value.SetComplex(complex(realPart, imaginaryPart))
+		}
+	case reflect.Array:
+		for i := range value.Len() {
+			canonicalizeFloatZeros(value.Index(i))
+		}
+	case reflect.Struct:
+		for i := range value.NumField() {
+			canonicalizeFloatZeros(value.Field(i))
+		}
+	}
+}
+
+// DecodeForFuzz decodes fuzzing input in the format produced by [EncodeForFuzz] into a slice of
+// elements, which can then be passed to a set constructor such as [HashSetFromSlice]:
+//
+//	func FuzzUnion(f *testing.F) {
+//		seed, _ := set.EncodeForFuzz(set.HashSetOf(1, 2, 3))
+//		f.Add(seed)
+//
+//		f.Fuzz(func(t *testing.T, data []byte) {
+//			elements, err := set.DecodeForFuzz[int](data)
+//			if err != nil {
+//				t.Fatal(err)
+//			}
+//			hashSet := set.HashSetFromSlice(elements)
+//			// ...
+//		})
+//	}
+//
+// Since the fuzzer mutates input arbitrarily, decoding is lenient: elements are decoded until the
+// data ends or an element fails to decode, and the elements decoded up to that point are returned.
+// The returned slice may contain duplicates. An error is only returned if E is not supported by
+// the encoding, in which case the fuzz target should fail.
+func DecodeForFuzz[E comparable](data []byte) ([]E, error) {
+	codec, err := newBinaryElementCodec[E]()
+	if err != nil {
+		return nil, err
+	}
+
+	var elements []E
+	for len(data) != 0 {
+		element, bytesRead, err := codec.decode(data)
+		if err != nil || bytesRead <= 0 {
+			break
+		}
+
+		elements = append(elements, element)
+		data = data[bytesRead:]
+	}
+
+	return elements, nil
+}
@@ -0,0 +1,122 @@
+package set_test
+
+import (
+	"bytes"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestEncodeForFuzzIsCanonical(t *testing.T) {
+	arrayData, err := set.EncodeForFuzz[string](set.ArraySetOf("c", "a", "b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashData, err := set.EncodeForFuzz[string](set.HashSetOf("b", "c", "a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(arrayData, hashData) {
+		t.Errorf("expected equal sets to have equal fuzz encodings, got %v and %v", arrayData, hashData)
+	}
+
+	elements, err := set.DecodeForFuzz[string](arrayData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := set.ArraySetFromSlice(elements)
+	assertSize(t, decoded, 3)
+	assertContains(t, decoded, "a", "b", "c")
+}
+
+func TestDecodeForFuzzIsLenient(t *testing.T) {
+	data, err := set.EncodeForFuzz[string](set.ArraySetOf("a", "bcd"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Truncates the last element.
+	elements, err := set.DecodeForFuzz[string](data[:len(data)-1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(elements) != 1 || elements[0] != "a" {
+		t.Errorf("expected only the first element to be decoded from truncated data, got %v", elements)
+	}
+}
+
+func FuzzSetOperations(f *testing.F) {
+	for _, seeds := range [][2]set.ComparableSet[int]{
+		{set.ArraySetOf(1, 2, 3), set.ArraySetOf(2, 3, 4)},
+		{set.HashSetOf[int](), set.HashSetOf(-1, 1000)},
+		{
+			set.HashSetFromSlice(createRandomIntSlice(50)),
+			set.HashSetFromSlice(createRandomIntSlice(50)),
+		},
+	} {
+		data1, err := set.EncodeForFuzz(seeds[0])
+		if err != nil {
+			f.Fatal(err)
+		}
+		data2, err := set.EncodeForFuzz(seeds[1])
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data1, data2)
+	}
+
+	f.Fuzz(func(t *testing.T, data1 []byte, data2 []byte) {
+		elements1, err := set.DecodeForFuzz[int](data1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		elements2, err := set.DecodeForFuzz[int](data2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedUnion := make(map[int]struct{})
+		expectedIntersection := make(map[int]struct{})
+		for _, element := range elements1 {
+			expectedUnion[element] = struct{}{}
+		}
+		for _, element := range elements2 {
+			if _, inFirst := expectedUnion[element]; inFirst {
+				expectedIntersection[element] = struct{}{}
+			}
+		}
+		for _, element := range elements2 {
+			expectedUnion[element] = struct{}{}
+		}
+
+		arraySet1, arraySet2 := set.ArraySetFromSlice(elements1), set.ArraySetFromSlice(elements2)
+		hashSet1, hashSet2 := set.HashSetFromSlice(elements1), set.HashSetFromSlice(elements2)
+		dynamicSet1 := set.DynamicSetFromSlice(elements1)
+		dynamicSet2 := set.DynamicSetFromSlice(elements2)
+
+		for _, sets := range [][2]set.ComparableSet[int]{
+			{arraySet1, arraySet2},
+			{hashSet1, hashSet2},
+			{&dynamicSet1, &dynamicSet2},
+			{arraySet1, hashSet2},
+			{hashSet1, &dynamicSet2},
+		} {
+			union := sets[0].Union(sets[1])
+			assertSize(t, union, len(expectedUnion))
+			for element := range expectedUnion {
+				assertContains(t, union, element)
+			}
+
+			intersection := sets[0].Intersection(sets[1])
+			assertSize(t, intersection, len(expectedIntersection))
+			for element := range expectedIntersection {
+				assertContains(t, intersection, element)
+			}
+
+			if !intersection.IsSubsetOf(union) {
+				t.Errorf("expected intersection %v to be subset of union %v", intersection, union)
+			}
+		}
+	})
+}
@@ -1,3 +1,3 @@
 module hermannm.dev/set
 
-go 1.20
+go 1.24
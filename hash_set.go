@@ -1,7 +1,10 @@
 package set
 
 import (
+	"database/sql/driver"
 	"fmt"
+	"iter"
+	"slices"
 	"strings"
 )
 
@@ -48,6 +51,37 @@ func HashSetFromSlice[E comparable](elements []E) HashSet[E] {
 	return set
 }
 
+// HashSetAdoptingMap creates a new [HashSet] that takes ownership of the given map, using it as the
+// set's backing storage instead of copying it. This avoids doubling memory when the elements are
+// already held as the keys of a map. The caller must not use the map after passing it, since the
+// set modifies it. Since map keys are unique, no check for duplicates is needed.
+// It must not be copied after first use.
+//
+// This is the inverse of [HashSet.ToMap], which returns the set's backing map.
+func HashSetAdoptingMap[E comparable](elements map[E]struct{}) HashSet[E] {
+	return HashSet[E]{elements: elements}
+}
+
+// HashSetFromSliceStrict creates a new [HashSet] from the elements in the given slice, like
+// [HashSetFromSlice], except that duplicate elements are an error rather than added only once. This
+// is useful for input such as user configuration, where a duplicate is likely a mistake.
+// It must not be copied after first use.
+//
+// If the slice contains duplicates, the returned error is a [DuplicateElementError] for the first
+// element that occurs again.
+func HashSetFromSliceStrict[E comparable](elements []E) (HashSet[E], error) {
+	set := HashSet[E]{elements: make(map[E]struct{}, len(elements))}
+
+	for i, element := range elements {
+		if _, alreadyAdded := set.elements[element]; alreadyAdded {
+			return HashSet[E]{elements: nil}, DuplicateElementError[E]{Element: element, Index: i}
+		}
+		set.elements[element] = struct{}{}
+	}
+
+	return set, nil
+}
+
 // Add adds the given element to the set.
 // If the element is already present in the set, Add is a no-op.
 //
@@ -61,6 +95,34 @@ func (set *HashSet[E]) Add(element E) {
 	set.elements[element] = struct{}{}
 }
 
+// AddStrict adds the given element to the set, or returns an [ElementExistsError] if the element
+// is already present. Unlike Add, this does not silently accept adding an element twice, which is
+// useful for enforcing uniqueness, such as when claiming IDs.
+func (set *HashSet[E]) AddStrict(element E) error {
+	if set.Contains(element) {
+		return ElementExistsError[E]{Element: element}
+	}
+
+	set.Add(element)
+	return nil
+}
+
+// TryAdd adds the given element to the set, unless the set already holds maxSize elements, in
+// which case it returns a [SetFullError] without adding it. Adding an element that is already
+// present is a no-op, and succeeds even when the set is full. This is useful for enforcing limits,
+// such as a maximum number of labels per tenant, without checking Size around every Add.
+func (set *HashSet[E]) TryAdd(element E, maxSize int) error {
+	if set.Contains(element) {
+		return nil
+	}
+	if set.Size() >= maxSize {
+		return SetFullError[E]{Element: element, MaxSize: maxSize}
+	}
+
+	set.Add(element)
+	return nil
+}
+
 // AddMultiple adds the given elements to the set. Duplicate elements are added only once, and
 // elements already present in the set are not added.
 //
@@ -76,9 +138,7 @@ func (set *HashSet[E]) AddMultiple(elements ...E) {
 // If the hash set was not previously initialized through one of the constructors in this package,
 // it will be initialized here.
 func (set *HashSet[E]) AddFromSlice(elements []E) {
-	if set.elements == nil {
-		set.elements = make(map[E]struct{}, len(elements))
-	}
+	set.growForBulkAdd(len(elements))
 
 	for _, element := range elements {
 		set.elements[element] = struct{}{}
@@ -90,14 +150,27 @@ func (set *HashSet[E]) AddFromSlice(elements []E) {
 // If the hash set was not previously initialized through one of the constructors in this package,
 // it will be initialized here.
 func (set *HashSet[E]) AddFromSet(otherSet ComparableSet[E]) {
+	set.growForBulkAdd(otherSet.Size())
+
+	for element := range otherSet.All() {
+		set.elements[element] = struct{}{}
+	}
+}
+
+// AddFromSeq adds the elements from the given iterator to the set, without first collecting them
+// into a slice. Duplicate elements are added only once, and elements already present in the set are
+// not added.
+//
+// If the hash set was not previously initialized through one of the constructors in this package,
+// it will be initialized here.
+func (set *HashSet[E]) AddFromSeq(seq iter.Seq[E]) {
 	if set.elements == nil {
-		set.elements = make(map[E]struct{}, otherSet.Size())
+		set.elements = make(map[E]struct{})
 	}
 
-	otherSet.All()(func(element E) bool {
-		set.Add(element)
-		return true
-	})
+	for element := range seq {
+		set.elements[element] = struct{}{}
+	}
 }
 
 // Remove removes the given element from the set.
@@ -106,6 +179,18 @@ func (set HashSet[E]) Remove(element E) {
 	delete(set.elements, element)
 }
 
+// RemoveStrict removes the given element from the set, or returns an [ElementNotFoundError] if
+// the element is not present. Unlike Remove, this does not silently accept removing a missing
+// element.
+func (set *HashSet[E]) RemoveStrict(element E) error {
+	if !set.Contains(element) {
+		return ElementNotFoundError[E]{Element: element}
+	}
+
+	set.Remove(element)
+	return nil
+}
+
 // Clear removes all elements from the set, leaving an empty set with the same capacity as before.
 func (set HashSet[E]) Clear() {
 	for element := range set.elements {
@@ -113,6 +198,54 @@ func (set HashSet[E]) Clear() {
 	}
 }
 
+// Grow increases the set's capacity to make room for another n elements, so that they can be
+// added without the backing map growing incrementally. Since Go maps do not expose their capacity,
+// Grow always reallocates the map (unless n is 0), so it should only be called ahead of adding many
+// elements.
+//
+// After Grow, maps previously returned by ToMap no longer alias the set.
+//
+// Panics if n is negative.
+func (set *HashSet[E]) Grow(n int) {
+	checkGrowCount(n)
+	if n == 0 {
+		return
+	}
+
+	grown := make(map[E]struct{}, len(set.elements)+n)
+	for element := range set.elements {
+		grown[element] = struct{}{}
+	}
+
+	set.elements = grown
+}
+
+// Compact reallocates the set's backing map to fit its current size. Go maps never shrink after
+// elements are deleted from them, so a set that has grown large and then had most of its elements
+// removed keeps the memory of its largest size. Compact gives that memory back, at the cost of
+// copying all the elements.
+//
+// After Compact, maps previously returned by ToMap no longer alias the set.
+func (set *HashSet[E]) Compact() {
+	if set.elements == nil {
+		return
+	}
+
+	compacted := make(map[E]struct{}, len(set.elements))
+	for element := range set.elements {
+		compacted[element] = struct{}{}
+	}
+
+	set.elements = compacted
+}
+
+// Cap returns an estimate of the number of elements the set can hold before its backing map must
+// grow. Since Go maps do not expose their capacity, this is estimated from the set's size, as
+// described on [Stats].
+func (set HashSet[E]) Cap() int {
+	return set.Stats().Capacity
+}
+
 // Contains checks if given element is present in the set.
 func (set HashSet[E]) Contains(element E) bool {
 	if set.elements == nil {
@@ -140,6 +273,16 @@ func (set HashSet[E]) Equals(otherSet ComparableSet[E]) bool {
 
 // IsSubsetOf checks if all of the elements in the set exist in the other given set.
 func (set HashSet[E]) IsSubsetOf(otherSet ComparableSet[E]) bool {
+	if otherElements, ok := hashSetBackingMap(otherSet); ok {
+		for element := range set.elements {
+			if _, contains := otherElements[element]; !contains {
+				return false
+			}
+		}
+
+		return true
+	}
+
 	for element := range set.elements {
 		if !otherSet.Contains(element) {
 			return false
@@ -168,13 +311,22 @@ func (set HashSet[E]) UnionHashSet(otherSet ComparableSet[E]) HashSet[E] {
 	union := HashSetWithCapacity[E](set.Size() + otherSet.Size())
 
 	for element := range set.elements {
-		union.Add(element)
+		union.elements[element] = struct{}{}
 	}
 
-	otherSet.All()(func(element E) bool {
-		union.Add(element)
-		return true
-	})
+	if otherElements, ok := hashSetBackingMap(otherSet); ok {
+		for element := range otherElements {
+			union.elements[element] = struct{}{}
+		}
+	} else if otherElements, ok := arraySetBackingSlice(otherSet); ok {
+		for _, element := range otherElements {
+			union.elements[element] = struct{}{}
+		}
+	} else {
+		for element := range otherSet.All() {
+			union.elements[element] = struct{}{}
+		}
+	}
 
 	return union
 }
@@ -198,9 +350,26 @@ func (set HashSet[E]) IntersectionHashSet(otherSet ComparableSet[E]) HashSet[E]
 	}
 
 	intersection := HashSetWithCapacity[E](capacity)
+
+	if otherElements, ok := hashSetBackingMap(otherSet); ok {
+		// When both sets are maps, we can iterate over the smaller one and look up in the larger.
+		smaller, larger := set.elements, otherElements
+		if len(otherElements) < len(set.elements) {
+			smaller, larger = otherElements, set.elements
+		}
+
+		for element := range smaller {
+			if _, contains := larger[element]; contains {
+				intersection.elements[element] = struct{}{}
+			}
+		}
+
+		return intersection
+	}
+
 	for element := range set.elements {
 		if otherSet.Contains(element) {
-			intersection.Add(element)
+			intersection.elements[element] = struct{}{}
 		}
 	}
 
@@ -223,14 +392,38 @@ func (set HashSet[E]) ToSlice() []E {
 	return slice
 }
 
+// AppendTo appends the elements in the set to the given slice, and returns the extended slice,
+// like the append builtin. Unlike ToSlice, this lets the caller reuse a buffer across calls.
+func (set HashSet[E]) AppendTo(slice []E) []E {
+	slice = slices.Grow(slice, len(set.elements))
+
+	for element := range set.elements {
+		slice = append(slice, element)
+	}
+
+	return slice
+}
+
 // ToMap returns a map with all the set's elements as keys.
 //
 // Mutating the map will also mutate the set, since it uses the same backing storage. To avoid this,
-// call CopyHashSet first.
+// use [HashSet.ToMapCopy] instead.
 func (set HashSet[E]) ToMap() map[E]struct{} {
 	return set.elements
 }
 
+// ToMapCopy creates a new map with all the set's elements as keys. Unlike ToMap, the map does not
+// alias the set, so it is safe to mutate.
+func (set HashSet[E]) ToMapCopy() map[E]struct{} {
+	m := make(map[E]struct{}, len(set.elements))
+
+	for element := range set.elements {
+		m[element] = struct{}{}
+	}
+
+	return m
+}
+
 // Copy creates a new set with all the same elements and capacity as the original set.
 // The underlying type of the returned set is a *HashSet - to get a value type, use
 // [HashSet.CopyHashSet] instead.
@@ -275,11 +468,14 @@ func (set HashSet[E]) String() string {
 	return stringBuilder.String()
 }
 
-// All returns an [Iterator] function, which when called will loop over the elements in the set and
-// call the given yield function on each element. If yield returns false, iteration stops.
+// All returns an iterator over the elements in the set, which can be used in a for-range loop:
+//
+//	for element := range set.All() {
+//		fmt.Println(element)
+//	}
 //
 // Since sets are unordered, iteration order is non-deterministic.
-func (set HashSet[E]) All() Iterator[E] {
+func (set HashSet[E]) All() iter.Seq[E] {
 	return func(yield func(element E) bool) {
 		for element := range set.elements {
 			if !yield(element) {
@@ -288,3 +484,79 @@ func (set HashSet[E]) All() Iterator[E] {
 		}
 	}
 }
+
+// MarshalBinary encodes the set in a compact, length-prefixed binary format, implementing
+// [encoding.BinaryMarshaler]. Strings, numbers, bools and other fixed-size element types are
+// supported, as well as element types that implement encoding.BinaryMarshaler (with pointers to the
+// element type implementing [encoding.BinaryUnmarshaler]).
+func (set HashSet[E]) MarshalBinary() ([]byte, error) {
+	return marshalSetBinary[E](set)
+}
+
+// UnmarshalBinary replaces the elements of the set with the elements decoded from the given data,
+// which must be in the format produced by [HashSet.MarshalBinary]. It implements
+// [encoding.BinaryUnmarshaler].
+func (set *HashSet[E]) UnmarshalBinary(data []byte) error {
+	return unmarshalSetBinary[E](data, set)
+}
+
+// Value converts the set to a PostgreSQL array literal string (such as {1,2,3} or {"a","b"}),
+// implementing [driver.Valuer] so the set can be stored in an array or text column. Strings,
+// numbers, bools and element types implementing [encoding.TextMarshaler] are supported.
+func (set HashSet[E]) Value() (driver.Value, error) {
+	return sqlValueFromSet[E](set)
+}
+
+// Scan replaces the elements of the set with the elements parsed from the given PostgreSQL array
+// literal (as a string or []byte), implementing [database/sql.Scanner]. A NULL value results in an
+// empty set.
+func (set *HashSet[E]) Scan(src any) error {
+	return scanSQLValueIntoSet[E](src, set)
+}
+
+// Format implements [fmt.Formatter], supporting the following verbs:
+//   - %v and %s print the set like String (%+v formats elements with %+v)
+//   - %q quotes string elements
+//   - %#v prints the set in Go syntax, like [HashSet.GoString]
+//   - Other verbs are applied to each element, like fmt does for slices (e.g. %x prints elements in
+//     hex)
+//
+// Precision limits the number of printed elements (e.g. %.2v prints HashSet{1, 2, ...}), and width
+// pads the output.
+func (set HashSet[E]) Format(state fmt.State, verb rune) {
+	formatSet[E](state, verb, "HashSet", set)
+}
+
+// GoString returns a representation of the set in Go syntax, implementing [fmt.GoStringer]. This is
+// also used by the %#v verb. A HashSet of elements 1, 2 and 3 will be printed as:
+// set.HashSetOf[int](1, 2, 3)
+func (set HashSet[E]) GoString() string {
+	return goSyntaxString[E]("HashSet", set)
+}
+
+// growForBulkAdd prepares the set for adding the given number of elements, by initializing its
+// backing map with room for them if the set has not been initialized. An existing map is never
+// replaced, since maps returned by ToMap must keep aliasing the set.
+func (set *HashSet[E]) growForBulkAdd(count int) {
+	if set.elements == nil {
+		set.elements = make(map[E]struct{}, count)
+	}
+}
+
+// hashSetBackingMap returns the backing map of the given set, if it is a HashSet or a DynamicSet
+// that is currently a HashSet. This lets operations between sets use the other set's map directly,
+// instead of going through an interface method call for every element.
+func hashSetBackingMap[E comparable](set ComparableSet[E]) (elements map[E]struct{}, ok bool) {
+	switch set := set.(type) {
+	case HashSet[E]:
+		return set.elements, true
+	case *HashSet[E]:
+		return set.elements, true
+	case DynamicSet[E]:
+		return set.hash.elements, set.IsHashSet()
+	case *DynamicSet[E]:
+		return set.hash.elements, set.IsHashSet()
+	default:
+		return nil, false
+	}
+}
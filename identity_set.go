@@ -0,0 +1,97 @@
+package set
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// An IdentitySet is a collection of unique pointers to values of type T, where elements are
+// compared by pointer identity rather than by the values they point to. Two distinct pointers to
+// equal values are both kept in the set, which is useful for tracking visited nodes in a graph or
+// syntax tree. Since T may be any type, this also works for values that are not comparable.
+//
+// IdentitySet embeds a [HashSet] of pointers, and so has all of its methods. Sets returned from
+// methods such as Union, Intersection and Copy are HashSets.
+//
+// The zero value for an IdentitySet is ready to use. It must not be copied after first use.
+//
+// IdentitySet implements [Set] when passed by pointer, and [ComparableSet] when passed by value.
+type IdentitySet[T any] struct {
+	HashSet[*T]
+}
+
+// NewIdentitySet creates a new [IdentitySet] for pointers to values of type T.
+// It must not be copied after first use.
+func NewIdentitySet[T any]() IdentitySet[T] {
+	return IdentitySet[T]{HashSet: NewHashSet[*T]()}
+}
+
+// IdentitySetOf creates a new [IdentitySet] from the given pointers.
+// It must not be copied after first use.
+// Duplicate pointers are added only once.
+func IdentitySetOf[T any](elements ...*T) IdentitySet[T] {
+	return IdentitySet[T]{HashSet: HashSetFromSlice(elements)}
+}
+
+// String returns a string representation of the set, implementing [fmt.Stringer].
+//
+// Since elements are compared by identity, they are printed as pointer addresses. An IdentitySet of
+// 2 pointers will be printed as: IdentitySet{0xc000012345, 0xc000012346} (though the order may
+// vary).
+func (set IdentitySet[T]) String() string {
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString("IdentitySet{")
+
+	i := 0
+	for element := range set.elements {
+		fmt.Fprintf(&stringBuilder, "%p", element)
+
+		if i < len(set.elements)-1 {
+			stringBuilder.WriteString(", ")
+		}
+
+		i++
+	}
+
+	stringBuilder.WriteByte('}')
+	return stringBuilder.String()
+}
+
+// Format implements [fmt.Formatter]. It overrides [HashSet.Format] from the embedded HashSet, so
+// that the set is printed as an IdentitySet:
+//   - %v, %s and %q print the set like String, with elements as pointer addresses
+//   - %#v prints the set in Go syntax, like [IdentitySet.GoString]
+//   - Other verbs are applied to each element, like fmt does for slices
+func (set IdentitySet[T]) Format(state fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && state.Flag('#'):
+		writePadded(state, set.GoString())
+	case verb == 'v' || verb == 's' || verb == 'q':
+		formatSet[*T](state, 'p', "IdentitySet", set)
+	default:
+		formatSet[*T](state, verb, "IdentitySet", set)
+	}
+}
+
+// GoString returns a representation of the set in Go syntax, implementing [fmt.GoStringer]. This is
+// also used by the %#v verb. It overrides [HashSet.GoString] from the embedded HashSet. An
+// IdentitySet of 2 pointers to ints will be printed as:
+// set.IdentitySetOf[int]((*int)(0xc000012345), (*int)(0xc000012346))
+func (set IdentitySet[T]) GoString() string {
+	var stringBuilder strings.Builder
+	fmt.Fprintf(&stringBuilder, "set.IdentitySetOf[%v](", reflect.TypeFor[T]())
+
+	i := 0
+	for element := range set.elements {
+		if i > 0 {
+			stringBuilder.WriteString(", ")
+		}
+		fmt.Fprintf(&stringBuilder, "%#v", element)
+
+		i++
+	}
+
+	stringBuilder.WriteByte(')')
+	return stringBuilder.String()
+}
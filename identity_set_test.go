@@ -0,0 +1,51 @@
+package set_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+type identityTestNode struct {
+	children []int
+}
+
+func TestIdentitySet(t *testing.T) {
+	node1 := &identityTestNode{children: []int{1, 2}}
+	node2 := &identityTestNode{children: []int{1, 2}}
+
+	identitySet := set.NewIdentitySet[identityTestNode]()
+	identitySet.Add(node1)
+	identitySet.Add(node2)
+	identitySet.Add(node1)
+
+	assertSize[*identityTestNode](t, identitySet, 2)
+	assertContains(t, identitySet, node1, node2)
+
+	if identitySet.Contains(&identityTestNode{children: []int{1, 2}}) {
+		t.Errorf("expected %v to not contain value-equal pointer not added to the set", identitySet)
+	}
+}
+
+func TestIdentitySetFormat(t *testing.T) {
+	node := &identityTestNode{children: []int{1}}
+	identitySet := set.IdentitySetOf(node)
+
+	expected := fmt.Sprintf("IdentitySet{%p}", node)
+	if formatted := fmt.Sprintf("%v", identitySet); formatted != expected {
+		t.Errorf("expected %%v to print %s, got %s", expected, formatted)
+	}
+	if printed := fmt.Sprint(&identitySet); printed != expected {
+		t.Errorf("expected Sprint to print %s, got %s", expected, printed)
+	}
+	if formatted := fmt.Sprintf("%s", identitySet); formatted != identitySet.String() {
+		t.Errorf("expected %%s to print %s, got %s", identitySet.String(), formatted)
+	}
+
+	goSyntax := fmt.Sprintf("%#v", identitySet)
+	if !strings.HasPrefix(goSyntax, "set.IdentitySetOf[set_test.identityTestNode](") {
+		t.Errorf("unexpected Go syntax for IdentitySet: %s", goSyntax)
+	}
+}
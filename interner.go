@@ -0,0 +1,131 @@
+package set
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// An Interner is a set that hands out the canonical instance of each element, as stored in the
+// set. This is useful for deduplicating many equal strings (or other elements that reference
+// memory, such as structs with string fields): by replacing each string with the instance returned
+// by [Interner.Intern], all equal strings share the same memory, and the duplicates can be garbage
+// collected.
+//
+//	var names set.Interner[string]
+//	for _, record := range records {
+//		record.Name = names.Intern(record.Name)
+//	}
+//
+// The other set types in this package cannot return their stored elements, since Go maps do not
+// give access to their stored keys on lookup. So Interner stores every element as both key and
+// value in its map, which takes more memory per element than a [HashSet]. For interning that is
+// shared across a whole program, and where unused elements should be freed automatically, see the
+// standard library's unique package instead.
+//
+// Interner does not implement [Set], since its purpose is interning rather than set operations.
+// The zero value for an Interner is ready to use. It must not be copied after first use.
+type Interner[E comparable] struct {
+	elements map[E]E
+}
+
+// NewInterner creates a new [Interner] for elements of type E.
+// It must not be copied after first use.
+func NewInterner[E comparable]() Interner[E] {
+	return Interner[E]{elements: make(map[E]E)}
+}
+
+// Intern returns the instance of the given element that is stored in the set. If no equal element
+// is stored, the given element is added, and returned.
+func (interner *Interner[E]) Intern(element E) E {
+	if canonical, ok := interner.elements[element]; ok {
+		return canonical
+	}
+
+	if interner.elements == nil {
+		interner.elements = make(map[E]E)
+	}
+	interner.elements[element] = element
+	return element
+}
+
+// Lookup returns the instance of the given element that is stored in the set, and true, or the
+// zero value and false if no equal element is stored. Unlike Intern, Lookup does not add the
+// element.
+func (interner Interner[E]) Lookup(element E) (canonical E, ok bool) {
+	canonical, ok = interner.elements[element]
+	return canonical, ok
+}
+
+// Contains checks if an element equal to the given element is stored in the set.
+func (interner Interner[E]) Contains(element E) bool {
+	_, ok := interner.elements[element]
+	return ok
+}
+
+// Remove removes the given element from the set. Instances previously returned by Intern are not
+// affected, but a later call to Intern with an equal element stores that element as the new
+// canonical instance.
+// If the element is not present in the set, Remove is a no-op.
+func (interner *Interner[E]) Remove(element E) {
+	delete(interner.elements, element)
+}
+
+// Clear removes all elements from the set.
+func (interner *Interner[E]) Clear() {
+	clear(interner.elements)
+}
+
+// Size returns the number of elements in the set.
+func (interner Interner[E]) Size() int {
+	return len(interner.elements)
+}
+
+// IsEmpty checks if there are 0 elements in the set.
+func (interner Interner[E]) IsEmpty() bool {
+	return len(interner.elements) == 0
+}
+
+// All returns an iterator over the canonical elements in the set, which can be used in a for-range
+// loop:
+//
+//	for element := range interner.All() {
+//		fmt.Println(element)
+//	}
+//
+// Since sets are unordered, iteration order is non-deterministic.
+func (interner Interner[E]) All() iter.Seq[E] {
+	return func(yield func(element E) bool) {
+		for _, element := range interner.elements {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// String returns a string representation of the set, implementing [fmt.Stringer].
+//
+// Since sets are unordered, the order of elements in the string may differ each time it is called.
+//
+// An Interner of elements "a" and "b" will be printed as: Interner{a, b} (though the order may
+// vary).
+func (interner Interner[E]) String() string {
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString("Interner{")
+
+	size := len(interner.elements)
+	i := 0
+	for element := range interner.elements {
+		fmt.Fprint(&stringBuilder, element)
+
+		if i < size-1 {
+			stringBuilder.WriteString(", ")
+		}
+
+		i++
+	}
+
+	stringBuilder.WriteByte('}')
+	return stringBuilder.String()
+}
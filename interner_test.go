@@ -0,0 +1,46 @@
+package set_test
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+
+	"hermannm.dev/set"
+)
+
+func TestInterner(t *testing.T) {
+	var interner set.Interner[string]
+
+	first := strings.Repeat("a", 3)
+	second := strings.Repeat("a", 3)
+	if unsafe.StringData(first) == unsafe.StringData(second) {
+		t.Fatal("expected test strings to have separate memory")
+	}
+
+	interned := interner.Intern(first)
+	if unsafe.StringData(interned) != unsafe.StringData(first) {
+		t.Errorf("expected first Intern to return the given instance")
+	}
+
+	interned = interner.Intern(second)
+	if unsafe.StringData(interned) != unsafe.StringData(first) {
+		t.Errorf("expected second Intern to return the first instance")
+	}
+
+	canonical, ok := interner.Lookup(second)
+	if !ok || unsafe.StringData(canonical) != unsafe.StringData(first) {
+		t.Errorf("expected Lookup to return the first instance")
+	}
+	if _, ok := interner.Lookup("b"); ok {
+		t.Errorf("expected Lookup of missing element to return false")
+	}
+
+	if interner.Size() != 1 || !interner.Contains("aaa") {
+		t.Errorf("expected %v to contain only aaa", interner)
+	}
+
+	interner.Remove("aaa")
+	if !interner.IsEmpty() {
+		t.Errorf("expected %v to be empty after Remove", interner)
+	}
+}
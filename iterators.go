@@ -0,0 +1,149 @@
+package set
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"iter"
+	"slices"
+)
+
+// SortedAll returns an iterator over the elements in the given set in ascending order, for when
+// deterministic iteration is needed. The elements are collected and sorted each time the iterator
+// is used, so this is O(n log n) rather than O(n) like [ComparableSet.All].
+func SortedAll[E cmp.Ordered](set ComparableSet[E]) iter.Seq[E] {
+	return func(yield func(element E) bool) {
+		elements := slices.Collect(set.All())
+		slices.Sort(elements)
+
+		for _, element := range elements {
+			if !yield(element) {
+				break
+			}
+		}
+	}
+}
+
+// SeededAll returns an iterator over the elements in the given set in a pseudo-random order, which
+// is the same every time for the same seed and elements, also across runs of the program. This is
+// useful for tests that record iteration results in fixtures, where the changing iteration order of
+// Go maps would break them, but where the elements cannot be sorted with [SortedAll], or where a
+// sorted order would hide order-dependent bugs. Different seeds give different orders.
+//
+// Each element is hashed together with the seed, and the elements are ordered by their hashes. The
+// hashes are computed from the elements' binary encoding if supported (see MarshalBinary), or else
+// from their default formatting with fmt, so the order is only stable if that formatting is (which
+// is not the case for pointers, for example). Like SortedAll, the elements are collected and
+// ordered each time the iterator is used.
+func SeededAll[E comparable](set ComparableSet[E], seed uint64) iter.Seq[E] {
+	return func(yield func(element E) bool) {
+		type seededElement struct {
+			hash    uint64
+			key     []byte
+			element E
+		}
+
+		codec, codecErr := newBinaryElementCodec[E]()
+		seedBytes := binary.LittleEndian.AppendUint64(nil, seed)
+
+		elements := make([]seededElement, 0, set.Size())
+		for element := range set.All() {
+			var key []byte
+			var err error = codecErr
+			if err == nil {
+				key, err = codec.encode(nil, element)
+			}
+			if err != nil {
+				key = fmt.Append(nil, element)
+			}
+
+			hash := fnv.New64a()
+			hash.Write(seedBytes)
+			hash.Write(key)
+			elements = append(elements, seededElement{hash: hash.Sum64(), key: key, element: element})
+		}
+
+		slices.SortFunc(elements, func(a, b seededElement) int {
+			if result := cmp.Compare(a.hash, b.hash); result != 0 {
+				return result
+			}
+			return bytes.Compare(a.key, b.key)
+		})
+
+		for _, element := range elements {
+			if !yield(element.element) {
+				break
+			}
+		}
+	}
+}
+
+// Enumerate wraps the given iterator to also yield an index for each element, starting at 0. This
+// is useful for progress reporting, or for stopping after the first N elements:
+//
+//	for i, element := range set.Enumerate(mySet.All()) {
+//		if i == 10 {
+//			break
+//		}
+//		fmt.Println(element)
+//	}
+//
+// Since sets are unordered, the index only counts iterations - it is not a stable position of the
+// element in the set. Use [SortedAll] as the input iterator for deterministic indices.
+func Enumerate[E any](seq iter.Seq[E]) iter.Seq2[int, E] {
+	return func(yield func(index int, element E) bool) {
+		index := 0
+		for element := range seq {
+			if !yield(index, element) {
+				break
+			}
+			index++
+		}
+	}
+}
+
+// Pull converts the iterator over the given set's elements into a "pull-style" iterator, using
+// [iter.Pull]. Each call to next returns the next element and true, or the zero value and false
+// once all elements have been returned. This allows traversing two sets in lockstep without
+// nesting callbacks:
+//
+//	next1, stop1 := set.Pull(set1)
+//	defer stop1()
+//	next2, stop2 := set.Pull(set2)
+//	defer stop2()
+//
+// The caller must call stop when done with the iterator, unless next has returned false. For
+// merge-style comparisons of ordered sets, pass [SortedAll] to iter.Pull directly.
+func Pull[E comparable](set ComparableSet[E]) (next func() (E, bool), stop func()) {
+	return iter.Pull(set.All())
+}
+
+// DedupSeq wraps the given iterator to yield each element at most once, in order of first
+// appearance, without first collecting the elements into a set. This is useful for deduplicating
+// streams of elements:
+//
+//	for user := range set.DedupSeq(usersFromAllTeams) {
+//		notify(user)
+//	}
+//
+// Seen elements are tracked in a [DynamicSet], so deduplicating a short stream stays cheap, while
+// long streams get O(1) lookups. The set is created anew each time the iterator is used, and is
+// discarded once the loop ends.
+func DedupSeq[E comparable](seq iter.Seq[E]) iter.Seq[E] {
+	return func(yield func(element E) bool) {
+		seen := NewDynamicSet[E]()
+
+		for element := range seq {
+			if seen.Contains(element) {
+				continue
+			}
+			seen.Add(element)
+
+			if !yield(element) {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package set_test
+
+import (
+	"slices"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestSortedAll(t *testing.T) {
+	testAllSetTypes(func(set1 set.Set[int], setName string) {
+		set1.AddMultiple(3, 1, 5, 2, 4)
+
+		sorted := slices.Collect(set.SortedAll[int](set1))
+
+		expected := []int{1, 2, 3, 4, 5}
+		if !slices.Equal(sorted, expected) {
+			t.Errorf("expected SortedAll(%v) to yield %v, got %v", set1, expected, sorted)
+		}
+	})
+}
+
+func TestEnumerate(t *testing.T) {
+	ints := set.ArraySetOf(3, 1, 2)
+
+	var indices []int
+	var elements []int
+	for i, element := range set.Enumerate(set.SortedAll[int](ints)) {
+		if i == 2 {
+			break
+		}
+		indices = append(indices, i)
+		elements = append(elements, element)
+	}
+
+	if !slices.Equal(indices, []int{0, 1}) || !slices.Equal(elements, []int{1, 2}) {
+		t.Errorf("expected indices [0 1] and elements [1 2], got %v and %v", indices, elements)
+	}
+}
+
+func TestPull(t *testing.T) {
+	testAllSetTypes(func(set1 set.Set[int], setName string) {
+		set1.AddMultiple(1, 2, 3)
+
+		next, stop := set.Pull[int](set1)
+		defer stop()
+
+		pulled := set.NewHashSet[int]()
+		for element, ok := next(); ok; element, ok = next() {
+			pulled.Add(element)
+		}
+
+		if !pulled.Equals(set1) {
+			t.Errorf("expected elements pulled from %v to equal the set, got %v", set1, pulled)
+		}
+	})
+}
+
+func TestDedupSeq(t *testing.T) {
+	seq := slices.Values([]int{3, 1, 3, 2, 1, 4})
+
+	deduplicated := slices.Collect(set.DedupSeq(seq))
+	if expected := []int{3, 1, 2, 4}; !slices.Equal(deduplicated, expected) {
+		t.Errorf("expected DedupSeq to yield %v, got %v", expected, deduplicated)
+	}
+
+	var firstTwo []int
+	for element := range set.DedupSeq(seq) {
+		firstTwo = append(firstTwo, element)
+		if len(firstTwo) == 2 {
+			break
+		}
+	}
+	if expected := []int{3, 1}; !slices.Equal(firstTwo, expected) {
+		t.Errorf("expected DedupSeq to stop after %v, got %v", expected, firstTwo)
+	}
+}
+
+func TestSeededAll(t *testing.T) {
+	ints := set.HashSetFromSlice(createRandomIntSlice(100))
+
+	first := slices.Collect(set.SeededAll[int](&ints, 42))
+	for range 5 {
+		if again := slices.Collect(set.SeededAll[int](ints.Copy(), 42)); !slices.Equal(first, again) {
+			t.Fatalf("expected SeededAll to give the same order for the same seed")
+		}
+	}
+
+	if other := slices.Collect(set.SeededAll[int](&ints, 43)); slices.Equal(first, other) {
+		t.Errorf("expected SeededAll to give different orders for different seeds")
+	}
+	if sorted := slices.Sorted(slices.Values(first)); slices.Equal(first, sorted) {
+		t.Errorf("expected SeededAll to not give sorted order")
+	}
+	assertSize(t, set.ArraySetFromSlice(first), ints.Size())
+
+	structs := set.HashSetFromSlice(createRandomStructSlice(50))
+	firstStructs := slices.Collect(set.SeededAll[testStruct](&structs, 1))
+	againStructs := slices.Collect(set.SeededAll[testStruct](structs.Copy(), 1))
+	if !slices.Equal(firstStructs, againStructs) {
+		t.Errorf("expected SeededAll to give the same order for structs with the same seed")
+	}
+}
@@ -0,0 +1,43 @@
+package set
+
+import (
+	"slices"
+	"strings"
+)
+
+// Join concatenates the elements of the given set of strings into a single string, with the given
+// separator between elements, like [strings.Join]. Since sets are unordered, the order of elements
+// in the result is non-deterministic - use [JoinSorted] for deterministic output.
+func Join[E ~string](set ComparableSet[E], separator string) string {
+	var stringBuilder strings.Builder
+
+	i := 0
+	for element := range set.All() {
+		if i != 0 {
+			stringBuilder.WriteString(separator)
+		}
+		stringBuilder.WriteString(string(element))
+		i++
+	}
+
+	return stringBuilder.String()
+}
+
+// JoinSorted is like [Join], but with elements in ascending order, so that the result is
+// deterministic, for example in error messages or log output:
+//
+//	set.JoinSorted(set.HashSetOf("c", "a", "b"), ", ") // "a, b, c"
+func JoinSorted[E ~string](set ComparableSet[E], separator string) string {
+	elements := set.ToSlice()
+	slices.Sort(elements)
+
+	var stringBuilder strings.Builder
+	for i, element := range elements {
+		if i != 0 {
+			stringBuilder.WriteString(separator)
+		}
+		stringBuilder.WriteString(string(element))
+	}
+
+	return stringBuilder.String()
+}
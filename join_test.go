@@ -0,0 +1,21 @@
+package set_test
+
+import (
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestJoin(t *testing.T) {
+	if joined := set.Join(set.ArraySetOf("a"), ", "); joined != "a" {
+		t.Errorf("expected Join of single element to be \"a\", got %q", joined)
+	}
+	if joined := set.Join(set.NewHashSet[string](), ", "); joined != "" {
+		t.Errorf("expected Join of empty set to be empty, got %q", joined)
+	}
+
+	type tag string
+	if joined := set.JoinSorted(set.HashSetOf[tag]("c", "a", "b"), ", "); joined != "a, b, c" {
+		t.Errorf("expected JoinSorted to be \"a, b, c\", got %q", joined)
+	}
+}
@@ -0,0 +1,42 @@
+package set
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DecodeJSONArray reads a JSON array from the given decoder, and adds its elements to the given set
+// one by one as they are decoded. This avoids buffering the whole array into a slice before adding
+// it to the set, which matters for large arrays. To decode from an [io.Reader], use
+// [json.NewDecoder].
+//
+// Elements are decoded with [json.Decoder.Decode], so the element type may implement
+// [json.Unmarshaler]. If decoding fails partway through the array, the elements decoded up to that
+// point remain in the set. A JSON null is treated as an empty array.
+func DecodeJSONArray[E comparable](decoder *json.Decoder, set Set[E]) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return fmt.Errorf("set: failed to read start of JSON array: %w", err)
+	}
+	if token == nil {
+		return nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("set: expected start of JSON array, got %v", token)
+	}
+
+	for decoder.More() {
+		var element E
+		if err := decoder.Decode(&element); err != nil {
+			return fmt.Errorf("set: failed to decode JSON array element: %w", err)
+		}
+
+		set.Add(element)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return fmt.Errorf("set: failed to read end of JSON array: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package set_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestDecodeJSONArray(t *testing.T) {
+	decoder := json.NewDecoder(strings.NewReader(`["a", "b", "a", "c"] "trailing"`))
+
+	var decoded set.HashSet[string]
+	if err := set.DecodeJSONArray[string](decoder, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	assertSize(t, decoded, 3)
+	assertContains(t, decoded, "a", "b", "c")
+
+	var trailing string
+	if err := decoder.Decode(&trailing); err != nil || trailing != "trailing" {
+		t.Errorf("expected decoder to be positioned after JSON array, got %q (err: %v)", trailing, err)
+	}
+}
+
+func TestDecodeJSONArrayInvalid(t *testing.T) {
+	for _, input := range []string{`{"a": 1}`, `[1, "two"]`, `[1, 2`} {
+		var ints set.ArraySet[int]
+		err := set.DecodeJSONArray[int](json.NewDecoder(strings.NewReader(input)), &ints)
+		if err == nil {
+			t.Errorf("expected error when decoding invalid input %s", input)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package set
+
+import (
+	"iter"
+	"strings"
+)
+
+// A NormalizedSet wraps another [Set], and applies a normalization function to every element that
+// is added to, removed from or looked up in the set. This keeps equivalence logic, such as trimming
+// whitespace or ignoring case, inside the set, instead of relying on every caller to normalize
+// elements first:
+//
+//	tags := set.Normalize[string](&set.HashSet[string]{}, func(tag string) string {
+//		return strings.ToLower(strings.TrimSpace(tag))
+//	})
+//	tags.Add(" Go")
+//	tags.Contains("go") // true
+//
+// The wrapped set only holds normalized elements, so iterating over the set and methods such as
+// ToSlice give normalized elements. The normalization function should be idempotent, i.e.
+// normalizing an already normalized element should give the same element.
+//
+// Methods that compare the set with another set (Equals, IsSubsetOf, Union and Intersection) use
+// the normalized elements of this set as they are, and do not normalize the elements of the other
+// set, except for IsSupersetOf, which looks up the other set's elements with Contains.
+//
+// NormalizedSet is not safe for concurrent use - to make it so, pass it to [Synchronize]. The
+// wrapped set must not be modified directly after being passed to Normalize, as that would bypass
+// normalization.
+//
+// NormalizedSet implements [Set] and [ComparableSet] when passed by pointer.
+type NormalizedSet[E comparable] struct {
+	inner     Set[E]
+	normalize func(element E) E
+}
+
+// Normalize wraps the given set in a [NormalizedSet], which applies the given function to elements
+// on every add, remove and lookup.
+//
+// Elements already in the set are not normalized. The wrapped set must not be modified directly
+// afterwards, as that would bypass normalization.
+func Normalize[E comparable](set Set[E], normalize func(element E) E) *NormalizedSet[E] {
+	return &NormalizedSet[E]{inner: set, normalize: normalize}
+}
+
+// Add normalizes the given element, and adds it to the set.
+// If the normalized element is already present in the set, Add is a no-op.
+func (set *NormalizedSet[E]) Add(element E) {
+	set.inner.Add(set.normalize(element))
+}
+
+// AddMultiple normalizes the given elements, and adds them to the set. Elements that are equal
+// after normalization are added only once, and elements already present in the set are not added.
+func (set *NormalizedSet[E]) AddMultiple(elements ...E) {
+	set.AddFromSlice(elements)
+}
+
+// AddFromSlice normalizes the elements from the given slice, and adds them to the set. Elements
+// that are equal after normalization are added only once, and elements already present in the set
+// are not added.
+func (set *NormalizedSet[E]) AddFromSlice(elements []E) {
+	set.inner.Grow(len(elements))
+	for _, element := range elements {
+		set.inner.Add(set.normalize(element))
+	}
+}
+
+// AddFromSet normalizes the elements from the given other set, and adds them to the set.
+func (set *NormalizedSet[E]) AddFromSet(otherSet ComparableSet[E]) {
+	if set.isSameSet(otherSet) {
+		return
+	}
+
+	set.inner.Grow(otherSet.Size())
+	for element := range otherSet.All() {
+		set.inner.Add(set.normalize(element))
+	}
+}
+
+// AddFromSeq normalizes the elements from the given iterator, and adds them to the set.
+func (set *NormalizedSet[E]) AddFromSeq(seq iter.Seq[E]) {
+	for element := range seq {
+		set.inner.Add(set.normalize(element))
+	}
+}
+
+// Remove normalizes the given element, and removes it from the set.
+// If the normalized element is not present in the set, Remove is a no-op.
+func (set *NormalizedSet[E]) Remove(element E) {
+	set.inner.Remove(set.normalize(element))
+}
+
+// Clear removes all elements from the set.
+func (set *NormalizedSet[E]) Clear() {
+	set.inner.Clear()
+}
+
+// Grow increases the set's capacity, if necessary, to guarantee room for another n elements.
+//
+// Panics if n is negative.
+func (set *NormalizedSet[E]) Grow(n int) {
+	set.inner.Grow(n)
+}
+
+// Contains normalizes the given element, and checks if it is present in the set.
+func (set *NormalizedSet[E]) Contains(element E) bool {
+	return set.inner.Contains(set.normalize(element))
+}
+
+// Size returns the number of elements in the set.
+func (set *NormalizedSet[E]) Size() int {
+	return set.inner.Size()
+}
+
+// IsEmpty checks if there are 0 elements in the set.
+func (set *NormalizedSet[E]) IsEmpty() bool {
+	return set.inner.IsEmpty()
+}
+
+// Equals checks if the set contains exactly the same elements as the other given set. The other
+// set's elements are not normalized.
+func (set *NormalizedSet[E]) Equals(otherSet ComparableSet[E]) bool {
+	if set.isSameSet(otherSet) {
+		return true
+	}
+	return set.inner.Equals(otherSet)
+}
+
+// IsSubsetOf checks if all of the elements in the set exist in the other given set. The other
+// set's elements are not normalized.
+func (set *NormalizedSet[E]) IsSubsetOf(otherSet ComparableSet[E]) bool {
+	if set.isSameSet(otherSet) {
+		return true
+	}
+	return set.inner.IsSubsetOf(otherSet)
+}
+
+// IsSupersetOf checks if the set contains all of the elements in the other given set, after
+// normalizing them.
+func (set *NormalizedSet[E]) IsSupersetOf(otherSet ComparableSet[E]) bool {
+	if set.isSameSet(otherSet) {
+		return true
+	}
+
+	for element := range otherSet.All() {
+		if !set.Contains(element) {
+			return false
+		}
+	}
+	return true
+}
+
+// Union creates a new set that contains all the elements of the receiver set and the other given
+// set. The returned set is the result of Union on the wrapped set, so the other set's elements are
+// not normalized, and the returned set does not normalize elements.
+func (set *NormalizedSet[E]) Union(otherSet ComparableSet[E]) Set[E] {
+	if set.isSameSet(otherSet) {
+		return set.inner.Copy()
+	}
+	return set.inner.Union(otherSet)
+}
+
+// Intersection creates a new set with only the elements that exist in both the receiver set and the
+// other given set. The returned set is the result of Intersection on the wrapped set, so the other
+// set's elements are not normalized, and the returned set does not normalize elements.
+func (set *NormalizedSet[E]) Intersection(otherSet ComparableSet[E]) Set[E] {
+	if set.isSameSet(otherSet) {
+		return set.inner.Copy()
+	}
+	return set.inner.Intersection(otherSet)
+}
+
+// ToSlice creates a slice with all the (normalized) elements in the set.
+func (set *NormalizedSet[E]) ToSlice() []E {
+	return set.inner.ToSlice()
+}
+
+// AppendTo appends the elements in the set to the given slice, and returns the extended slice,
+// like the append builtin. Unlike ToSlice, this lets the caller reuse a buffer across calls.
+func (set *NormalizedSet[E]) AppendTo(slice []E) []E {
+	return set.inner.AppendTo(slice)
+}
+
+// ToMap creates a map with all the set's (normalized) elements as keys.
+//
+// Unlike [HashSet.ToMap], the returned map is always a copy, since modifying the set's storage
+// directly would bypass normalization.
+func (set *NormalizedSet[E]) ToMap() map[E]struct{} {
+	m := make(map[E]struct{}, set.inner.Size())
+	for element := range set.inner.All() {
+		m[element] = struct{}{}
+	}
+	return m
+}
+
+// Copy creates a new set with all the same elements as the original set. The returned set is a
+// copy of the wrapped set, and does not normalize elements.
+func (set *NormalizedSet[E]) Copy() Set[E] {
+	return set.inner.Copy()
+}
+
+// String returns a string representation of the set, implementing [fmt.Stringer].
+//
+// The string is the string of the wrapped set, prefixed by "Normalized". A NormalizedSet wrapping
+// an ArraySet of elements 1, 2 and 3 will be printed as: NormalizedArraySet{1, 2, 3}
+func (set *NormalizedSet[E]) String() string {
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString("Normalized")
+	stringBuilder.WriteString(set.inner.String())
+	return stringBuilder.String()
+}
+
+// All returns an iterator over the (normalized) elements in the set, which can be used in a
+// for-range loop:
+//
+//	for element := range set.All() {
+//		fmt.Println(element)
+//	}
+//
+// Since sets are unordered, iteration order is non-deterministic.
+func (set *NormalizedSet[E]) All() iter.Seq[E] {
+	return set.inner.All()
+}
+
+// isSameSet checks if the given other set is the receiver itself, in which case bulk operations
+// must not iterate over the other set while modifying the receiver.
+func (set *NormalizedSet[E]) isSameSet(otherSet ComparableSet[E]) bool {
+	other, ok := otherSet.(*NormalizedSet[E])
+	return ok && other == set
+}
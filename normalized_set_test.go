@@ -0,0 +1,39 @@
+package set_test
+
+import (
+	"strings"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+func TestNormalizedSet(t *testing.T) {
+	tags := set.Normalize[string](&set.HashSet[string]{}, normalizeTag)
+
+	tags.Add(" Go")
+	tags.AddMultiple("go", "RUST ", "rust")
+	tags.AddFromSet(set.ArraySetOf("Zig"))
+
+	assertSize(t, tags, 3)
+	assertContains(t, tags, "go", "GO", " rust", "zig")
+
+	if !tags.IsSupersetOf(set.ArraySetOf("Go", "Rust")) {
+		t.Errorf("expected %v to be superset of differently cased elements", tags)
+	}
+
+	tags.Remove("ZIG ")
+	assertSize(t, tags, 2)
+	if tags.Contains("zig") {
+		t.Errorf("expected zig to be removed from %v", tags)
+	}
+
+	for tag := range tags.All() {
+		if tag != normalizeTag(tag) {
+			t.Errorf("expected only normalized elements in %v, got %q", tags, tag)
+		}
+	}
+}
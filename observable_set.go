@@ -0,0 +1,266 @@
+package set
+
+import (
+	"iter"
+	"strings"
+)
+
+// An ObservableSet wraps another [Set], and calls registered hooks whenever an element is added to
+// or removed from the set. This lets membership be mirrored elsewhere, such as in a metrics gauge
+// or an external cache, without intercepting every call site that modifies the set.
+//
+// Hooks registered with [ObservableSet.OnAdd] are called once for every element that was not
+// already in the set when added, and hooks registered with [ObservableSet.OnRemove] are called once
+// for every element that was in the set when removed. Bulk operations such as AddFromSlice and
+// Clear call the hooks for each affected element. Hooks are called after the set has been
+// modified, in the order they were registered, and must not modify the set.
+//
+// ObservableSet is not safe for concurrent use - to make it so, pass it to [Synchronize]. The
+// wrapped set must not be modified directly after being passed to [Observe], as that would bypass
+// the hooks.
+//
+// ObservableSet implements [Set] and [ComparableSet] when passed by pointer.
+type ObservableSet[E comparable] struct {
+	inner    Set[E]
+	onAdd    []func(element E)
+	onRemove []func(element E)
+}
+
+// Observe wraps the given set in an [ObservableSet], to register hooks on its changes.
+//
+// The wrapped set must not be modified directly afterwards, as that would bypass the hooks.
+func Observe[E comparable](set Set[E]) *ObservableSet[E] {
+	return &ObservableSet[E]{inner: set}
+}
+
+// OnAdd registers a hook that is called with each element that is added to the set.
+func (set *ObservableSet[E]) OnAdd(hook func(element E)) {
+	set.onAdd = append(set.onAdd, hook)
+}
+
+// OnRemove registers a hook that is called with each element that is removed from the set.
+func (set *ObservableSet[E]) OnRemove(hook func(element E)) {
+	set.onRemove = append(set.onRemove, hook)
+}
+
+// Add adds the given element to the set.
+// If the element is already present in the set, Add is a no-op.
+func (set *ObservableSet[E]) Add(element E) {
+	if len(set.onAdd) == 0 {
+		set.inner.Add(element)
+		return
+	}
+
+	if set.inner.Contains(element) {
+		return
+	}
+
+	set.inner.Add(element)
+	set.notify(set.onAdd, element)
+}
+
+// AddMultiple adds the given elements to the set. Duplicate elements are added only once, and
+// elements already present in the set are not added.
+func (set *ObservableSet[E]) AddMultiple(elements ...E) {
+	set.AddFromSlice(elements)
+}
+
+// AddFromSlice adds the elements from the given slice to the set. Duplicate elements are added only
+// once, and elements already present in the set are not added.
+func (set *ObservableSet[E]) AddFromSlice(elements []E) {
+	if len(set.onAdd) == 0 {
+		set.inner.AddFromSlice(elements)
+		return
+	}
+
+	for _, element := range elements {
+		set.Add(element)
+	}
+}
+
+// AddFromSet adds elements from the given other set to the set.
+func (set *ObservableSet[E]) AddFromSet(otherSet ComparableSet[E]) {
+	if set.isSameSet(otherSet) {
+		return
+	}
+
+	if len(set.onAdd) == 0 {
+		set.inner.AddFromSet(otherSet)
+		return
+	}
+
+	for element := range otherSet.All() {
+		set.Add(element)
+	}
+}
+
+// AddFromSeq adds the elements from the given iterator to the set.
+func (set *ObservableSet[E]) AddFromSeq(seq iter.Seq[E]) {
+	if len(set.onAdd) == 0 {
+		set.inner.AddFromSeq(seq)
+		return
+	}
+
+	for element := range seq {
+		set.Add(element)
+	}
+}
+
+// Remove removes the given element from the set.
+// If the element is not present in the set, Remove is a no-op.
+func (set *ObservableSet[E]) Remove(element E) {
+	if len(set.onRemove) == 0 {
+		set.inner.Remove(element)
+		return
+	}
+
+	if !set.inner.Contains(element) {
+		return
+	}
+
+	set.inner.Remove(element)
+	set.notify(set.onRemove, element)
+}
+
+// Clear removes all elements from the set.
+func (set *ObservableSet[E]) Clear() {
+	if len(set.onRemove) == 0 {
+		set.inner.Clear()
+		return
+	}
+
+	removed := set.inner.ToSlice()
+	set.inner.Clear()
+	for _, element := range removed {
+		set.notify(set.onRemove, element)
+	}
+}
+
+// Grow increases the set's capacity, if necessary, to guarantee room for another n elements.
+//
+// Panics if n is negative.
+func (set *ObservableSet[E]) Grow(n int) {
+	set.inner.Grow(n)
+}
+
+// Contains checks if given element is present in the set.
+func (set *ObservableSet[E]) Contains(element E) bool {
+	return set.inner.Contains(element)
+}
+
+// Size returns the number of elements in the set.
+func (set *ObservableSet[E]) Size() int {
+	return set.inner.Size()
+}
+
+// IsEmpty checks if there are 0 elements in the set.
+func (set *ObservableSet[E]) IsEmpty() bool {
+	return set.inner.IsEmpty()
+}
+
+// Equals checks if the set contains exactly the same elements as the other given set.
+func (set *ObservableSet[E]) Equals(otherSet ComparableSet[E]) bool {
+	if set.isSameSet(otherSet) {
+		return true
+	}
+	return set.inner.Equals(otherSet)
+}
+
+// IsSubsetOf checks if all of the elements in the set exist in the other given set.
+func (set *ObservableSet[E]) IsSubsetOf(otherSet ComparableSet[E]) bool {
+	if set.isSameSet(otherSet) {
+		return true
+	}
+	return set.inner.IsSubsetOf(otherSet)
+}
+
+// IsSupersetOf checks if the set contains all of the elements in the other given set.
+func (set *ObservableSet[E]) IsSupersetOf(otherSet ComparableSet[E]) bool {
+	if set.isSameSet(otherSet) {
+		return true
+	}
+	return otherSet.IsSubsetOf(set.inner)
+}
+
+// Union creates a new set that contains all the elements of the receiver set and the other given
+// set. The returned set is the result of Union on the wrapped set, and has no hooks.
+func (set *ObservableSet[E]) Union(otherSet ComparableSet[E]) Set[E] {
+	if set.isSameSet(otherSet) {
+		return set.inner.Copy()
+	}
+	return set.inner.Union(otherSet)
+}
+
+// Intersection creates a new set with only the elements that exist in both the receiver set and the
+// other given set. The returned set is the result of Intersection on the wrapped set, and has no
+// hooks.
+func (set *ObservableSet[E]) Intersection(otherSet ComparableSet[E]) Set[E] {
+	if set.isSameSet(otherSet) {
+		return set.inner.Copy()
+	}
+	return set.inner.Intersection(otherSet)
+}
+
+// ToSlice creates a slice with all the elements in the set.
+func (set *ObservableSet[E]) ToSlice() []E {
+	return set.inner.ToSlice()
+}
+
+// AppendTo appends the elements in the set to the given slice, and returns the extended slice,
+// like the append builtin. Unlike ToSlice, this lets the caller reuse a buffer across calls.
+func (set *ObservableSet[E]) AppendTo(slice []E) []E {
+	return set.inner.AppendTo(slice)
+}
+
+// ToMap creates a map with all the set's elements as keys.
+//
+// Unlike [HashSet.ToMap], the returned map is always a copy, since modifying the set's storage
+// directly would bypass the hooks.
+func (set *ObservableSet[E]) ToMap() map[E]struct{} {
+	m := make(map[E]struct{}, set.inner.Size())
+	for element := range set.inner.All() {
+		m[element] = struct{}{}
+	}
+	return m
+}
+
+// Copy creates a new set with all the same elements as the original set. The returned set is a
+// copy of the wrapped set, and has no hooks.
+func (set *ObservableSet[E]) Copy() Set[E] {
+	return set.inner.Copy()
+}
+
+// String returns a string representation of the set, implementing [fmt.Stringer].
+//
+// The string is the string of the wrapped set, prefixed by "Observable". An ObservableSet wrapping
+// an ArraySet of elements 1, 2 and 3 will be printed as: ObservableArraySet{1, 2, 3}
+func (set *ObservableSet[E]) String() string {
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString("Observable")
+	stringBuilder.WriteString(set.inner.String())
+	return stringBuilder.String()
+}
+
+// All returns an iterator over the elements in the set, which can be used in a for-range loop:
+//
+//	for element := range set.All() {
+//		fmt.Println(element)
+//	}
+//
+// Since sets are unordered, iteration order is non-deterministic.
+func (set *ObservableSet[E]) All() iter.Seq[E] {
+	return set.inner.All()
+}
+
+func (set *ObservableSet[E]) notify(hooks []func(element E), element E) {
+	for _, hook := range hooks {
+		hook(element)
+	}
+}
+
+// isSameSet checks if the given other set is the receiver itself, in which case bulk operations
+// must not iterate over the other set while modifying the receiver.
+func (set *ObservableSet[E]) isSameSet(otherSet ComparableSet[E]) bool {
+	other, ok := otherSet.(*ObservableSet[E])
+	return ok && other == set
+}
@@ -0,0 +1,38 @@
+package set_test
+
+import (
+	"slices"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestObservableSetHooks(t *testing.T) {
+	observableSet := set.Observe[int](&set.HashSet[int]{})
+
+	var added, removed []int
+	observableSet.OnAdd(func(element int) { added = append(added, element) })
+	observableSet.OnRemove(func(element int) { removed = append(removed, element) })
+
+	observableSet.Add(1)
+	observableSet.Add(1)
+	observableSet.AddMultiple(2, 3, 2)
+	observableSet.AddFromSet(set.ArraySetOf(3, 4))
+	observableSet.Remove(5)
+	observableSet.Remove(1)
+
+	if expected := []int{1, 2, 3, 4}; !slices.Equal(added, expected) {
+		t.Errorf("expected added elements %v, got %v", expected, added)
+	}
+	if expected := []int{1}; !slices.Equal(removed, expected) {
+		t.Errorf("expected removed elements %v, got %v", expected, removed)
+	}
+
+	removed = nil
+	observableSet.Clear()
+	slices.Sort(removed)
+	if expected := []int{2, 3, 4}; !slices.Equal(removed, expected) {
+		t.Errorf("expected removed elements %v after Clear, got %v", expected, removed)
+	}
+	assertSize(t, observableSet, 0)
+}
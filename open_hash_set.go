@@ -0,0 +1,740 @@
+package set
+
+import (
+	"fmt"
+	"hash/maphash"
+	"iter"
+	"math/bits"
+	"slices"
+	"strings"
+)
+
+// An OpenHashSet is an unordered collection of unique elements of type E, using a hash table with
+// open addressing as its backing storage. Unlike [HashSet], which uses Go's built-in map, it lets
+// the user provide the hash function, to control hashing for adversarial inputs. Elements are
+// stored inline without map values, which keeps memory overhead low for large sets of small
+// elements.
+//
+// The table uses a "swiss table" layout: slots are grouped by 8, and each group has a control word
+// with 1 byte per slot, holding 7 bits of the slot element's hash (or marking the slot as empty or
+// deleted). A lookup compares its hash against all 8 control bytes of a group at once, using
+// bitwise operations on the control word, so it only needs to compare elements whose hash bits
+// match. Go's
+// built-in map uses a similar layout since Go 1.24, so for the default hash function, OpenHashSet
+// mainly differs from HashSet in avoiding per-entry storage for map values.
+//
+// By default, elements are hashed with [maphash.Comparable], using a random seed per set. A custom
+// hash function can be provided with [OpenHashSetWithHasher].
+//
+// When the table is full, it is rehashed into a larger table, which by default moves all elements
+// at once, so a single Add may take O(n) time. For latency-sensitive uses, incremental rehashing
+// can be enabled with [OpenHashSet.SetIncrementalRehash], to bound the time of each Add.
+//
+// The zero value for an OpenHashSet is ready to use. It must not be copied after first use.
+//
+// OpenHashSet implements [Set] when passed by pointer, and [ComparableSet] when passed by value.
+type OpenHashSet[E comparable] struct {
+	hasher     func(element E) uint64
+	groups     []openHashGroup[E]
+	size       int
+	tombstones int
+
+	// With incremental rehashing, oldGroups holds the previous table while its elements are moved
+	// into groups, a few groups at a time. Groups before index migrated have been moved, and are
+	// marked as deleted.
+	incremental bool
+	oldGroups   []openHashGroup[E]
+	migrated    int
+}
+
+// An openHashGroup holds 8 slots of an OpenHashSet, and a control word with 1 control byte per
+// slot. A control byte is either openHashEmpty, openHashDeleted, or the lower 7 bits of the hash of
+// the element in the slot (so the top bit is only set for empty and deleted slots).
+type openHashGroup[E comparable] struct {
+	control uint64
+	slots   [openHashGroupSize]E
+}
+
+const (
+	openHashGroupSize = 8
+
+	// Control byte for a slot that has never been used since the group was last cleared. Lookups stop
+	// probing at a group with an empty slot, since the element would have been inserted there.
+	openHashEmpty = 0b1000_0000
+
+	// Control byte for a slot whose element has been removed (a "tombstone"). Lookups must keep
+	// probing past deleted slots, but they can be reused for new elements.
+	openHashDeleted = 0b1111_1110
+
+	openHashAllEmpty   = 0x8080_8080_8080_8080
+	openHashAllDeleted = 0xFEFE_FEFE_FEFE_FEFE
+	openHashLSBs       = 0x0101_0101_0101_0101
+	openHashMSBs       = 0x8080_8080_8080_8080
+)
+
+// NewOpenHashSet creates a new [OpenHashSet] for elements of type E, hashed with
+// [maphash.Comparable].
+// It must not be copied after first use.
+func NewOpenHashSet[E comparable]() OpenHashSet[E] {
+	return OpenHashSet[E]{hasher: newMaphashHasher[E](), groups: nil, size: 0, tombstones: 0}
+}
+
+// OpenHashSetWithCapacity creates a new [OpenHashSet], with room for at least the given number of
+// elements before growing.
+// It must not be copied after first use.
+func OpenHashSetWithCapacity[E comparable](capacity int) OpenHashSet[E] {
+	return OpenHashSetWithHasher(newMaphashHasher[E](), capacity)
+}
+
+// OpenHashSetWithHasher creates a new [OpenHashSet] that hashes elements with the given function,
+// with room for at least the given number of elements before growing. The hash function must return
+// the same hash for equal elements, and should spread its output over all 64 bits.
+// It must not be copied after first use.
+func OpenHashSetWithHasher[E comparable](
+	hasher func(element E) uint64,
+	capacity int,
+) OpenHashSet[E] {
+	return OpenHashSet[E]{
+		hasher:     hasher,
+		groups:     newOpenHashGroups[E](openHashGroupsForCapacity(capacity)),
+		size:       0,
+		tombstones: 0,
+	}
+}
+
+// OpenHashSetOf creates a new [OpenHashSet] from the given elements.
+// It must not be copied after first use.
+// Duplicate elements are added only once.
+func OpenHashSetOf[E comparable](elements ...E) OpenHashSet[E] {
+	return OpenHashSetFromSlice(elements)
+}
+
+// OpenHashSetFromSlice creates a new [OpenHashSet] from the elements in the given slice.
+// It must not be copied after first use.
+// Duplicate elements in the slice are added only once.
+func OpenHashSetFromSlice[E comparable](elements []E) OpenHashSet[E] {
+	set := OpenHashSetWithCapacity[E](len(elements))
+
+	for _, element := range elements {
+		set.Add(element)
+	}
+
+	return set
+}
+
+// Add adds the given element to the set.
+// If the element is already present in the set, Add is a no-op.
+//
+// If the set was not previously initialized through one of the constructors in this package, it
+// will be initialized here.
+func (set *OpenHashSet[E]) Add(element E) {
+	if set.hasher == nil {
+		set.hasher = newMaphashHasher[E]()
+	}
+
+	set.migrateStep()
+
+	hash := set.hasher(element)
+	if len(set.groups) != 0 {
+		if _, _, found := set.find(element, hash); found {
+			return
+		}
+	}
+	if set.oldGroups != nil {
+		if _, _, found := findInOpenHashGroups(set.oldGroups, element, hash); found {
+			return
+		}
+	}
+
+	if set.size+set.tombstones+1 > len(set.groups)*openHashMaxLoadPerGroup {
+		set.growForAdd()
+	}
+
+	group, slot := set.findInsertSlot(hash)
+	if group.controlByte(slot) == openHashDeleted {
+		set.tombstones--
+	}
+
+	group.setControlByte(slot, byte(hash&0x7f))
+	group.slots[slot] = element
+	set.size++
+}
+
+// AddMultiple adds the given elements to the set. Duplicate elements are added only once, and
+// elements already present in the set are not added.
+//
+// If the set was not previously initialized through one of the constructors in this package, it
+// will be initialized here.
+func (set *OpenHashSet[E]) AddMultiple(elements ...E) {
+	set.AddFromSlice(elements)
+}
+
+// AddFromSlice adds the elements from the given slice to the set. Duplicate elements are added only
+// once, and elements already present in the set are not added.
+//
+// If the set was not previously initialized through one of the constructors in this package, it
+// will be initialized here.
+func (set *OpenHashSet[E]) AddFromSlice(elements []E) {
+	set.reserve(len(elements))
+
+	for _, element := range elements {
+		set.Add(element)
+	}
+}
+
+// AddFromSet adds elements from the given other set to the set.
+//
+// If the set was not previously initialized through one of the constructors in this package, it
+// will be initialized here.
+func (set *OpenHashSet[E]) AddFromSet(otherSet ComparableSet[E]) {
+	set.reserve(otherSet.Size())
+
+	for element := range otherSet.All() {
+		set.Add(element)
+	}
+}
+
+// AddFromSeq adds the elements from the given iterator to the set, without first collecting them
+// into a slice. Duplicate elements are added only once, and elements already present in the set are
+// not added.
+//
+// If the set was not previously initialized through one of the constructors in this package, it
+// will be initialized here.
+func (set *OpenHashSet[E]) AddFromSeq(seq iter.Seq[E]) {
+	for element := range seq {
+		set.Add(element)
+	}
+}
+
+// Remove removes the given element from the set.
+// If the element is not present in the set, Remove is a no-op.
+//
+// If the element's group is full, its slot is marked as deleted rather than empty, since lookups
+// for other elements may have probed past it. Deleted slots are reused by later adds, and cleared
+// when the set is rehashed.
+func (set *OpenHashSet[E]) Remove(element E) {
+	if set.size == 0 {
+		return
+	}
+
+	set.migrateStep()
+
+	hash := set.hasher(element)
+	group, slot, found := set.find(element, hash)
+	if !found {
+		if set.oldGroups == nil {
+			return
+		}
+
+		// Slots in the old table are always marked as deleted, since it is never inserted into.
+		group, slot, found = findInOpenHashGroups(set.oldGroups, element, hash)
+		if !found {
+			return
+		}
+
+		group.setControlByte(slot, openHashDeleted)
+		var zero E
+		group.slots[slot] = zero
+		set.size--
+		return
+	}
+
+	if matchEmpty(group.control) != 0 {
+		group.setControlByte(slot, openHashEmpty)
+	} else {
+		group.setControlByte(slot, openHashDeleted)
+		set.tombstones++
+	}
+
+	var zero E
+	group.slots[slot] = zero // Lets the garbage collector free whatever the element points to.
+	set.size--
+}
+
+// Clear removes all elements from the set, leaving an empty set with the same capacity as before.
+func (set *OpenHashSet[E]) Clear() {
+	for i := range set.groups {
+		set.groups[i] = openHashGroup[E]{control: openHashAllEmpty}
+	}
+
+	set.size = 0
+	set.tombstones = 0
+	set.oldGroups = nil
+	set.migrated = 0
+}
+
+// Grow increases the set's capacity, if necessary, to guarantee room for another n elements. After
+// Grow(n), at least n elements can be added to the set without rehashing.
+//
+// Panics if n is negative.
+func (set *OpenHashSet[E]) Grow(n int) {
+	checkGrowCount(n)
+	set.reserve(n)
+}
+
+// SetIncrementalRehash enables or disables incremental rehashing. When enabled, growing the table
+// does not move all elements at once: the set keeps the old table alongside a new one that is twice
+// as large, and each later Add and Remove moves the elements of a few old groups into the new
+// table. This bounds the worst-case time of Add, at the cost of lookups checking both tables while
+// the migration is ongoing, and of using more memory for the duration.
+//
+// Grow, and bulk adds that need more room than the table has, still rehash all at once. Disabling
+// incremental rehashing finishes any ongoing migration.
+func (set *OpenHashSet[E]) SetIncrementalRehash(enabled bool) {
+	set.incremental = enabled
+	if !enabled {
+		set.finishMigration()
+	}
+}
+
+// Cap returns the number of elements the set can hold before it must rehash. Slots held by
+// tombstones of removed elements are not available until the set is rehashed.
+func (set OpenHashSet[E]) Cap() int {
+	return set.Stats().Capacity
+}
+
+// Contains checks if given element is present in the set.
+func (set OpenHashSet[E]) Contains(element E) bool {
+	if set.size == 0 {
+		return false
+	}
+
+	hash := set.hasher(element)
+	if _, _, found := set.find(element, hash); found {
+		return true
+	}
+	if set.oldGroups != nil {
+		_, _, found := findInOpenHashGroups(set.oldGroups, element, hash)
+		return found
+	}
+	return false
+}
+
+// Size returns the number of elements in the set.
+func (set OpenHashSet[E]) Size() int {
+	return set.size
+}
+
+// IsEmpty checks if there are 0 elements in the set.
+func (set OpenHashSet[E]) IsEmpty() bool {
+	return set.size == 0
+}
+
+// Equals checks if the set contains exactly the same elements as the other given set.
+func (set OpenHashSet[E]) Equals(otherSet ComparableSet[E]) bool {
+	return set.Size() == otherSet.Size() && set.IsSubsetOf(otherSet)
+}
+
+// IsSubsetOf checks if all of the elements in the set exist in the other given set.
+func (set OpenHashSet[E]) IsSubsetOf(otherSet ComparableSet[E]) bool {
+	for element := range set.All() {
+		if !otherSet.Contains(element) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsSupersetOf checks if the set contains all of the elements in the other given set.
+func (set OpenHashSet[E]) IsSupersetOf(otherSet ComparableSet[E]) bool {
+	return otherSet.IsSubsetOf(set)
+}
+
+// Union creates a new set that contains all the elements of the receiver set and the other given
+// set. The underlying type of the returned set is an *OpenHashSet, using the same hash function as
+// the receiver - to get a value type, use [OpenHashSet.UnionOpenHashSet] instead.
+func (set OpenHashSet[E]) Union(otherSet ComparableSet[E]) Set[E] {
+	union := set.UnionOpenHashSet(otherSet)
+	return &union
+}
+
+// UnionOpenHashSet creates a new OpenHashSet that contains all the elements of the receiver set and
+// the other given set, using the same hash function as the receiver.
+func (set OpenHashSet[E]) UnionOpenHashSet(otherSet ComparableSet[E]) OpenHashSet[E] {
+	union := set.emptyWithSameHasher(set.Size() + otherSet.Size())
+
+	for element := range set.All() {
+		union.Add(element)
+	}
+
+	for element := range otherSet.All() {
+		union.Add(element)
+	}
+
+	return union
+}
+
+// Intersection creates a new set with only the elements that exist in both the receiver set and the
+// other given set. The underlying type of the returned set is an *OpenHashSet, using the same hash
+// function as the receiver - to get a value type, use [OpenHashSet.IntersectionOpenHashSet]
+// instead.
+func (set OpenHashSet[E]) Intersection(otherSet ComparableSet[E]) Set[E] {
+	intersection := set.IntersectionOpenHashSet(otherSet)
+	return &intersection
+}
+
+// IntersectionOpenHashSet creates a new OpenHashSet with only the elements that exist in both the
+// receiver set and the other given set, using the same hash function as the receiver.
+func (set OpenHashSet[E]) IntersectionOpenHashSet(otherSet ComparableSet[E]) OpenHashSet[E] {
+	var capacity int
+	if set.Size() < otherSet.Size() {
+		capacity = set.Size()
+	} else {
+		capacity = otherSet.Size()
+	}
+
+	intersection := set.emptyWithSameHasher(capacity)
+	for element := range set.All() {
+		if otherSet.Contains(element) {
+			intersection.Add(element)
+		}
+	}
+
+	return intersection
+}
+
+// ToSlice creates a slice with all the elements in the set.
+//
+// Since sets are unordered, the order of elements in the slice is non-deterministic, and may vary
+// even when called multiple times on the same set.
+func (set OpenHashSet[E]) ToSlice() []E {
+	return set.AppendTo(make([]E, 0, set.size))
+}
+
+// AppendTo appends the elements in the set to the given slice, and returns the extended slice,
+// like the append builtin. Unlike ToSlice, this lets the caller reuse a buffer across calls.
+func (set OpenHashSet[E]) AppendTo(slice []E) []E {
+	slice = slices.Grow(slice, set.size)
+
+	for element := range set.All() {
+		slice = append(slice, element)
+	}
+
+	return slice
+}
+
+// ToMap creates a map with all the set's elements as keys.
+func (set OpenHashSet[E]) ToMap() map[E]struct{} {
+	m := make(map[E]struct{}, set.size)
+
+	for element := range set.All() {
+		m[element] = struct{}{}
+	}
+
+	return m
+}
+
+// Copy creates a new set with all the same elements, capacity and hash function as the original
+// set. The underlying type of the returned set is an *OpenHashSet - to get a value type, use
+// [OpenHashSet.CopyOpenHashSet] instead.
+func (set OpenHashSet[E]) Copy() Set[E] {
+	newSet := set.CopyOpenHashSet()
+	return &newSet
+}
+
+// CopyOpenHashSet creates a new OpenHashSet with all the same elements, capacity and hash function
+// as the original set.
+func (set OpenHashSet[E]) CopyOpenHashSet() OpenHashSet[E] {
+	return OpenHashSet[E]{
+		hasher:      set.hasher,
+		groups:      slices.Clone(set.groups),
+		size:        set.size,
+		tombstones:  set.tombstones,
+		incremental: set.incremental,
+		oldGroups:   slices.Clone(set.oldGroups),
+		migrated:    set.migrated,
+	}
+}
+
+// String returns a string representation of the set, implementing [fmt.Stringer].
+//
+// Since sets are unordered, the order of elements in the string may differ each time it is called.
+//
+// An OpenHashSet of elements 1, 2 and 3 will be printed as: OpenHashSet{1, 2, 3} (though the order
+// may vary).
+func (set OpenHashSet[E]) String() string {
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString("OpenHashSet{")
+
+	first := true
+	for element := range set.All() {
+		if !first {
+			stringBuilder.WriteString(", ")
+		}
+		fmt.Fprint(&stringBuilder, element)
+		first = false
+	}
+
+	stringBuilder.WriteByte('}')
+	return stringBuilder.String()
+}
+
+// All returns an iterator over the elements in the set, which can be used in a for-range loop:
+//
+//	for element := range set.All() {
+//		fmt.Println(element)
+//	}
+//
+// Since sets are unordered, iteration order is non-deterministic.
+func (set OpenHashSet[E]) All() iter.Seq[E] {
+	return func(yield func(element E) bool) {
+		for _, groups := range [2][]openHashGroup[E]{set.groups, set.oldGroups} {
+			for i := range groups {
+				group := &groups[i]
+
+				for full := matchFull(group.control); full != 0; full &= full - 1 {
+					if !yield(group.slots[firstMatchIndex(full)]) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// find returns the group and slot index that holds the given element with the given hash, with
+// found = true if the element is present in the set. The set must have at least 1 group.
+//
+// Groups are probed in triangular number order (1, 2, 3... groups apart), which visits every group
+// when the number of groups is a power of 2. Probing stops at the first group with an empty slot.
+func (set OpenHashSet[E]) find(
+	element E,
+	hash uint64,
+) (group *openHashGroup[E], slot int, found bool) {
+	return findInOpenHashGroups(set.groups, element, hash)
+}
+
+// findInOpenHashGroups is like OpenHashSet.find, but on the given groups, so that it can also be
+// used on the old table while rehashing incrementally.
+func findInOpenHashGroups[E comparable](
+	groups []openHashGroup[E],
+	element E,
+	hash uint64,
+) (group *openHashGroup[E], slot int, found bool) {
+	mask := uint64(len(groups) - 1)
+	groupIndex := (hash >> 7) & mask
+	controlByte := byte(hash & 0x7f)
+
+	// The old table of an incremental rehash may have no empty slots left, since migrated groups
+	// are marked as deleted, so probing also stops after visiting every group.
+	for probe := uint64(1); probe <= mask+1; probe++ {
+		group = &groups[groupIndex]
+
+		matches := matchControlByte(group.control, controlByte)
+		for ; matches != 0; matches &= matches - 1 {
+			slot = firstMatchIndex(matches)
+			if group.slots[slot] == element {
+				return group, slot, true
+			}
+		}
+
+		if matchEmpty(group.control) != 0 {
+			return nil, 0, false
+		}
+
+		groupIndex = (groupIndex + probe) & mask
+	}
+
+	return nil, 0, false
+}
+
+// findInsertSlot returns the first empty or deleted slot in the probe sequence for the given hash.
+// The set must have room for at least 1 more element.
+func (set OpenHashSet[E]) findInsertSlot(hash uint64) (group *openHashGroup[E], slot int) {
+	mask := uint64(len(set.groups) - 1)
+	groupIndex := (hash >> 7) & mask
+
+	for probe := uint64(1); ; probe++ {
+		group = &set.groups[groupIndex]
+
+		if available := matchEmptyOrDeleted(group.control); available != 0 {
+			return group, firstMatchIndex(available)
+		}
+
+		groupIndex = (groupIndex + probe) & mask
+	}
+}
+
+// reserve makes room for the given number of additional elements, to avoid rehashing repeatedly
+// when adding many elements.
+func (set *OpenHashSet[E]) reserve(additional int) {
+	if set.hasher == nil {
+		set.hasher = newMaphashHasher[E]()
+	}
+
+	if set.size+set.tombstones+additional > len(set.groups)*openHashMaxLoadPerGroup {
+		// Rehashing only moves the elements of the current table, so any incremental rehash must
+		// finish first.
+		set.finishMigration()
+		set.rehash(openHashGroupsForCapacity(set.size + additional))
+	}
+}
+
+// rehash moves the elements of the set into the given number of new groups, which also clears
+// deleted slots.
+func (set *OpenHashSet[E]) rehash(groupCount int) {
+	oldGroups := set.groups
+	set.groups = newOpenHashGroups[E](groupCount)
+	set.tombstones = 0
+
+	for i := range oldGroups {
+		oldGroup := &oldGroups[i]
+
+		for full := matchFull(oldGroup.control); full != 0; full &= full - 1 {
+			element := oldGroup.slots[firstMatchIndex(full)]
+			hash := set.hasher(element)
+
+			group, slot := set.findInsertSlot(hash)
+			group.setControlByte(slot, byte(hash&0x7f))
+			group.slots[slot] = element
+		}
+	}
+}
+
+// growForAdd makes room for adding 1 more element to a full table. If the live elements take up at
+// most 7/16 of the slots, so that most of the load is tombstones, this rehashes at the same size,
+// which clears them. Otherwise, the table is doubled, since rehashing at the same size would leave
+// it nearly full again, so that repeatedly removing and adding elements would rehash every time.
+func (set *OpenHashSet[E]) growForAdd() {
+	if !set.incremental || len(set.groups) == 0 {
+		groupCount := len(set.groups)
+		if (set.size+1)*16 > groupCount*openHashGroupSize*7 {
+			groupCount = max(2*groupCount, openHashGroupsForCapacity(set.size+1))
+		}
+		set.rehash(groupCount)
+		return
+	}
+
+	// The new table is made twice as large as needed, so that it has room for the elements added
+	// while the old table is migrated.
+	set.finishMigration()
+	set.oldGroups = set.groups
+	set.migrated = 0
+	set.groups = newOpenHashGroups[E](openHashGroupsForCapacity(2 * (set.size + 1)))
+	set.tombstones = 0
+}
+
+// migrateStep moves the elements of the next few groups of the old table into the current table,
+// if the set is rehashing incrementally. Every Add and Remove calls this, so that the old table is
+// fully migrated before the current table can fill up.
+func (set *OpenHashSet[E]) migrateStep() {
+	if set.oldGroups == nil {
+		return
+	}
+
+	end := min(set.migrated+openHashMigrationGroupsPerStep, len(set.oldGroups))
+	for ; set.migrated < end; set.migrated++ {
+		oldGroup := &set.oldGroups[set.migrated]
+
+		for full := matchFull(oldGroup.control); full != 0; full &= full - 1 {
+			element := oldGroup.slots[firstMatchIndex(full)]
+			hash := set.hasher(element)
+
+			group, slot := set.findInsertSlot(hash)
+			if group.controlByte(slot) == openHashDeleted {
+				set.tombstones--
+			}
+			group.setControlByte(slot, byte(hash&0x7f))
+			group.slots[slot] = element
+		}
+
+		// Marking the migrated slots as deleted rather than empty keeps probe sequences through
+		// this group intact for elements in later groups that have not been migrated yet.
+		*oldGroup = openHashGroup[E]{control: openHashAllDeleted}
+	}
+
+	if set.migrated == len(set.oldGroups) {
+		set.oldGroups = nil
+		set.migrated = 0
+	}
+}
+
+// finishMigration moves all remaining elements of the old table into the current table, if the set
+// is rehashing incrementally.
+func (set *OpenHashSet[E]) finishMigration() {
+	for set.oldGroups != nil {
+		set.migrateStep()
+	}
+}
+
+func (set OpenHashSet[E]) emptyWithSameHasher(capacity int) OpenHashSet[E] {
+	hasher := set.hasher
+	if hasher == nil {
+		hasher = newMaphashHasher[E]()
+	}
+
+	return OpenHashSetWithHasher(hasher, capacity)
+}
+
+// The number of old groups that are migrated by each Add and Remove while rehashing incrementally.
+// This must be large enough that the old table is fully migrated before the new table, which is
+// twice as large as needed, fills up.
+const openHashMigrationGroupsPerStep = 4
+
+// The maximum number of elements (including deleted slots) per group before rehashing, giving a
+// max load factor of 7/8. This guarantees that every group probe sequence ends at an empty slot.
+const openHashMaxLoadPerGroup = openHashGroupSize * 7 / 8
+
+// openHashGroupsForCapacity returns the number of groups needed to hold the given number of
+// elements without exceeding the max load factor. The result is always a power of 2, so that hashes
+// can be mapped to groups with a bit mask.
+func openHashGroupsForCapacity(capacity int) int {
+	groupCount := 1
+	for groupCount*openHashMaxLoadPerGroup < capacity {
+		groupCount *= 2
+	}
+	return groupCount
+}
+
+func newOpenHashGroups[E comparable](groupCount int) []openHashGroup[E] {
+	groups := make([]openHashGroup[E], groupCount)
+	for i := range groups {
+		groups[i].control = openHashAllEmpty
+	}
+	return groups
+}
+
+func (group *openHashGroup[E]) controlByte(slot int) byte {
+	return byte(group.control >> (slot * 8))
+}
+
+func (group *openHashGroup[E]) setControlByte(slot int, controlByte byte) {
+	shift := slot * 8
+	group.control = group.control&^(0xff<<shift) | uint64(controlByte)<<shift
+}
+
+// The match functions below compare all 8 control bytes in a control word at once, returning a
+// word with the top bit set in each matching byte. matchControlByte may give false positives for
+// full slots next to a true match, so callers must compare elements of the matched slots.
+
+func matchControlByte(control uint64, controlByte byte) uint64 {
+	diff := control ^ (openHashLSBs * uint64(controlByte))
+	return (diff - openHashLSBs) &^ diff & openHashMSBs
+}
+
+func matchEmpty(control uint64) uint64 {
+	// Empty and deleted slots both have the top bit set, but only deleted slots have the second
+	// lowest bit set, which the shift moves to the top bit of the same byte.
+	return control &^ (control << 6) & openHashMSBs
+}
+
+func matchEmptyOrDeleted(control uint64) uint64 {
+	return control & openHashMSBs
+}
+
+func matchFull(control uint64) uint64 {
+	return ^control & openHashMSBs
+}
+
+func firstMatchIndex(matches uint64) int {
+	return bits.TrailingZeros64(matches) / 8
+}
+
+func newMaphashHasher[E comparable]() func(element E) uint64 {
+	seed := maphash.MakeSeed()
+
+	return func(element E) uint64 {
+		return maphash.Comparable(seed, element)
+	}
+}
@@ -0,0 +1,138 @@
+package set_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestOpenHashSetRemoveKeepsElementsReachable(t *testing.T) {
+	openHashSet := set.NewOpenHashSet[int]()
+	expected := make(map[int]struct{})
+
+	for i := 0; i < 10000; i++ {
+		element := rand.Intn(500)
+
+		if rand.Intn(3) == 0 {
+			openHashSet.Remove(element)
+			delete(expected, element)
+		} else {
+			openHashSet.Add(element)
+			expected[element] = struct{}{}
+		}
+	}
+
+	assertSize(t, openHashSet, len(expected))
+	for element := range expected {
+		if !openHashSet.Contains(element) {
+			t.Fatalf("expected %v to contain %d after random adds and removes", openHashSet, element)
+		}
+	}
+}
+
+func TestOpenHashSetWithHasher(t *testing.T) {
+	// A hash function that maps all elements to the same slot, to test collision handling.
+	collidingHasher := func(element string) uint64 { return 0 }
+
+	openHashSet := set.OpenHashSetWithHasher(collidingHasher, 0)
+	openHashSet.AddMultiple("a", "b", "c", "d")
+	openHashSet.Remove("b")
+
+	assertSize(t, openHashSet, 3)
+	assertContains(t, openHashSet, "a", "c", "d")
+
+	union := openHashSet.UnionOpenHashSet(set.HashSetOf("e"))
+	assertSize(t, union, 4)
+	assertContains(t, union, "a", "c", "d", "e")
+}
+
+func TestOpenHashSetReusesDeletedSlots(t *testing.T) {
+	openHashSet := set.OpenHashSetWithCapacity[int](100)
+
+	// Repeatedly adding and removing elements fills the table with deleted slots, which must either
+	// be reused or cleared by rehashing, so that lookups still terminate.
+	for i := 0; i < 100000; i++ {
+		openHashSet.Add(i)
+		openHashSet.Remove(i - 50)
+	}
+
+	assertSize(t, openHashSet, 50)
+	for i := 100000 - 50; i < 100000; i++ {
+		if !openHashSet.Contains(i) {
+			t.Fatalf("expected %v to contain %d", openHashSet, i)
+		}
+	}
+	if openHashSet.Contains(0) {
+		t.Errorf("expected removed element 0 to not be in set")
+	}
+}
+
+func TestOpenHashSetChurnAtFullLoad(t *testing.T) {
+	openHashSet := set.OpenHashSetWithCapacity[int](7168)
+	initialCap := openHashSet.Cap()
+	for i := range initialCap - 1 {
+		openHashSet.Add(i)
+	}
+
+	// Removing and adding elements in a nearly full table fills it with tombstones. Clearing them
+	// by rehashing at the same size would leave the table nearly full, and rehash again on the next
+	// add, so the table must grow instead.
+	for i := range 2000 {
+		openHashSet.Remove(i)
+		openHashSet.Add(initialCap + i)
+	}
+
+	assertSize(t, openHashSet, initialCap-1)
+	if openHashSet.Cap() <= initialCap {
+		t.Errorf("expected table to grow beyond capacity %d, got %d", initialCap, openHashSet.Cap())
+	}
+}
+
+func TestOpenHashSetIncrementalRehash(t *testing.T) {
+	openHashSet := set.NewOpenHashSet[int]()
+	openHashSet.SetIncrementalRehash(true)
+	expected := make(map[int]struct{})
+
+	// Checking the set after every operation covers states in the middle of migrating the old table.
+	for i := 0; i < 10000; i++ {
+		element := rand.Intn(5000)
+
+		if rand.Intn(4) == 0 {
+			openHashSet.Remove(element)
+			delete(expected, element)
+		} else {
+			openHashSet.Add(element)
+			expected[element] = struct{}{}
+		}
+
+		if openHashSet.Size() != len(expected) {
+			t.Fatalf("expected size %d after operation %d, got %d", len(expected), i, openHashSet.Size())
+		}
+		if !openHashSet.Contains(element) {
+			if _, ok := expected[element]; ok {
+				t.Fatalf("expected %d to be in set after operation %d", element, i)
+			}
+		}
+	}
+
+	copied := openHashSet.CopyOpenHashSet()
+	iterated := 0
+	for element := range copied.All() {
+		if _, ok := expected[element]; !ok {
+			t.Fatalf("unexpected element %d in copied set", element)
+		}
+		iterated++
+	}
+	if iterated != len(expected) {
+		t.Errorf("expected to iterate %d elements, got %d", len(expected), iterated)
+	}
+
+	openHashSet.SetIncrementalRehash(false)
+	assertSize(t, openHashSet, len(expected))
+	for element := range expected {
+		if !openHashSet.Contains(element) {
+			t.Fatalf("expected %v to contain %d", openHashSet, element)
+		}
+	}
+}
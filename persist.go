@@ -0,0 +1,173 @@
+package set
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+)
+
+// The format written by Save is a header, followed by the set in the format of MarshalBinary (see
+// binary.go), followed by a checksum:
+//   - Magic bytes "SET" and a format version byte (currently 1)
+//   - The element type, as a uvarint length prefix followed by the type's string (e.g. "int"), so
+//     that loading into a set of another element type fails instead of giving garbage
+//   - The number of elements as a uvarint, then the elements
+//   - The CRC-32 (IEEE) checksum of everything before it, little-endian
+//
+// The version is incremented for changes that older versions of Load cannot read, so Load can give
+// a clear error for files from newer versions instead of misreading them.
+
+var persistMagic = []byte("SET")
+
+const persistFormatVersion = 1
+
+// Save writes the given set to the given writer, in a versioned format that can be read back by
+// [Load]. This lets large sets be checkpointed to disk and restored across process restarts. The
+// same element types as for MarshalBinary are supported.
+//
+// The set is encoded element by element, without building the whole encoding in memory. See
+// [SaveFile] for writing to a file.
+func Save[E comparable](writer io.Writer, set ComparableSet[E]) error {
+	codec, err := newBinaryElementCodec[E]()
+	if err != nil {
+		return err
+	}
+
+	checksum := crc32.NewIEEE()
+	bufferedWriter := bufio.NewWriter(io.MultiWriter(writer, checksum))
+
+	buffer := append([]byte(nil), persistMagic...)
+	buffer = append(buffer, persistFormatVersion)
+	elementType := reflect.TypeFor[E]().String()
+	buffer = binary.AppendUvarint(buffer, uint64(len(elementType)))
+	buffer = append(buffer, elementType...)
+	buffer = binary.AppendUvarint(buffer, uint64(set.Size()))
+	if _, err := bufferedWriter.Write(buffer); err != nil {
+		return fmt.Errorf("set: failed to write header: %w", err)
+	}
+
+	for element := range set.All() {
+		buffer, err = codec.encode(buffer[:0], element)
+		if err != nil {
+			return fmt.Errorf("set: failed to encode element: %w", err)
+		}
+		if _, err := bufferedWriter.Write(buffer); err != nil {
+			return fmt.Errorf("set: failed to write element: %w", err)
+		}
+	}
+
+	if err := bufferedWriter.Flush(); err != nil {
+		return fmt.Errorf("set: failed to write elements: %w", err)
+	}
+
+	// The checksum is written directly to the writer, so that it is not included in itself.
+	if _, err := writer.Write(binary.LittleEndian.AppendUint32(nil, checksum.Sum32())); err != nil {
+		return fmt.Errorf("set: failed to write checksum: %w", err)
+	}
+	return nil
+}
+
+// Load clears the given set, and adds the elements read from the given reader, in the format
+// written by [Save]. It returns an error if the data is from an unsupported format version, was
+// saved with a different element type, or is corrupt.
+//
+// The whole input is read into memory before decoding. See [LoadFile] for reading from a file.
+func Load[E comparable](reader io.Reader, set Set[E]) error {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("set: failed to read saved set: %w", err)
+	}
+
+	elements, err := decodeSaved[E](data)
+	if err != nil {
+		return err
+	}
+
+	set.Clear()
+	set.AddFromSlice(elements)
+	return nil
+}
+
+func decodeSaved[E comparable](data []byte) ([]E, error) {
+	if len(data) < len(persistMagic)+1+crc32.Size || !bytes.HasPrefix(data, persistMagic) {
+		return nil, errors.New("set: data is not a saved set")
+	}
+
+	version := data[len(persistMagic)]
+	if version != persistFormatVersion {
+		return nil, fmt.Errorf(
+			"set: unsupported saved set format version %d (supported: %d)",
+			version,
+			persistFormatVersion,
+		)
+	}
+
+	payload, checksum := data[:len(data)-crc32.Size], data[len(data)-crc32.Size:]
+	if crc32.ChecksumIEEE(payload) != binary.LittleEndian.Uint32(checksum) {
+		return nil, errors.New("set: saved set is corrupt: checksum mismatch")
+	}
+
+	payload = payload[len(persistMagic)+1:]
+	savedType, bytesRead, err := decodeLengthPrefixed(payload)
+	if err != nil {
+		return nil, fmt.Errorf("set: failed to read element type of saved set: %w", err)
+	}
+	if elementType := reflect.TypeFor[E]().String(); string(savedType) != elementType {
+		return nil, fmt.Errorf(
+			"set: saved set has element type %s, cannot load into set of %s",
+			savedType,
+			elementType,
+		)
+	}
+
+	return unmarshalBinaryElements[E](payload[bytesRead:])
+}
+
+// SaveFile writes the given set to the file at the given path with [Save], replacing the file if
+// it exists. The set is first written to a temporary file in the same directory, which is then
+// renamed to the path, so that a crash while saving never leaves a partially written file.
+func SaveFile[E comparable](path string, set ComparableSet[E]) (returnedErr error) {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("set: failed to create temporary file: %w", err)
+	}
+	defer func() {
+		if returnedErr != nil {
+			_ = file.Close()
+			_ = os.Remove(file.Name())
+		}
+	}()
+
+	if err := Save(file, set); err != nil {
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("set: failed to sync saved set to disk: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("set: failed to close saved set file: %w", err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("set: failed to move saved set into place: %w", err)
+	}
+	return nil
+}
+
+// LoadFile clears the given set, and adds the elements read with [Load] from the file at the given
+// path.
+func LoadFile[E comparable](path string, set Set[E]) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("set: failed to open saved set file: %w", err)
+	}
+	defer file.Close()
+
+	return Load(file, set)
+}
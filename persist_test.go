@@ -0,0 +1,87 @@
+package set_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestSaveLoad(t *testing.T) {
+	original := set.HashSetFromSlice(createRandomIntSlice(1000))
+
+	var buffer bytes.Buffer
+	if err := set.Save[int](&buffer, original); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := set.HashSetOf(-1)
+	if err := set.Load[int](&buffer, &loaded); err != nil {
+		t.Fatal(err)
+	}
+
+	if !loaded.Equals(original) {
+		t.Errorf("expected loaded set to equal original set, got %v", loaded)
+	}
+}
+
+func TestSaveLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.set")
+
+	if err := set.SaveFile[string](path, set.ArraySetOf("a", "b")); err != nil {
+		t.Fatal(err)
+	}
+	// Saving again replaces the file.
+	if err := set.SaveFile[string](path, set.ArraySetOf("c")); err != nil {
+		t.Fatal(err)
+	}
+
+	var loaded set.DynamicSet[string]
+	if err := set.LoadFile[string](path, &loaded); err != nil {
+		t.Fatal(err)
+	}
+
+	assertSize(t, loaded, 1)
+	assertContains(t, loaded, "c")
+}
+
+func TestLoadInvalidData(t *testing.T) {
+	var saved bytes.Buffer
+	if err := set.Save[int](&saved, set.ArraySetOf(1, 2, 3)); err != nil {
+		t.Fatal(err)
+	}
+	data := saved.Bytes()
+
+	corrupted := bytes.Clone(data)
+	corrupted[len(corrupted)-5]++
+
+	newerVersion := bytes.Clone(data)
+	newerVersion[3] = 2
+
+	for _, testCase := range []struct {
+		name          string
+		data          []byte
+		expectedError string
+	}{
+		{"empty", nil, "not a saved set"},
+		{"wrong magic", []byte("NOTASET"), "not a saved set"},
+		{"corrupted", corrupted, "checksum mismatch"},
+		{"newer version", newerVersion, "unsupported saved set format version 2"},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			var loaded set.HashSet[int]
+			err := set.Load[int](bytes.NewReader(testCase.data), &loaded)
+			if err == nil || !strings.Contains(err.Error(), testCase.expectedError) {
+				t.Errorf("expected error containing %q, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+
+	var wrongType set.HashSet[string]
+	err := set.Load[string](bytes.NewReader(data), &wrongType)
+	if err == nil || !strings.Contains(err.Error(), "element type int") {
+		t.Errorf("expected element type mismatch error, got %v", err)
+	}
+}
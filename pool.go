@@ -0,0 +1,82 @@
+package set
+
+import (
+	"reflect"
+	"sync"
+)
+
+// maxPooledHashSetSize is the largest size of a HashSet that is kept for reuse when released to a
+// [HashSetPool]. Go maps keep their memory after being cleared, so pooling larger sets would keep
+// the memory of rare large sets alive for every later user of the pool.
+const maxPooledHashSetSize = 4096
+
+// A HashSetPool is a pool of empty HashSets for elements of type E, for reusing sets that are built
+// and discarded frequently, such as a set built for each request in a server. Reusing a set avoids
+// allocating its backing map again, since clearing a Go map keeps its memory. It is backed by a
+// [sync.Pool], so unused sets may be garbage collected at any time.
+//
+// HashSetPool is safe for concurrent use by multiple goroutines. The zero value for a HashSetPool
+// is ready to use. It must not be copied after first use.
+type HashSetPool[E comparable] struct {
+	pool sync.Pool
+}
+
+// Get returns an empty HashSet from the pool, or allocates a new one if the pool is empty. Call
+// [HashSetPool.Put] to return the set to the pool when done with it.
+func (pool *HashSetPool[E]) Get() *HashSet[E] {
+	if set, ok := pool.pool.Get().(*HashSet[E]); ok {
+		return set
+	}
+
+	set := NewHashSet[E]()
+	return &set
+}
+
+// Put clears the given set and returns it to the pool, to be reused by a later call to Get. The set
+// must not be used after it is returned to the pool. Sets with more than 4096 elements are not
+// returned to the pool, to avoid keeping their memory alive.
+//
+// Put only checks the set's current size, since Go maps do not expose their capacity. A set that
+// grew large and then had its elements removed keeps the memory of its peak size, but is still
+// pooled. If that is a concern, let such a set be garbage collected instead of calling Put.
+func (pool *HashSetPool[E]) Put(set *HashSet[E]) {
+	if set == nil || set.Size() > maxPooledHashSetSize {
+		return
+	}
+
+	set.Clear()
+	pool.pool.Put(set)
+}
+
+// hashSetPools maps element types (reflect.Type) to the *HashSetPool used by GetPooledHashSet and
+// ReleasePooledHashSet for that type.
+var hashSetPools sync.Map
+
+// GetPooledHashSet returns an empty HashSet from a package-level [HashSetPool] for element type E.
+// Call [ReleasePooledHashSet] to return the set to the pool when done with it:
+//
+//	seen := set.GetPooledHashSet[string]()
+//	defer set.ReleasePooledHashSet(seen)
+//
+// Looking up the pool for E has a small cost - in hot paths, use a HashSetPool directly instead.
+func GetPooledHashSet[E comparable]() *HashSet[E] {
+	return hashSetPoolFor[E]().Get()
+}
+
+// ReleasePooledHashSet clears the given set and returns it to the package-level pool for element
+// type E, to be reused by a later call to [GetPooledHashSet]. The set must not be used after it is
+// released.
+func ReleasePooledHashSet[E comparable](set *HashSet[E]) {
+	hashSetPoolFor[E]().Put(set)
+}
+
+func hashSetPoolFor[E comparable]() *HashSetPool[E] {
+	elementType := reflect.TypeFor[E]()
+
+	if pool, ok := hashSetPools.Load(elementType); ok {
+		return pool.(*HashSetPool[E])
+	}
+
+	pool, _ := hashSetPools.LoadOrStore(elementType, &HashSetPool[E]{})
+	return pool.(*HashSetPool[E])
+}
@@ -0,0 +1,33 @@
+package set_test
+
+import (
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestHashSetPool(t *testing.T) {
+	var pool set.HashSetPool[int]
+
+	hashSet := pool.Get()
+	hashSet.AddMultiple(1, 2, 3)
+	pool.Put(hashSet)
+
+	// sync.Pool may drop values at any time, so we can only check that sets from the pool are empty.
+	for range 10 {
+		reused := pool.Get()
+		assertSize(t, reused, 0)
+		reused.Add(4)
+		pool.Put(reused)
+	}
+}
+
+func TestPooledHashSet(t *testing.T) {
+	hashSet := set.GetPooledHashSet[string]()
+	hashSet.AddMultiple("a", "b")
+	set.ReleasePooledHashSet(hashSet)
+
+	reused := set.GetPooledHashSet[string]()
+	defer set.ReleasePooledHashSet(reused)
+	assertSize(t, reused, 0)
+}
@@ -0,0 +1,194 @@
+package set
+
+import (
+	"container/heap"
+	"fmt"
+	"hash/maphash"
+	"iter"
+	"strings"
+)
+
+// A Reservoir keeps a uniform random sample of at most k distinct elements from a stream of
+// elements. This gives a representative sample of a stream that is too large to keep in memory, in
+// a single pass, without knowing the length of the stream up front:
+//
+//	sample := set.NewReservoir[string](1000)
+//	sample.AddFromSeq(requestPaths)
+//	fmt.Println(sample.Sample())
+//
+// Every distinct element in the stream has the same probability of being in the sample, regardless
+// of how many times it occurs. This is done by hashing each element with a random seed, and keeping
+// the k elements with the smallest hashes (a "bottom-k" sample). Since an element always gets the
+// same hash, repeating it does not make it more likely to be sampled.
+//
+// A Reservoir must be created with [NewReservoir]. It must not be copied after first use, and is
+// not safe for concurrent use.
+type Reservoir[E comparable] struct {
+	maxSize int
+	seen    int
+	seed    maphash.Seed
+	// entries is a max-heap by hash, so that the entry with the largest hash is the first to be
+	// replaced by an element with a smaller hash.
+	entries  reservoirHeap[E]
+	contains map[E]struct{}
+}
+
+// NewReservoir creates a new [Reservoir], which keeps a sample of at most maxSize elements.
+// It must not be copied after first use.
+//
+// Panics if maxSize is less than 1.
+func NewReservoir[E comparable](maxSize int) Reservoir[E] {
+	if maxSize < 1 {
+		panic(fmt.Sprintf("set: Reservoir max size must be at least 1, got %d", maxSize))
+	}
+
+	return Reservoir[E]{
+		maxSize:  maxSize,
+		seen:     0,
+		seed:     maphash.MakeSeed(),
+		entries:  make(reservoirHeap[E], 0, maxSize),
+		contains: make(map[E]struct{}, maxSize),
+	}
+}
+
+// Add adds the given element to the stream that the reservoir samples from. Until the reservoir is
+// full, every distinct element is kept. After that, the element replaces the sampled element with
+// the largest hash, if its own hash is smaller.
+func (reservoir *Reservoir[E]) Add(element E) {
+	reservoir.seen++
+
+	hash := maphash.Comparable(reservoir.seed, element)
+	full := len(reservoir.entries) == reservoir.maxSize
+	if full && hash >= reservoir.entries[0].hash {
+		return
+	}
+	if _, ok := reservoir.contains[element]; ok {
+		return
+	}
+
+	entry := reservoirEntry[E]{element: element, hash: hash}
+	if full {
+		delete(reservoir.contains, reservoir.entries[0].element)
+		reservoir.entries[0] = entry
+		heap.Fix(&reservoir.entries, 0)
+	} else {
+		heap.Push(&reservoir.entries, entry)
+	}
+	reservoir.contains[element] = struct{}{}
+}
+
+// AddFromSeq adds all the elements from the given iterator to the stream that the reservoir
+// samples from.
+func (reservoir *Reservoir[E]) AddFromSeq(seq iter.Seq[E]) {
+	for element := range seq {
+		reservoir.Add(element)
+	}
+}
+
+// Sample creates a new set with the elements currently in the sample.
+func (reservoir Reservoir[E]) Sample() HashSet[E] {
+	sample := HashSetWithCapacity[E](len(reservoir.entries))
+	for _, entry := range reservoir.entries {
+		sample.Add(entry.element)
+	}
+	return sample
+}
+
+// Contains checks if the given element is currently in the sample.
+func (reservoir Reservoir[E]) Contains(element E) bool {
+	_, ok := reservoir.contains[element]
+	return ok
+}
+
+// Size returns the number of elements currently in the sample, which is at most the max size.
+func (reservoir Reservoir[E]) Size() int {
+	return len(reservoir.entries)
+}
+
+// MaxSize returns the maximum number of elements in the sample.
+func (reservoir Reservoir[E]) MaxSize() int {
+	return reservoir.maxSize
+}
+
+// Seen returns the number of elements added to the reservoir, including elements that were not
+// kept in the sample.
+func (reservoir Reservoir[E]) Seen() int {
+	return reservoir.seen
+}
+
+// Clear removes all elements from the sample, and resets the number of seen elements, so that the
+// reservoir can sample a new stream. A new random seed is used for the new stream.
+func (reservoir *Reservoir[E]) Clear() {
+	clear(reservoir.contains)
+	reservoir.entries = reservoir.entries[:0]
+	reservoir.seen = 0
+	reservoir.seed = maphash.MakeSeed()
+}
+
+// All returns an iterator over the elements currently in the sample, which can be used in a
+// for-range loop:
+//
+//	for element := range reservoir.All() {
+//		fmt.Println(element)
+//	}
+//
+// The iteration order is the order of the reservoir's internal heap, which is effectively random.
+func (reservoir Reservoir[E]) All() iter.Seq[E] {
+	return func(yield func(element E) bool) {
+		for _, entry := range reservoir.entries {
+			if !yield(entry.element) {
+				return
+			}
+		}
+	}
+}
+
+// String returns a string representation of the sample, implementing [fmt.Stringer].
+//
+// A Reservoir with elements 1, 2 and 3 in its sample will be printed as: Reservoir{1, 2, 3} (though
+// the order may vary).
+func (reservoir Reservoir[E]) String() string {
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString("Reservoir{")
+
+	for i, entry := range reservoir.entries {
+		fmt.Fprint(&stringBuilder, entry.element)
+
+		if i < len(reservoir.entries)-1 {
+			stringBuilder.WriteString(", ")
+		}
+	}
+
+	stringBuilder.WriteByte('}')
+	return stringBuilder.String()
+}
+
+type reservoirEntry[E comparable] struct {
+	element E
+	hash    uint64
+}
+
+// reservoirHeap is a max-heap of reservoir entries by hash, implementing [heap.Interface].
+type reservoirHeap[E comparable] []reservoirEntry[E]
+
+func (entries reservoirHeap[E]) Len() int {
+	return len(entries)
+}
+
+func (entries reservoirHeap[E]) Less(i, j int) bool {
+	return entries[i].hash > entries[j].hash
+}
+
+func (entries reservoirHeap[E]) Swap(i, j int) {
+	entries[i], entries[j] = entries[j], entries[i]
+}
+
+func (entries *reservoirHeap[E]) Push(entry any) {
+	*entries = append(*entries, entry.(reservoirEntry[E]))
+}
+
+func (entries *reservoirHeap[E]) Pop() any {
+	last := (*entries)[len(*entries)-1]
+	*entries = (*entries)[:len(*entries)-1]
+	return last
+}
@@ -0,0 +1,88 @@
+package set_test
+
+import (
+	"slices"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestReservoir(t *testing.T) {
+	reservoir := set.NewReservoir[int](10)
+	reservoir.AddFromSeq(slices.Values(createRandomIntSlice(10_000)))
+
+	if reservoir.Seen() != 10_000 {
+		t.Errorf("expected 10000 seen elements, got %d", reservoir.Seen())
+	}
+
+	sample := reservoir.Sample()
+	assertSize(t, &sample, 10)
+	for element := range sample.All() {
+		if element < 0 || element >= 20_000 {
+			t.Errorf("unexpected element %d in sample", element)
+		}
+		if !reservoir.Contains(element) {
+			t.Errorf("expected reservoir to contain sampled element %d", element)
+		}
+	}
+
+	reservoir.Clear()
+	if reservoir.Size() != 0 || reservoir.Seen() != 0 {
+		t.Errorf("expected cleared reservoir to be empty, got %v", reservoir)
+	}
+}
+
+func TestReservoirIsUniform(t *testing.T) {
+	const trials = 5000
+	var counts [10]int
+	for range trials {
+		reservoir := set.NewReservoir[int](2)
+		for i := range len(counts) {
+			reservoir.Add(i)
+		}
+		for element := range reservoir.All() {
+			counts[element]++
+		}
+	}
+
+	// Each element is expected to be sampled in 2/10 of the trials.
+	for element, count := range counts {
+		if count < trials/10 || count > trials*3/10 {
+			t.Errorf("element %d was sampled %d times in %d trials", element, count, trials)
+		}
+	}
+}
+
+func TestReservoirIsUniformOverDistinctElements(t *testing.T) {
+	const trials = 5000
+	var counts [10]int
+	for range trials {
+		reservoir := set.NewReservoir[int](2)
+		for range 100 {
+			reservoir.Add(0)
+		}
+		for i := 1; i < len(counts); i++ {
+			reservoir.Add(i)
+		}
+		for element := range reservoir.All() {
+			counts[element]++
+		}
+	}
+
+	// Element 0 occurs 100 times, but is expected to be sampled as often as the other elements, in
+	// 2/10 of the trials.
+	for element, count := range counts {
+		if count < trials/10 || count > trials*3/10 {
+			t.Errorf("element %d was sampled %d times in %d trials", element, count, trials)
+		}
+	}
+}
+
+func TestReservoirIgnoresElementsInSample(t *testing.T) {
+	reservoir := set.NewReservoir[int](3)
+	reservoir.AddFromSeq(slices.Values([]int{1, 1, 2, 1}))
+
+	if reservoir.Size() != 2 || !reservoir.Contains(1) || !reservoir.Contains(2) {
+		t.Errorf("expected sample of 2 elements, got %v", reservoir)
+	}
+}
@@ -0,0 +1,124 @@
+package set
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+)
+
+// A SecretSet is a set of secret values, such as API tokens, for checking presented secrets against
+// an allow list or a revocation list without leaking information through timing. With a regular
+// set, the time to look up a string may depend on how many of its leading bytes match a stored
+// string, which an attacker who can time lookups may use to guess a secret byte by byte.
+//
+// A SecretSet instead stores the HMAC-SHA256 of each secret, keyed with a random key that is
+// generated for each set. Looking up a secret computes its HMAC, which takes time that depends
+// only on the length of the secret, and then looks up the HMAC. Since the key is unknown to the
+// attacker, the timing of that lookup gives no information about the stored secrets.
+//
+// Since only HMACs are stored, the secrets cannot be retrieved from the set, so SecretSet has no
+// methods for iterating over its elements, and does not implement [Set].
+//
+// The zero value for a SecretSet is ready to use, and generates its key on the first Add. It must
+// not be copied after first use, and is not safe for concurrent use while it is being modified.
+//
+// To not leak the key, a SecretSet prints only its number of secrets with all fmt verbs.
+type SecretSet[S ~string | ~[]byte] struct {
+	key     []byte
+	digests HashSet[[sha256.Size]byte]
+}
+
+// secretSetKeySize is the size of the random HMAC key of a SecretSet, which is the block size of
+// SHA-256, so that the key is not hashed before use.
+const secretSetKeySize = sha256.BlockSize
+
+// NewSecretSet creates a new [SecretSet], with a random key from [crypto/rand].
+// It must not be copied after first use.
+func NewSecretSet[S ~string | ~[]byte]() SecretSet[S] {
+	return SecretSet[S]{key: newSecretSetKey(), digests: NewHashSet[[sha256.Size]byte]()}
+}
+
+// Add adds the given secret to the set.
+// If the secret is already present in the set, Add is a no-op.
+func (set *SecretSet[S]) Add(secret S) {
+	if set.key == nil {
+		set.key = newSecretSetKey()
+	}
+
+	set.digests.Add(set.digest(secret))
+}
+
+// Remove removes the given secret from the set.
+// If the secret is not present in the set, Remove is a no-op.
+func (set *SecretSet[S]) Remove(secret S) {
+	set.digests.Remove(set.digest(secret))
+}
+
+// Clear removes all secrets from the set.
+func (set *SecretSet[S]) Clear() {
+	set.digests.Clear()
+}
+
+// Contains checks if the given secret is present in the set, in time that does not depend on how
+// much of the secret matches a stored secret.
+func (set SecretSet[S]) Contains(secret S) bool {
+	// A set without a key has never had a secret added, and must not compute digests with an empty
+	// key.
+	if set.key == nil {
+		return false
+	}
+
+	return set.digests.Contains(set.digest(secret))
+}
+
+// Size returns the number of secrets in the set.
+func (set SecretSet[S]) Size() int {
+	return set.digests.Size()
+}
+
+// IsEmpty checks if there are 0 secrets in the set.
+func (set SecretSet[S]) IsEmpty() bool {
+	return set.digests.IsEmpty()
+}
+
+// String returns a string representation of the set, implementing [fmt.Stringer]. Since the
+// secrets cannot be retrieved, only the number of secrets is printed.
+//
+// A SecretSet of 3 secrets will be printed as: SecretSet{3 hidden}
+func (set SecretSet[S]) String() string {
+	return fmt.Sprintf("SecretSet{%d hidden}", set.digests.Size())
+}
+
+// GoString implements [fmt.GoStringer], so that the %#v verb does not print the set's key. A
+// SecretSet of 3 secrets will be printed as: set.SecretSet{3 hidden}
+func (set SecretSet[S]) GoString() string {
+	return "set." + set.String()
+}
+
+// Format implements [fmt.Formatter], printing the set like [SecretSet.String] for all verbs, and
+// like [SecretSet.GoString] for %#v. Without this, verbs such as %d would print the set's fields,
+// including its key.
+func (set SecretSet[S]) Format(state fmt.State, verb rune) {
+	if verb == 'v' && state.Flag('#') {
+		writePadded(state, set.GoString())
+	} else {
+		writePadded(state, set.String())
+	}
+}
+
+func newSecretSetKey() []byte {
+	key := make([]byte, secretSetKeySize)
+	if _, err := rand.Read(key); err != nil {
+		// crypto/rand.Read is documented to never return an error.
+		panic(fmt.Sprintf("set: failed to generate SecretSet key: %v", err))
+	}
+	return key
+}
+
+func (set SecretSet[S]) digest(secret S) (digest [sha256.Size]byte) {
+	mac := hmac.New(sha256.New, set.key)
+	mac.Write([]byte(secret))
+	mac.Sum(digest[:0])
+	return digest
+}
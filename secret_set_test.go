@@ -0,0 +1,66 @@
+package set_test
+
+import (
+	"fmt"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestSecretSet(t *testing.T) {
+	tokens := set.NewSecretSet[string]()
+	tokens.Add("token-1")
+	tokens.Add("token-2")
+	tokens.Add("token-1")
+
+	if tokens.Size() != 2 {
+		t.Errorf("expected 2 secrets, got %v", tokens)
+	}
+	if !tokens.Contains("token-1") || !tokens.Contains("token-2") {
+		t.Errorf("expected added secrets to be contained")
+	}
+	if tokens.Contains("token-") || tokens.Contains("token-3") {
+		t.Errorf("expected secrets that were not added to not be contained")
+	}
+
+	tokens.Remove("token-1")
+	if tokens.Contains("token-1") || tokens.Size() != 1 {
+		t.Errorf("expected removed secret to not be contained")
+	}
+
+	if str := tokens.String(); str != "SecretSet{1 hidden}" {
+		t.Errorf("unexpected string %q", str)
+	}
+
+	byteTokens := set.NewSecretSet[[]byte]()
+	byteTokens.Add([]byte("token"))
+	if !byteTokens.Contains([]byte("token")) {
+		t.Errorf("expected added byte secret to be contained")
+	}
+}
+
+func TestSecretSetZeroValue(t *testing.T) {
+	var tokens set.SecretSet[string]
+	if tokens.Contains("") {
+		t.Errorf("expected empty zero value to not contain any secret")
+	}
+
+	tokens.Add("token")
+	if !tokens.Contains("token") || tokens.Contains("other") {
+		t.Errorf("expected zero value to work after Add")
+	}
+}
+
+func TestSecretSetFormatHidesKey(t *testing.T) {
+	tokens := set.NewSecretSet[string]()
+	tokens.Add("token")
+
+	for _, format := range []string{"%v", "%+v", "%d", "%x", "%s"} {
+		if formatted := fmt.Sprintf(format, tokens); formatted != "SecretSet{1 hidden}" {
+			t.Errorf("expected %s to print only the number of secrets, got %s", format, formatted)
+		}
+	}
+	if formatted := fmt.Sprintf("%#v", tokens); formatted != "set.SecretSet{1 hidden}" {
+		t.Errorf("expected %%#v to print only the number of secrets, got %s", formatted)
+	}
+}
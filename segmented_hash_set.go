@@ -0,0 +1,377 @@
+package set
+
+import (
+	"fmt"
+	"hash/maphash"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// A SegmentedHashSet is an unordered collection of unique elements of type E, which spreads its
+// elements over a fixed number of segments, each backed by its own hashmap. An element's segment is
+// chosen by hashing it. Since each segment grows and is reallocated independently, operations that
+// reallocate the set's storage, such as Grow and Compact, allocate and copy one segment at a time,
+// instead of the whole set at once. This bounds the peak memory and pause of growing a very large
+// set, at the cost of hashing each element one extra time to find its segment.
+//
+// Note that Go maps themselves grow incrementally in fixed-size tables since Go 1.24, so adding
+// elements one by one to a [HashSet] does not cause large single allocations either. The benefit
+// of SegmentedHashSet is mainly for Grow, Compact and copying, which reallocate a HashSet's whole
+// map at once.
+//
+// The zero value for a SegmentedHashSet is ready to use. It must not be copied after first use.
+//
+// SegmentedHashSet implements [Set] when passed by pointer, and [ComparableSet] when passed by
+// value.
+type SegmentedHashSet[E comparable] struct {
+	segments []map[E]struct{}
+	size     int
+	seed     maphash.Seed
+}
+
+// segmentedHashSetSegments is the number of segments in a SegmentedHashSet. It must be a power of
+// 2, so that an element's segment can be chosen by masking its hash.
+const segmentedHashSetSegments = 64
+
+// NewSegmentedHashSet creates a new [SegmentedHashSet] for elements of type E.
+// It must not be copied after first use.
+func NewSegmentedHashSet[E comparable]() SegmentedHashSet[E] {
+	return SegmentedHashSetWithCapacity[E](0)
+}
+
+// SegmentedHashSetWithCapacity creates a new [SegmentedHashSet], with at least the given initial
+// capacity, spread evenly over its segments.
+// It must not be copied after first use.
+func SegmentedHashSetWithCapacity[E comparable](capacity int) SegmentedHashSet[E] {
+	set := SegmentedHashSet[E]{segments: nil, size: 0, seed: maphash.MakeSeed()}
+	set.segments = make([]map[E]struct{}, segmentedHashSetSegments)
+	for i := range set.segments {
+		set.segments[i] = make(map[E]struct{}, segmentCapacity(capacity))
+	}
+	return set
+}
+
+// SegmentedHashSetOf creates a new [SegmentedHashSet] from the given elements.
+// It must not be copied after first use.
+// Duplicate elements are added only once.
+func SegmentedHashSetOf[E comparable](elements ...E) SegmentedHashSet[E] {
+	return SegmentedHashSetFromSlice(elements)
+}
+
+// SegmentedHashSetFromSlice creates a new [SegmentedHashSet] from the elements in the given slice.
+// It must not be copied after first use.
+// Duplicate elements in the slice are added only once.
+func SegmentedHashSetFromSlice[E comparable](elements []E) SegmentedHashSet[E] {
+	set := SegmentedHashSetWithCapacity[E](len(elements))
+	set.AddFromSlice(elements)
+	return set
+}
+
+// Add adds the given element to the set.
+// If the element is already present in the set, Add is a no-op.
+func (set *SegmentedHashSet[E]) Add(element E) {
+	if set.segments == nil {
+		*set = NewSegmentedHashSet[E]()
+	}
+
+	segment := set.segmentFor(element)
+	sizeBefore := len(segment)
+	segment[element] = struct{}{}
+	set.size += len(segment) - sizeBefore
+}
+
+// AddMultiple adds the given elements to the set. Duplicate elements are added only once, and
+// elements already present in the set are not added.
+func (set *SegmentedHashSet[E]) AddMultiple(elements ...E) {
+	set.AddFromSlice(elements)
+}
+
+// AddFromSlice adds the elements from the given slice to the set. Duplicate elements are added only
+// once, and elements already present in the set are not added.
+func (set *SegmentedHashSet[E]) AddFromSlice(elements []E) {
+	for _, element := range elements {
+		set.Add(element)
+	}
+}
+
+// AddFromSet adds elements from the given other set to the set.
+func (set *SegmentedHashSet[E]) AddFromSet(otherSet ComparableSet[E]) {
+	for element := range otherSet.All() {
+		set.Add(element)
+	}
+}
+
+// AddFromSeq adds the elements from the given iterator to the set, without first collecting them
+// into a slice. Duplicate elements are added only once, and elements already present in the set are
+// not added.
+func (set *SegmentedHashSet[E]) AddFromSeq(seq iter.Seq[E]) {
+	for element := range seq {
+		set.Add(element)
+	}
+}
+
+// Remove removes the given element from the set.
+// If the element is not present in the set, Remove is a no-op.
+func (set *SegmentedHashSet[E]) Remove(element E) {
+	if set.size == 0 {
+		return
+	}
+
+	segment := set.segmentFor(element)
+	sizeBefore := len(segment)
+	delete(segment, element)
+	set.size -= sizeBefore - len(segment)
+}
+
+// Clear removes all elements from the set, leaving an empty set with the same capacity as before.
+func (set *SegmentedHashSet[E]) Clear() {
+	for _, segment := range set.segments {
+		clear(segment)
+	}
+	set.size = 0
+}
+
+// Grow increases the set's capacity to make room for another n elements, spread evenly over its
+// segments. Like [HashSet.Grow], this reallocates the backing maps (unless n is 0), but one segment
+// at a time, so at most one segment's worth of memory is copied at once.
+//
+// Panics if n is negative.
+func (set *SegmentedHashSet[E]) Grow(n int) {
+	checkGrowCount(n)
+	if n == 0 {
+		return
+	}
+	if set.segments == nil {
+		*set = SegmentedHashSetWithCapacity[E](n)
+		return
+	}
+
+	additional := segmentCapacity(n)
+	for i, segment := range set.segments {
+		grown := make(map[E]struct{}, len(segment)+additional)
+		for element := range segment {
+			grown[element] = struct{}{}
+		}
+		set.segments[i] = grown
+	}
+}
+
+// Compact reallocates each of the set's segments to fit its current size, like [HashSet.Compact],
+// one segment at a time.
+func (set *SegmentedHashSet[E]) Compact() {
+	for i, segment := range set.segments {
+		compacted := make(map[E]struct{}, len(segment))
+		for element := range segment {
+			compacted[element] = struct{}{}
+		}
+		set.segments[i] = compacted
+	}
+}
+
+// Contains checks if given element is present in the set.
+func (set SegmentedHashSet[E]) Contains(element E) bool {
+	if set.size == 0 {
+		return false
+	}
+
+	_, contains := set.segmentFor(element)[element]
+	return contains
+}
+
+// Size returns the number of elements in the set.
+func (set SegmentedHashSet[E]) Size() int {
+	return set.size
+}
+
+// IsEmpty checks if there are 0 elements in the set.
+func (set SegmentedHashSet[E]) IsEmpty() bool {
+	return set.size == 0
+}
+
+// Equals checks if the set contains exactly the same elements as the other given set.
+func (set SegmentedHashSet[E]) Equals(otherSet ComparableSet[E]) bool {
+	return set.Size() == otherSet.Size() && set.IsSubsetOf(otherSet)
+}
+
+// IsSubsetOf checks if all of the elements in the set exist in the other given set.
+func (set SegmentedHashSet[E]) IsSubsetOf(otherSet ComparableSet[E]) bool {
+	for element := range set.All() {
+		if !otherSet.Contains(element) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsSupersetOf checks if the set contains all of the elements in the other given set.
+func (set SegmentedHashSet[E]) IsSupersetOf(otherSet ComparableSet[E]) bool {
+	return otherSet.IsSubsetOf(set)
+}
+
+// Union creates a new set that contains all the elements of the receiver set and the other given
+// set. The underlying type of the returned set is a *SegmentedHashSet - to get a value type, use
+// [SegmentedHashSet.UnionSegmentedHashSet] instead.
+func (set SegmentedHashSet[E]) Union(otherSet ComparableSet[E]) Set[E] {
+	union := set.UnionSegmentedHashSet(otherSet)
+	return &union
+}
+
+// UnionSegmentedHashSet creates a new SegmentedHashSet that contains all the elements of the
+// receiver set and the other given set.
+func (set SegmentedHashSet[E]) UnionSegmentedHashSet(
+	otherSet ComparableSet[E],
+) SegmentedHashSet[E] {
+	union := set.CopySegmentedHashSet()
+	union.AddFromSet(otherSet)
+	return union
+}
+
+// Intersection creates a new set with only the elements that exist in both the receiver set and the
+// other given set. The underlying type of the returned set is a *SegmentedHashSet - to get a value
+// type, use [SegmentedHashSet.IntersectionSegmentedHashSet] instead.
+func (set SegmentedHashSet[E]) Intersection(otherSet ComparableSet[E]) Set[E] {
+	intersection := set.IntersectionSegmentedHashSet(otherSet)
+	return &intersection
+}
+
+// IntersectionSegmentedHashSet creates a new SegmentedHashSet with only the elements that exist in
+// both the receiver set and the other given set.
+func (set SegmentedHashSet[E]) IntersectionSegmentedHashSet(
+	otherSet ComparableSet[E],
+) SegmentedHashSet[E] {
+	intersection := NewSegmentedHashSet[E]()
+
+	for element := range set.All() {
+		if otherSet.Contains(element) {
+			intersection.Add(element)
+		}
+	}
+
+	return intersection
+}
+
+// ToSlice creates a slice with all the elements in the set.
+//
+// Since sets are unordered, the order of elements in the slice is non-deterministic, and may vary
+// even when called multiple times on the same set.
+func (set SegmentedHashSet[E]) ToSlice() []E {
+	return set.AppendTo(make([]E, 0, set.size))
+}
+
+// AppendTo appends the elements in the set to the given slice, and returns the extended slice,
+// like the append builtin. Unlike ToSlice, this lets the caller reuse a buffer across calls.
+func (set SegmentedHashSet[E]) AppendTo(slice []E) []E {
+	slice = slices.Grow(slice, set.size)
+
+	for _, segment := range set.segments {
+		for element := range segment {
+			slice = append(slice, element)
+		}
+	}
+
+	return slice
+}
+
+// ToMap creates a map with all the set's elements as keys. Since the elements are spread over
+// several maps, this always copies them.
+func (set SegmentedHashSet[E]) ToMap() map[E]struct{} {
+	m := make(map[E]struct{}, set.size)
+
+	for _, segment := range set.segments {
+		for element := range segment {
+			m[element] = struct{}{}
+		}
+	}
+
+	return m
+}
+
+// Copy creates a new set with all the same elements as the original set.
+// The underlying type of the returned set is a *SegmentedHashSet - to get a value type, use
+// [SegmentedHashSet.CopySegmentedHashSet] instead.
+func (set SegmentedHashSet[E]) Copy() Set[E] {
+	newSet := set.CopySegmentedHashSet()
+	return &newSet
+}
+
+// CopySegmentedHashSet creates a new SegmentedHashSet with all the same elements as the original
+// set. Segments are copied one at a time, and the copy uses the same hash seed, so that elements
+// stay in the same segments.
+func (set SegmentedHashSet[E]) CopySegmentedHashSet() SegmentedHashSet[E] {
+	if set.segments == nil {
+		return NewSegmentedHashSet[E]()
+	}
+
+	newSet := SegmentedHashSet[E]{
+		segments: make([]map[E]struct{}, len(set.segments)),
+		size:     set.size,
+		seed:     set.seed,
+	}
+
+	for i, segment := range set.segments {
+		newSegment := make(map[E]struct{}, len(segment))
+		for element := range segment {
+			newSegment[element] = struct{}{}
+		}
+		newSet.segments[i] = newSegment
+	}
+
+	return newSet
+}
+
+// String returns a string representation of the set, implementing [fmt.Stringer].
+//
+// Since sets are unordered, the order of elements in the string may differ each time it is called.
+//
+// A SegmentedHashSet of elements 1, 2 and 3 will be printed as: SegmentedHashSet{1, 2, 3} (though
+// the order may vary).
+func (set SegmentedHashSet[E]) String() string {
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString("SegmentedHashSet{")
+
+	i := 0
+	for element := range set.All() {
+		fmt.Fprint(&stringBuilder, element)
+
+		if i < set.size-1 {
+			stringBuilder.WriteString(", ")
+		}
+
+		i++
+	}
+
+	stringBuilder.WriteByte('}')
+	return stringBuilder.String()
+}
+
+// All returns an iterator over the elements in the set, which can be used in a for-range loop:
+//
+//	for element := range set.All() {
+//		fmt.Println(element)
+//	}
+//
+// Since sets are unordered, iteration order is non-deterministic.
+func (set SegmentedHashSet[E]) All() iter.Seq[E] {
+	return func(yield func(element E) bool) {
+		for _, segment := range set.segments {
+			for element := range segment {
+				if !yield(element) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (set SegmentedHashSet[E]) segmentFor(element E) map[E]struct{} {
+	hash := maphash.Comparable(set.seed, element)
+	return set.segments[hash&(segmentedHashSetSegments-1)]
+}
+
+// segmentCapacity returns the capacity of each segment needed to hold the given number of elements
+// spread evenly over the segments.
+func segmentCapacity(capacity int) int {
+	return (capacity + segmentedHashSetSegments - 1) / segmentedHashSetSegments
+}
@@ -0,0 +1,30 @@
+package set_test
+
+import (
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestSegmentedHashSetGrowAndCompact(t *testing.T) {
+	elements := createRandomIntSlice(1000)
+
+	var segmentedSet set.SegmentedHashSet[int]
+	segmentedSet.Grow(500)
+	segmentedSet.AddFromSlice(elements)
+	segmentedSet.Grow(5000)
+	assertSize(t, &segmentedSet, 1000)
+	assertContains(t, &segmentedSet, elements...)
+
+	for _, element := range elements[100:] {
+		segmentedSet.Remove(element)
+	}
+	segmentedSet.Compact()
+	assertSize(t, &segmentedSet, 100)
+	assertContains(t, &segmentedSet, elements[:100]...)
+
+	copied := segmentedSet.CopySegmentedHashSet()
+	segmentedSet.Clear()
+	assertSize(t, &copied, 100)
+	assertContains(t, &copied, elements[:100]...)
+}
@@ -2,13 +2,48 @@
 // a [HashSet], an [ArraySet] and a [DynamicSet], with a common interface between them.
 package set
 
-// A Set is an unordered collection of unique elements of type E.
+import (
+	"fmt"
+	"iter"
+)
+
+// A Set is an unordered collection of unique elements of type E. Elements are compared with ==, so
+// for sets of floats, NaN elements can never be found or removed, since NaN != NaN - see
+// [RejectNaN] for how to keep them out.
 //
-// Three types in this package implement Set:
+// The three main types in this package that implement Set are:
 //   - [ArraySet] uses an array as its backing storage, optimized for small sets
 //   - [HashSet] uses a hashmap (with empty values) as its backing storage, optimized for large sets
 //   - [DynamicSet] starts out as an ArraySet, but transforms itself to a HashSet once it reaches a
 //     size threshold
+//
+// The package also provides specialized implementations:
+//   - [BoundedSet] has a maximum size, and evicts elements when full
+//   - [FixedSet] has a fixed capacity, in storage given by the caller, and never allocates
+//   - [CopyOnWriteSet] is thread-safe, with lock-free reads, optimized for read-heavy workloads
+//   - [IdentitySet] holds pointers, compared by identity rather than by the values they point to
+//   - [SparseSet] holds small non-negative integers, with O(1) operations and fast iteration
+//   - [BoolMapSet] adapts an existing map[E]bool into a Set
+//   - [SegmentedHashSet] spreads its elements over many maps, to reallocate in smaller steps
+//   - [OpenHashSet] is a hash set with open addressing, which can use a custom hash function
+//   - [IntegerArraySet] is an ArraySet of integers with a faster Contains
+//   - [SynchronizedSet] wraps any other Set with a mutex, to make it thread-safe
+//   - [ObservableSet] wraps any other Set, and calls hooks when elements are added or removed
+//   - [TrackedSet] wraps any other Set, and records the elements added and removed since last flush
+//   - [UndoableSet] wraps any other Set, and keeps a history of changes that can be undone
+//   - [ConstrainedSet] wraps any other Set, and validates elements added to it
+//   - [NormalizedSet] wraps any other Set, and normalizes elements on every add and lookup
+//   - [SortedArraySet] keeps its elements sorted, with O(log n) Contains using binary search
+//
+// [SliceSet] provides a read-only view of a slice as a [ComparableSet], and [FrontCodedSet] is a
+// compact read-only set of strings. [AtomicSet] holds a set that is atomically replaced as a whole,
+// for read-mostly data. [SetMap] maps keys to sets of values, and [DeepSet] holds elements of any
+// type, compared by deep equality. [Interner] returns the canonical stored instance of each
+// element, for deduplicating memory, and [SpillSet] spills to disk, for deduplicating streams that
+// do not fit in memory. [Reservoir] keeps a uniform random sample of a stream. [GSet],
+// [TwoPhaseSet] and [ORSet] are conflict-free replicated sets, for synchronizing between nodes.
+// [WindowSet] remembers elements for a sliding window of time, and [SecretSet] checks secrets such
+// as tokens without leaking them through timing.
 type Set[E comparable] interface {
 	ComparableSet[E]
 
@@ -27,6 +62,11 @@ type Set[E comparable] interface {
 	// AddFromSet adds elements from the given other set to the set.
 	AddFromSet(otherSet ComparableSet[E])
 
+	// AddFromSeq adds the elements from the given iterator to the set, without first collecting
+	// them into a slice. Duplicate elements are added only once, and elements already present in
+	// the set are not added.
+	AddFromSeq(seq iter.Seq[E])
+
 	// Remove removes the given element from the set.
 	// If the element is not present in the set, Remove is a no-op.
 	Remove(element E)
@@ -34,11 +74,23 @@ type Set[E comparable] interface {
 	// Clear removes all elements from the set. When possible, it will retain the same capacity as
 	// before.
 	Clear()
+
+	// Grow increases the set's capacity, if necessary, to make room for n more elements, so that a
+	// known number of elements can be added without repeated reallocation. For set types where this
+	// is not possible, Grow is a hint, as documented on each type.
+	//
+	// Panics if n is negative.
+	Grow(n int)
 }
 
 // A ComparableSet is the value type for a Set, containing only non-mutating methods. This allows
 // passing an ArraySet, HashSet or DynamicSet by value, whereas the full [Set] interface is only
 // implemented when passing them by pointer.
+//
+// Since the non-mutating methods of ArraySet, HashSet and DynamicSet have value receivers, calling
+// them through a nil pointer panics, as for any value method in Go. To pass an optional set, use
+// the zero value instead of a nil pointer: the zero values of these types are empty sets that are
+// ready to use.
 type ComparableSet[E comparable] interface {
 	// Contains checks if given element is present in the set.
 	Contains(element E) bool
@@ -72,10 +124,14 @@ type ComparableSet[E comparable] interface {
 	// Since sets are unordered, the order of elements in the slice is non-deterministic, and may
 	// vary even when called multiple times on the same set.
 	//
-	// If the underlying set type is an ArraySet, the returned slice uses the same backing storage,
-	// so mutating it may invalidate the set. To avoid this, call Copy first.
+	// The returned slice does not alias the set's storage, so it can be mutated freely. The one
+	// exception is [SliceSet], which returns its underlying slice.
 	ToSlice() []E
 
+	// AppendTo appends the elements in the set to the given slice, and returns the extended slice,
+	// like the append builtin. Unlike ToSlice, this lets the caller reuse a buffer across calls.
+	AppendTo(slice []E) []E
+
 	// ToMap returns a map with all the set's elements as keys.
 	//
 	// If the underlying set type is a HashSet, the returned map is the backing storage for the set,
@@ -92,19 +148,24 @@ type ComparableSet[E comparable] interface {
 	// called.
 	String() string
 
-	// All returns an [Iterator] function, which when called will loop over the elements in the set
-	// and call the given yield function on each element. If yield returns false, iteration stops.
+	// All returns an iterator over the elements in the set, which can be used in a for-range loop:
+	//
+	//	for element := range set.All() {
+	//		fmt.Println(element)
+	//	}
 	//
 	// Since sets are unordered, iteration order is non-deterministic.
-	All() Iterator[E]
+	All() iter.Seq[E]
 }
 
-// Iterator aims to satisfy the planned signature for [range over func] in Go, allowing iteration
-// over sets like this in the future:
-//
-//	for element := range mySet.All() {
-//		fmt.Println(element)
-//	}
+func checkGrowCount(n int) {
+	if n < 0 {
+		panic(fmt.Sprintf("set: Grow called with negative count %d", n))
+	}
+}
+
+// Iterator is the type of iterator returned by [ComparableSet.All].
 //
-// [range over func]: https://github.com/golang/go/issues/61405
-type Iterator[E comparable] func(yield func(element E) (continueIteration bool))
+// Deprecated: Sets now return [iter.Seq] from the standard library, which Iterator is an alias for.
+// Use iter.Seq directly instead.
+type Iterator[E comparable] = iter.Seq[E]
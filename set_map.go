@@ -0,0 +1,189 @@
+package set
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// A SetMap maps keys of type K to sets of values of type V, also known as a multimap. It is useful
+// for index structures, such as a map from tags to the documents that have them:
+//
+//	documentsByTag := set.NewSetMap[string, DocumentID]()
+//	documentsByTag.Add("go", doc1)
+//	documentsByTag.Add("go", doc2)
+//	for doc := range documentsByTag.Values("go").All() { ... }
+//
+// The value sets are [DynamicSet]s, since most keys in an index typically have few values, while
+// some may have many. Sets are created when the first value is added for a key, and removed when
+// the last value is removed, so a key is present in the map exactly when it has values.
+//
+// The zero value for a SetMap is ready to use. It must not be copied after first use.
+type SetMap[K comparable, V comparable] struct {
+	sets map[K]*DynamicSet[V]
+	size int
+}
+
+// NewSetMap creates a new [SetMap] with keys of type K and values of type V.
+// It must not be copied after first use.
+func NewSetMap[K comparable, V comparable]() SetMap[K, V] {
+	return SetMap[K, V]{sets: make(map[K]*DynamicSet[V]), size: 0}
+}
+
+// Add adds the given value to the set for the given key, creating the set if the key has no values.
+// If the value is already present for the key, Add is a no-op.
+func (setMap *SetMap[K, V]) Add(key K, value V) {
+	values, ok := setMap.sets[key]
+	if !ok {
+		if setMap.sets == nil {
+			setMap.sets = make(map[K]*DynamicSet[V])
+		}
+
+		newValues := NewDynamicSet[V]()
+		values = &newValues
+		setMap.sets[key] = values
+	}
+
+	sizeBefore := values.Size()
+	values.Add(value)
+	setMap.size += values.Size() - sizeBefore
+}
+
+// Remove removes the given value from the set for the given key. If that leaves the key with no
+// values, the key is removed from the map. If the value is not present for the key, Remove is a
+// no-op.
+func (setMap *SetMap[K, V]) Remove(key K, value V) {
+	values, ok := setMap.sets[key]
+	if !ok {
+		return
+	}
+
+	sizeBefore := values.Size()
+	values.Remove(value)
+	setMap.size -= sizeBefore - values.Size()
+
+	if values.IsEmpty() {
+		delete(setMap.sets, key)
+	}
+}
+
+// RemoveKey removes the given key and all of its values from the map.
+func (setMap *SetMap[K, V]) RemoveKey(key K) {
+	if values, ok := setMap.sets[key]; ok {
+		setMap.size -= values.Size()
+		delete(setMap.sets, key)
+	}
+}
+
+// Clear removes all keys and values from the map.
+func (setMap *SetMap[K, V]) Clear() {
+	clear(setMap.sets)
+	setMap.size = 0
+}
+
+// Contains checks if the given value is present in the set for the given key.
+func (setMap SetMap[K, V]) Contains(key K, value V) bool {
+	values, ok := setMap.sets[key]
+	return ok && values.Contains(value)
+}
+
+// ContainsKey checks if the given key has any values.
+func (setMap SetMap[K, V]) ContainsKey(key K) bool {
+	_, ok := setMap.sets[key]
+	return ok
+}
+
+// Values returns the set of values for the given key, or an empty set if the key has no values.
+//
+// The returned set is the map's own set for the key, so it reflects later changes to the map, and
+// must not be modified. To get a set that can be modified, call Copy on it.
+func (setMap SetMap[K, V]) Values(key K) ComparableSet[V] {
+	if values, ok := setMap.sets[key]; ok {
+		return values
+	}
+	return ArraySet[V]{elements: nil}
+}
+
+// Keys returns an iterator over the keys that have values in the map. Like for Go maps, the
+// iteration order is non-deterministic.
+func (setMap SetMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(key K) bool) {
+		for key := range setMap.sets {
+			if !yield(key) {
+				break
+			}
+		}
+	}
+}
+
+// All returns an iterator over every key-value pair in the map, which can be used in a for-range
+// loop:
+//
+//	for key, value := range setMap.All() {
+//		fmt.Println(key, value)
+//	}
+//
+// Like for Go maps, the iteration order is non-deterministic.
+func (setMap SetMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(key K, value V) bool) {
+		for key, values := range setMap.sets {
+			for value := range values.All() {
+				if !yield(key, value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// KeyCount returns the number of keys that have values in the map.
+func (setMap SetMap[K, V]) KeyCount() int {
+	return len(setMap.sets)
+}
+
+// Size returns the total number of key-value pairs in the map.
+func (setMap SetMap[K, V]) Size() int {
+	return setMap.size
+}
+
+// IsEmpty checks if there are 0 keys in the map.
+func (setMap SetMap[K, V]) IsEmpty() bool {
+	return len(setMap.sets) == 0
+}
+
+// String returns a string representation of the map, implementing [fmt.Stringer].
+//
+// A SetMap with key "a" mapped to values 1 and 2 will be printed as: SetMap{a: {1, 2}} (though the
+// order may vary).
+func (setMap SetMap[K, V]) String() string {
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString("SetMap{")
+
+	i := 0
+	for key, values := range setMap.sets {
+		fmt.Fprint(&stringBuilder, key)
+		stringBuilder.WriteString(": {")
+
+		j := 0
+		for value := range values.All() {
+			fmt.Fprint(&stringBuilder, value)
+
+			if j < values.Size()-1 {
+				stringBuilder.WriteString(", ")
+			}
+
+			j++
+		}
+
+		stringBuilder.WriteByte('}')
+
+		if i < len(setMap.sets)-1 {
+			stringBuilder.WriteString(", ")
+		}
+
+		i++
+	}
+
+	stringBuilder.WriteByte('}')
+	return stringBuilder.String()
+}
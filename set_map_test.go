@@ -0,0 +1,50 @@
+package set_test
+
+import (
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestSetMap(t *testing.T) {
+	var documentsByTag set.SetMap[string, int]
+
+	documentsByTag.Add("go", 1)
+	documentsByTag.Add("go", 2)
+	documentsByTag.Add("go", 2)
+	documentsByTag.Add("rust", 3)
+
+	if size := documentsByTag.Size(); size != 3 {
+		t.Errorf("expected Size() == 3, got %d", size)
+	}
+	if keyCount := documentsByTag.KeyCount(); keyCount != 2 {
+		t.Errorf("expected KeyCount() == 2, got %d", keyCount)
+	}
+	if !documentsByTag.Contains("go", 1) || documentsByTag.Contains("rust", 1) {
+		t.Errorf("unexpected Contains result for %v", documentsByTag)
+	}
+
+	goDocuments := documentsByTag.Values("go")
+	assertSize(t, goDocuments, 2)
+	assertContains(t, goDocuments, 1, 2)
+	assertSize(t, documentsByTag.Values("missing"), 0)
+
+	documentsByTag.Remove("rust", 3)
+	if documentsByTag.ContainsKey("rust") {
+		t.Errorf("expected key with no values to be removed from %v", documentsByTag)
+	}
+
+	documentsByTag.RemoveKey("go")
+	if !documentsByTag.IsEmpty() || documentsByTag.Size() != 0 {
+		t.Errorf("expected empty SetMap after removing all keys, got %v", documentsByTag)
+	}
+}
+
+func TestSetMapString(t *testing.T) {
+	setMap := set.NewSetMap[string, int]()
+	setMap.Add("a", 1)
+
+	if str := setMap.String(); str != "SetMap{a: {1}}" {
+		t.Errorf("unexpected string of SetMap: %s", str)
+	}
+}
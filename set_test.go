@@ -1,6 +1,9 @@
 package set_test
 
 import (
+	"cmp"
+	"errors"
+	"slices"
 	"testing"
 
 	"hermannm.dev/set"
@@ -63,6 +66,81 @@ func TestFromSliceWithDuplicates(t *testing.T) {
 	}
 }
 
+func TestFromSliceStrict(t *testing.T) {
+	// The large slice covers the ArraySet path that checks duplicates with a map.
+	for _, slice := range [][]int{{1, 2, 3}, createRandomIntSlice(100)} {
+		arraySet, err := set.ArraySetFromSliceStrict(slice)
+		if err != nil {
+			t.Fatalf("unexpected error from ArraySetFromSliceStrict: %v", err)
+		}
+		hashSet, err := set.HashSetFromSliceStrict(slice)
+		if err != nil {
+			t.Fatalf("unexpected error from HashSetFromSliceStrict: %v", err)
+		}
+
+		for _, set := range []set.ComparableSet[int]{arraySet, hashSet} {
+			assertSize(t, set, len(slice))
+			assertContains(t, set, slice...)
+		}
+	}
+}
+
+func TestFromSliceStrictWithDuplicates(t *testing.T) {
+	small := []int{1, 2, 1, 2}
+	large := append(createRandomIntSlice(100), -1, -2, -1)
+
+	for _, testCase := range []struct {
+		slice    []int
+		expected set.DuplicateElementError[int]
+	}{
+		{small, set.DuplicateElementError[int]{Element: 1, Index: 2}},
+		{large, set.DuplicateElementError[int]{Element: -1, Index: 102}},
+	} {
+		_, arrayErr := set.ArraySetFromSliceStrict(testCase.slice)
+		_, hashErr := set.HashSetFromSliceStrict(testCase.slice)
+
+		for _, err := range []error{arrayErr, hashErr} {
+			var duplicateErr set.DuplicateElementError[int]
+			if !errors.As(err, &duplicateErr) || duplicateErr != testCase.expected {
+				t.Errorf("expected error %v, got %v", testCase.expected, err)
+			}
+		}
+	}
+}
+
+func TestAdoptingConstructors(t *testing.T) {
+	// The large slice covers the ArraySet path that checks duplicates with a map.
+	for _, unique := range [][]int{{3, 1, 2}, createRandomIntSlice(100)} {
+		withDuplicates := append(slices.Clone(unique), unique...)
+
+		arraySet := set.ArraySetAdoptingSlice(withDuplicates)
+		if !slices.Equal(arraySet.ToSlice(), unique) {
+			t.Errorf("expected adopted ArraySet to keep first occurrences in order, got %v", arraySet)
+		}
+		if tail := withDuplicates[len(unique):]; slices.ContainsFunc(tail, func(i int) bool {
+			return i != 0
+		}) {
+			t.Errorf("expected elements after duplicates were removed to be zeroed, got %v", tail)
+		}
+
+		uniqueSet := set.ArraySetAdoptingUniqueSlice(unique)
+		assertSize(t, &uniqueSet, len(unique))
+		assertContains(t, &uniqueSet, unique...)
+	}
+
+	elements := map[int]struct{}{1: {}, 2: {}}
+	hashSet := set.HashSetAdoptingMap(elements)
+	assertSize(t, &hashSet, 2)
+	hashSet.Add(3)
+	if _, ok := elements[3]; !ok {
+		t.Errorf("expected adopted map to be used as the set's storage")
+	}
+
+	nilMapSet := set.HashSetAdoptingMap[int](nil)
+	nilMapSet.Add(1)
+	assertSize(t, &nilMapSet, 1)
+}
+
 func TestAdd(t *testing.T) {
 	testAllSetTypes(func(set set.Set[int], setName string) {
 		set.Add(1)
@@ -114,6 +192,58 @@ func TestAddFromSet(t *testing.T) {
 	})
 }
 
+func TestAddFromLargeSliceAndSet(t *testing.T) {
+	// Large enough to use the bulk path of ArraySet, with duplicates both within the slice and
+	// between the slice and the set's existing elements.
+	slice := make([]int, 0, 120)
+	for i := range 60 {
+		slice = append(slice, i, i)
+	}
+	otherSet := set.HashSetFromSlice(slice)
+
+	testAllSetTypes(func(set set.Set[int], setName string) {
+		set.AddMultiple(0, 50, 1000)
+
+		set.AddFromSlice(slice)
+		assertSize(t, set, 61)
+
+		set.AddFromSet(otherSet)
+		assertSize(t, set, 61)
+		assertContains(t, set, 0, 50, 59, 1000)
+	})
+}
+
+func TestArraySetAddFromSetDeduplicatesSliceSet(t *testing.T) {
+	var emptySet set.ArraySet[int]
+	emptySet.AddFromSet(set.SliceSet[int]{1, 1})
+	assertSize(t, &emptySet, 1)
+	assertContains(t, &emptySet, 1)
+
+	// Large enough to use the bulk path.
+	slice := make([]int, 0, 120)
+	for i := range 60 {
+		slice = append(slice, i, i)
+	}
+
+	nonEmptySet := set.ArraySetOf(0, 1000)
+	nonEmptySet.AddFromSet(set.SliceSet[int](slice))
+	assertSize(t, nonEmptySet, 61)
+	assertContains(t, nonEmptySet, 0, 59, 1000)
+}
+
+func TestAddFromSeq(t *testing.T) {
+	seq := slices.Values([]int{1, 2, 3, 3})
+
+	testAllSetTypes(func(set set.Set[int], setName string) {
+		set.Add(1)
+
+		set.AddFromSeq(seq)
+
+		assertSize(t, set, 3)
+		assertContains(t, set, 1, 2, 3)
+	})
+}
+
 func TestRemove(t *testing.T) {
 	testAllSetTypes(func(set set.Set[int], setName string) {
 		set.AddMultiple(1, 2, 3)
@@ -125,6 +255,16 @@ func TestRemove(t *testing.T) {
 	})
 }
 
+func TestArraySetRemoveMovesLastElement(t *testing.T) {
+	arraySet := set.ArraySetOf(1, 2, 3, 4)
+
+	arraySet.Remove(2)
+
+	if elements := arraySet.ToSlice(); !slices.Equal(elements, []int{1, 4, 3}) {
+		t.Errorf("expected last element to be moved into removed element's place, got %v", elements)
+	}
+}
+
 func TestRemoveNonExisting(t *testing.T) {
 	testAllSetTypes(func(set set.Set[int], setName string) {
 		set.AddMultiple(1, 2, 3)
@@ -146,6 +286,137 @@ func TestClear(t *testing.T) {
 	})
 }
 
+func TestGrow(t *testing.T) {
+	testAllSetTypes(func(set set.Set[int], setName string) {
+		set.AddMultiple(1, 2, 3)
+		set.Grow(50)
+
+		assertSize(t, set, 3)
+		assertContains(t, set, 1, 2, 3)
+
+		for i := 4; i <= 53; i++ {
+			set.Add(i)
+		}
+		assertSize(t, set, 53)
+
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected Grow with negative count on %s to panic", setName)
+				}
+			}()
+			set.Grow(-1)
+		}()
+	})
+}
+
+func TestToMapCopy(t *testing.T) {
+	hashSet := set.HashSetOf(1, 2, 3)
+	dynamicSet := set.DynamicSetFromSlice(createRandomIntSlice(set.DefaultDynamicSetSizeThreshold))
+
+	for _, m := range []map[int]struct{}{hashSet.ToMapCopy(), dynamicSet.ToMapCopy()} {
+		m[-1] = struct{}{}
+	}
+
+	if hashSet.Contains(-1) {
+		t.Errorf("expected mutating map from ToMapCopy to not mutate %v", hashSet)
+	}
+	if dynamicSet.Contains(-1) {
+		t.Errorf("expected mutating map from ToMapCopy to not mutate %v", dynamicSet)
+	}
+}
+
+func TestHashSetCompact(t *testing.T) {
+	ints := createRandomIntSlice(1000)
+	hashSet := set.HashSetFromSlice(ints)
+
+	for _, i := range ints[10:] {
+		hashSet.Remove(i)
+	}
+
+	backingMap := hashSet.ToMap()
+	hashSet.Compact()
+
+	assertSize(t, hashSet, 10)
+	assertContains(t, hashSet, ints[:10]...)
+
+	hashSet.Add(-1)
+	if _, aliased := backingMap[-1]; aliased {
+		t.Errorf("expected Compact to replace backing map of %v", hashSet)
+	}
+}
+
+func TestHashSetBulkAddKeepsToMapAlias(t *testing.T) {
+	hashSet := set.HashSetOf(-1)
+	backingMap := hashSet.ToMap()
+
+	ints := createRandomIntSlice(1000)
+	hashSet.AddFromSlice(ints[:500])
+	hashSet.AddFromSet(set.HashSetFromSlice(ints[500:]))
+
+	if len(backingMap) != hashSet.Size() {
+		t.Errorf("expected map from ToMap to still alias %v after bulk adds", hashSet)
+	}
+}
+
+func TestArraySetClip(t *testing.T) {
+	ints := createRandomIntSlice(100)
+	arraySet := set.ArraySetFromSlice(ints)
+
+	for _, i := range ints[10:] {
+		arraySet.Remove(i)
+	}
+
+	arraySet.Clip()
+
+	assertSize(t, arraySet, 10)
+	assertContains(t, arraySet, ints[:10]...)
+
+	if capacity := cap(arraySet.AsSlice()); capacity != 10 {
+		t.Errorf("expected Clip to shrink capacity of %v to 10, got %d", arraySet, capacity)
+	}
+}
+
+func TestArraySetIndexedAccess(t *testing.T) {
+	arraySet := set.ArraySetOf(1, 2, 3)
+
+	for i := range arraySet.Size() {
+		element := arraySet.At(i)
+		if index := arraySet.IndexOf(element); index != i {
+			t.Errorf("expected IndexOf(%d) == %d in %v, got %d", element, i, arraySet, index)
+		}
+	}
+
+	if index := arraySet.IndexOf(4); index != -1 {
+		t.Errorf("expected IndexOf of missing element to be -1, got %d", index)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected At with out-of-range index to panic")
+		}
+	}()
+	arraySet.At(3)
+}
+
+func TestIntegerArraySetContains(t *testing.T) {
+	// Tests every position within and after the chunks compared by Contains.
+	for size := range 10 {
+		integerArraySet := set.NewIntegerArraySet[int64]()
+		for i := range size {
+			integerArraySet.Add(int64(i))
+		}
+
+		assertSize(t, &integerArraySet, size)
+		for i := range size {
+			assertContains(t, integerArraySet, int64(i))
+		}
+		if integerArraySet.Contains(int64(size)) {
+			t.Errorf("expected %v to not contain %d", integerArraySet, size)
+		}
+	}
+}
+
 func TestSize(t *testing.T) {
 	testAllSetTypes(func(set set.Set[int], setName string) {
 		set.AddMultiple(1, 2, 3)
@@ -256,6 +527,46 @@ func TestIntersection(t *testing.T) {
 	})
 }
 
+func TestOperationsWithConcreteOtherSets(t *testing.T) {
+	var largeDynamicSet set.DynamicSet[int]
+	for i := 0; i < set.DefaultDynamicSetSizeThreshold; i++ {
+		largeDynamicSet.Add(100 + i)
+	}
+	largeDynamicSet.AddMultiple(2, 3, 4)
+
+	hashSet := set.HashSetOf(2, 3, 4)
+	otherSets := []set.ComparableSet[int]{
+		hashSet,
+		&hashSet,
+		set.ArraySetOf(2, 3, 4),
+		set.DynamicSetOf(2, 3, 4),
+		largeDynamicSet,
+		set.SliceSet[int]{2, 3, 4},
+	}
+
+	for _, receiver := range []set.ComparableSet[int]{
+		set.HashSetOf(1, 2, 3),
+		set.ArraySetOf(1, 2, 3),
+	} {
+		for _, otherSet := range otherSets {
+			union := receiver.Union(otherSet)
+			assertSize(t, union, otherSet.Size()+1)
+			assertContains(t, union, 1, 2, 3, 4)
+
+			intersection := receiver.Intersection(otherSet)
+			assertSize(t, intersection, 2)
+			assertContains(t, intersection, 2, 3)
+
+			if receiver.IsSubsetOf(otherSet) {
+				t.Errorf("expected %v.IsSubsetOf(%v) == false", receiver, otherSet)
+			}
+			if !intersection.IsSubsetOf(otherSet) {
+				t.Errorf("expected %v.IsSubsetOf(%v) == true", intersection, otherSet)
+			}
+		}
+	}
+}
+
 func TestToSlice(t *testing.T) {
 	testAllSetTypes(func(set set.Set[int], setName string) {
 		set.AddMultiple(1, 2, 3)
@@ -271,7 +582,7 @@ func TestToSlice(t *testing.T) {
 			)
 		}
 
-		set.All()(func(setElement int) bool {
+		for setElement := range set.All() {
 			containedInSlice := false
 
 			for _, sliceElement := range slice {
@@ -289,9 +600,49 @@ func TestToSlice(t *testing.T) {
 					setElement,
 				)
 			}
+		}
+	})
+}
 
-			return true
-		})
+func TestToSliceDoesNotAliasSet(t *testing.T) {
+	testAllSetTypes(func(set set.Set[int], setName string) {
+		set.AddMultiple(1, 2, 3)
+
+		slice := set.ToSlice()
+		for i := range slice {
+			slice[i] = 10 + i
+		}
+
+		assertContains(t, set, 1, 2, 3)
+	})
+}
+
+func TestAsSlice(t *testing.T) {
+	arraySet := set.ArraySetOf(1, 2, 3)
+	arraySet.AsSlice()[0] = 4
+
+	assertContains(t, arraySet, 4, 2, 3)
+}
+
+func TestAppendTo(t *testing.T) {
+	testAllSetTypes(func(set set.Set[int], setName string) {
+		set.AddMultiple(1, 2, 3)
+		buffer := make([]int, 1, 10)
+		buffer[0] = 0
+
+		result := set.AppendTo(buffer)
+
+		if len(result) != 4 || result[0] != 0 {
+			t.Errorf("expected %v.AppendTo([0]) to append 3 elements after 0, got %v", set, result)
+		}
+		if &result[0] != &buffer[0] {
+			t.Errorf("expected %v.AppendTo to reuse buffer with sufficient capacity", set)
+		}
+
+		slices.Sort(result)
+		if !slices.Equal(result, []int{0, 1, 2, 3}) {
+			t.Errorf("expected %v.AppendTo([0]) to contain [0 1 2 3], got %v", set, result)
+		}
 	})
 }
 
@@ -310,7 +661,7 @@ func TestToMap(t *testing.T) {
 			)
 		}
 
-		set.All()(func(element int) bool {
+		for element := range set.All() {
 			if _, containedInMap := m[element]; !containedInMap {
 				t.Errorf(
 					"expected %v to contain all elements of %v, but did not contain %v",
@@ -319,8 +670,7 @@ func TestToMap(t *testing.T) {
 					element,
 				)
 			}
-			return true
-		})
+		}
 	})
 }
 
@@ -369,6 +719,46 @@ func TestString(t *testing.T) {
 	})
 }
 
+func TestSortedString(t *testing.T) {
+	testAllSetTypes(func(set set.Set[int], setName string) {
+		set.AddMultiple(3, 1, 2)
+		assertSortedString(t, set, setName+"{1, 2, 3}")
+	})
+}
+
+func TestSortedStringEmptySet(t *testing.T) {
+	testAllSetTypes(func(set set.Set[int], setName string) {
+		assertSortedString(t, set, setName+"{}")
+	})
+}
+
+func TestSortedStringWrappedSet(t *testing.T) {
+	hashSet := set.HashSetOf(3, 1, 2)
+	assertSortedString(t, hashSet, "HashSet{1, 2, 3}")
+
+	tracked := set.Track[int](set.Normalize[int](&hashSet, func(element int) int { return element }))
+	assertSortedString(t, tracked, "TrackedNormalizedHashSet{1, 2, 3}")
+}
+
+func TestSortedStringFunc(t *testing.T) {
+	hashSet := set.HashSetOf(testStruct{2, "b"}, testStruct{1, "a"})
+
+	actual := set.SortedStringFunc(hashSet, func(a, b testStruct) int {
+		return cmp.Compare(a.i, b.i)
+	})
+	if expected := "HashSet{{1 a}, {2 b}}"; actual != expected {
+		t.Errorf("expected SortedStringFunc(%v) == %s, got %s", hashSet, expected, actual)
+	}
+}
+
+func assertSortedString(t *testing.T, intSet set.ComparableSet[int], expected string) {
+	t.Helper()
+
+	if actual := set.SortedString(intSet); actual != expected {
+		t.Errorf("expected SortedString(%v) == %s, got %s", intSet, expected, actual)
+	}
+}
+
 func TestStringEmptySet(t *testing.T) {
 	testAllSetTypes(func(set set.Set[int], setName string) {
 		expected := setName + "{}"
@@ -384,10 +774,9 @@ func TestIterator(t *testing.T) {
 		set.AddMultiple(1, 2, 3)
 		results := map[int]bool{}
 
-		set.All()(func(element int) bool {
+		for element := range set.All() {
 			results[element] = true
-			return true
-		})
+		}
 
 		if len(results) != 3 {
 			t.Errorf("expected iteration result map to have length 3, got %d", len(results))
@@ -403,6 +792,22 @@ func TestIterator(t *testing.T) {
 	})
 }
 
+func TestIteratorBreak(t *testing.T) {
+	testAllSetTypes(func(set set.Set[int], setName string) {
+		set.AddMultiple(1, 2, 3)
+
+		iterations := 0
+		for range set.All() {
+			iterations++
+			break
+		}
+
+		if iterations != 1 {
+			t.Errorf("expected iteration over %v to stop after break, got %d iterations", set, iterations)
+		}
+	})
+}
+
 func TestDynamicSetTransformation(t *testing.T) {
 	var set set.DynamicSet[int]
 	if !set.IsArraySet() {
@@ -439,6 +844,201 @@ func TestDynamicSetTransformation(t *testing.T) {
 	}
 }
 
+func TestDynamicSetDowngradeThreshold(t *testing.T) {
+	var set set.DynamicSet[int]
+	set.SetDowngradeThreshold(5)
+
+	ints := createRandomIntSlice(set.SizeThreshold())
+	set.AddFromSlice(ints)
+	if !set.IsHashSet() {
+		t.Fatalf("expected %v to transform to HashSet after reaching size threshold", set)
+	}
+
+	for i := 0; set.Size() > 6; i++ {
+		set.Remove(ints[i])
+	}
+	if !set.IsHashSet() {
+		t.Errorf("expected %v to stay HashSet while above downgrade threshold", set)
+	}
+
+	set.Remove(ints[len(ints)-1])
+	if !set.IsArraySet() {
+		t.Errorf("expected %v to transform to ArraySet after reaching downgrade threshold", set)
+	}
+}
+
+func TestDynamicSetDowngradeDisabled(t *testing.T) {
+	var set set.DynamicSet[int]
+	set.SetDowngradeEnabled(false)
+
+	ints := createRandomIntSlice(set.SizeThreshold())
+	set.AddFromSlice(ints)
+
+	for _, i := range ints[1:] {
+		set.Remove(i)
+	}
+	if !set.IsHashSet() {
+		t.Errorf("expected %v to stay HashSet after removing elements with downgrade disabled", set)
+	}
+
+	set.Clear()
+	if !set.IsHashSet() {
+		t.Errorf("expected %v to stay HashSet after clearing with downgrade disabled", set)
+	}
+
+	set.Add(1)
+	set.SetDowngradeEnabled(true)
+	if !set.IsArraySet() {
+		t.Errorf("expected %v to transform to ArraySet after re-enabling downgrade", set)
+	}
+}
+
+func TestDynamicSetUnderlyingSets(t *testing.T) {
+	set := set.DynamicSetOf(1, 2, 3)
+
+	if set.UnderlyingHashSet() != nil {
+		t.Errorf("expected UnderlyingHashSet of %v to be nil while it is an ArraySet", set)
+	}
+
+	array := set.UnderlyingArraySet()
+	if array == nil {
+		t.Fatalf("expected UnderlyingArraySet of %v to be non-nil while it is an ArraySet", set)
+	}
+	array.Add(4)
+	assertContains(t, &set, 4)
+
+	set.AddFromSlice(createRandomIntSlice(set.SizeThreshold()))
+	if set.UnderlyingArraySet() != nil {
+		t.Errorf("expected UnderlyingArraySet of %v to be nil after transforming to HashSet", set)
+	}
+
+	hash := set.UnderlyingHashSet()
+	if hash == nil {
+		t.Fatalf("expected UnderlyingHashSet of %v to be non-nil after transforming to HashSet", set)
+	}
+	hash.Remove(4)
+	if set.Contains(4) {
+		t.Errorf("expected removal through UnderlyingHashSet to be reflected in %v", set)
+	}
+}
+
+func TestDynamicSetTransformationCallback(t *testing.T) {
+	var transformations []set.DynamicSetTransformation
+
+	var dynamicSet set.DynamicSet[int]
+	dynamicSet.SetOnTransform(func(transformation set.DynamicSetTransformation) {
+		transformations = append(transformations, transformation)
+	})
+
+	ints := createRandomIntSlice(dynamicSet.SizeThreshold())
+	dynamicSet.AddFromSlice(ints)
+	for _, i := range ints {
+		dynamicSet.Remove(i)
+	}
+
+	if count := dynamicSet.TransformationCount(); count != 2 {
+		t.Errorf("expected 2 transformations of %v, got %d", dynamicSet, count)
+	}
+
+	if len(transformations) != 2 {
+		t.Fatalf("expected callback to be called 2 times, got %d", len(transformations))
+	}
+	if !transformations[0].ToHashSet || transformations[0].Size != len(ints) {
+		t.Errorf("unexpected first transformation %+v", transformations[0])
+	}
+	if transformations[1].ToHashSet || transformations[1].Count != 2 {
+		t.Errorf("unexpected second transformation %+v", transformations[1])
+	}
+}
+
+func TestDynamicSetBatch(t *testing.T) {
+	var dynamicSet set.DynamicSet[int]
+	ints := createRandomIntSlice(dynamicSet.SizeThreshold() * 2)
+
+	dynamicSet.BeginBatch()
+	for range 3 {
+		dynamicSet.AddFromSlice(ints)
+		for _, i := range ints {
+			dynamicSet.Remove(i)
+		}
+		for _, i := range ints {
+			dynamicSet.Add(i)
+		}
+	}
+
+	if count := dynamicSet.TransformationCount(); count != 0 {
+		t.Errorf("expected no transformations during batch, got %d", count)
+	}
+	assertSize(t, &dynamicSet, len(ints))
+
+	dynamicSet.BeginBatch()
+	dynamicSet.EndBatch()
+	if !dynamicSet.IsArraySet() {
+		t.Errorf("expected %v to stay ArraySet until outermost batch ends", dynamicSet)
+	}
+
+	dynamicSet.EndBatch()
+	if !dynamicSet.IsHashSet() || dynamicSet.TransformationCount() != 1 {
+		t.Errorf("expected 1 transformation to HashSet after batch, got %v", dynamicSet)
+	}
+
+	dynamicSet.BeginBatch()
+	for _, i := range ints[1:] {
+		dynamicSet.Remove(i)
+	}
+	dynamicSet.EndBatch()
+	if !dynamicSet.IsArraySet() || dynamicSet.TransformationCount() != 2 {
+		t.Errorf("expected 1 transformation to ArraySet after batch, got %v", dynamicSet)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected EndBatch without BeginBatch to panic")
+		}
+	}()
+	dynamicSet.EndBatch()
+}
+
+func TestDynamicSetBulkAddWithDuplicates(t *testing.T) {
+	var transformations []set.DynamicSetTransformation
+
+	var dynamicSet set.DynamicSet[int]
+	dynamicSet.SetOnTransform(func(transformation set.DynamicSetTransformation) {
+		transformations = append(transformations, transformation)
+	})
+
+	duplicates := make([]int, dynamicSet.SizeThreshold()*2)
+	dynamicSet.AddFromSlice(duplicates)
+	dynamicSet.AddFromSlice(duplicates)
+
+	assertSize(t, &dynamicSet, 1)
+	if !dynamicSet.IsArraySet() {
+		t.Errorf("expected %v to be ArraySet after adding only duplicates", dynamicSet)
+	}
+	if count := dynamicSet.TransformationCount(); count != 0 {
+		t.Errorf("expected no transformations from adding duplicates, got %d", count)
+	}
+
+	ints := createRandomIntSlice(dynamicSet.SizeThreshold() * 2)
+	dynamicSet.AddFromSet(set.HashSetFromSlice(ints))
+	if !dynamicSet.IsHashSet() {
+		t.Errorf("expected %v to be HashSet after adding set above size threshold", dynamicSet)
+	}
+
+	if len(transformations) != 1 {
+		t.Fatalf("expected callback to be called 1 time, got %d", len(transformations))
+	}
+	if size := dynamicSet.Size(); transformations[0].Size != size {
+		t.Errorf("expected transformation to report size %d after add, got %+v", size, transformations[0])
+	}
+
+	dynamicSet.Clear()
+	dynamicSet.AddFromSlice(ints)
+	if last := transformations[len(transformations)-1]; !last.ToHashSet || last.Size != len(ints) {
+		t.Errorf("expected AddFromSlice to report size %d after add, got %+v", len(ints), transformations)
+	}
+}
+
 func TestDynamicSetConstructors(t *testing.T) {
 	for _, dynamicSet := range []struct {
 		set.DynamicSet[int]
@@ -462,6 +1062,15 @@ func TestDynamicSetConstructors(t *testing.T) {
 				dynamicSet.SizeThreshold(),
 			)
 		}
+
+		if dynamicSet.DowngradeThreshold() != set.DefaultDynamicSetSizeThreshold/2 {
+			t.Errorf(
+				"expected DynamicSet from %s to have downgrade threshold %d, got %d",
+				dynamicSet.name,
+				set.DefaultDynamicSetSizeThreshold/2,
+				dynamicSet.DowngradeThreshold(),
+			)
+		}
 	}
 }
 
@@ -469,6 +1078,39 @@ func testAllSetTypes(testFunc func(set set.Set[int], setName string)) {
 	testFunc(&set.ArraySet[int]{}, "ArraySet")
 	testFunc(&set.HashSet[int]{}, "HashSet")
 	testFunc(&set.DynamicSet[int]{}, "DynamicSet")
+
+	boundedSet := set.NewBoundedSet[int](100, set.EvictLRU)
+	testFunc(&boundedSet, "BoundedSet")
+
+	fixedSet := set.NewFixedSet(make([]int, 100))
+	testFunc(&fixedSet, "FixedSet")
+
+	testFunc(&set.CopyOnWriteSet[int]{}, "CopyOnWriteSet")
+	testFunc(&set.SparseSet[int]{}, "SparseSet")
+	testFunc(&set.BoolMapSet[int]{}, "BoolMapSet")
+	testFunc(&set.OpenHashSet[int]{}, "OpenHashSet")
+
+	testFunc(&set.IntegerArraySet[int]{}, "IntegerArraySet")
+
+	sortedArraySet := set.NewSortedArraySet[int]()
+	testFunc(&sortedArraySet, "SortedArraySet")
+
+	testFunc(set.Synchronize[int](&set.HashSet[int]{}), "SynchronizedHashSet")
+
+	segmentedHashSet := set.NewSegmentedHashSet[int]()
+	testFunc(&segmentedHashSet, "SegmentedHashSet")
+
+	observableSet := set.Observe[int](&set.ArraySet[int]{})
+	observableSet.OnAdd(func(int) {})
+	observableSet.OnRemove(func(int) {})
+	testFunc(observableSet, "ObservableArraySet")
+
+	acceptAll := func(int) error { return nil }
+	constrainedSet := set.Constrain[int](&set.HashSet[int]{}, acceptAll, set.InvalidElementPanic)
+	testFunc(constrainedSet, "ConstrainedHashSet")
+
+	identity := func(element int) int { return element }
+	testFunc(set.Normalize[int](&set.ArraySet[int]{}, identity), "NormalizedArraySet")
 }
 
 func assertSize[E comparable, Set set.ComparableSet[E]](t *testing.T, set Set, expectedSize int) {
@@ -493,3 +1135,72 @@ func assertContains[E comparable, Set set.ComparableSet[E]](
 		}
 	}
 }
+
+func TestAddStrictAndRemoveStrict(t *testing.T) {
+	arraySet := set.NewArraySet[int]()
+	hashSet := set.NewHashSet[int]()
+	dynamicSet := set.NewDynamicSet[int]()
+	innerHashSet := set.NewHashSet[int]()
+	synchronizedSet := set.Synchronize[int](&innerHashSet)
+
+	for _, strictSet := range []interface {
+		set.ComparableSet[int]
+		AddStrict(element int) error
+		RemoveStrict(element int) error
+	}{&arraySet, &hashSet, &dynamicSet, synchronizedSet} {
+		if err := strictSet.AddStrict(1); err != nil {
+			t.Fatalf("unexpected error from AddStrict on %v: %v", strictSet, err)
+		}
+		assertContains(t, strictSet, 1)
+
+		err := strictSet.AddStrict(1)
+		var existsErr set.ElementExistsError[int]
+		if !errors.Is(err, set.ErrElementExists) || !errors.As(err, &existsErr) ||
+			existsErr.Element != 1 {
+			t.Errorf("expected ElementExistsError for 1 from %v, got %v", strictSet, err)
+		}
+
+		if err := strictSet.RemoveStrict(1); err != nil {
+			t.Fatalf("unexpected error from RemoveStrict on %v: %v", strictSet, err)
+		}
+		assertSize(t, strictSet, 0)
+
+		err = strictSet.RemoveStrict(1)
+		var notFoundErr set.ElementNotFoundError[int]
+		if !errors.Is(err, set.ErrElementNotFound) || !errors.As(err, &notFoundErr) ||
+			notFoundErr.Element != 1 {
+			t.Errorf("expected ElementNotFoundError for 1 from %v, got %v", strictSet, err)
+		}
+	}
+}
+
+func TestTryAdd(t *testing.T) {
+	arraySet := set.NewArraySet[int]()
+	hashSet := set.NewHashSet[int]()
+	dynamicSet := set.NewDynamicSet[int]()
+	innerHashSet := set.NewHashSet[int]()
+	synchronizedSet := set.Synchronize[int](&innerHashSet)
+
+	for _, cappedSet := range []interface {
+		set.ComparableSet[int]
+		TryAdd(element int, maxSize int) error
+	}{&arraySet, &hashSet, &dynamicSet, synchronizedSet} {
+		for i := range 3 {
+			if err := cappedSet.TryAdd(i, 3); err != nil {
+				t.Fatalf("unexpected error from TryAdd on %v: %v", cappedSet, err)
+			}
+		}
+
+		err := cappedSet.TryAdd(3, 3)
+		var fullErr set.SetFullError[int]
+		if !errors.Is(err, set.ErrSetFull) || !errors.As(err, &fullErr) ||
+			fullErr.Element != 3 || fullErr.MaxSize != 3 {
+			t.Errorf("expected SetFullError for 3 from %v, got %v", cappedSet, err)
+		}
+
+		if err := cappedSet.TryAdd(1, 3); err != nil {
+			t.Errorf("expected TryAdd of present element on full set to succeed, got %v", err)
+		}
+		assertSize(t, cappedSet, 3)
+	}
+}
@@ -0,0 +1,131 @@
+// Package setassert provides test helpers for asserting on sets from the [hermannm.dev/set]
+// package. Failed assertions report the difference between the sets in a readable form, and mark
+// the test as failed with [testing.TB.Errorf], so the test continues after a failed assertion.
+package setassert
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+// AssertContains checks that the given set contains all of the given elements, and reports the
+// missing elements otherwise.
+func AssertContains[E comparable](t testing.TB, actual set.ComparableSet[E], elements ...E) {
+	t.Helper()
+
+	var missing []E
+	for _, element := range elements {
+		if !actual.Contains(element) && !slices.Contains(missing, element) {
+			missing = append(missing, element)
+		}
+	}
+
+	if len(missing) != 0 {
+		t.Errorf(
+			"expected %v to contain all of %v, but missing %s",
+			actual,
+			elements,
+			formatElements(missing),
+		)
+	}
+}
+
+// AssertEquals checks that the actual set contains exactly the same elements as the expected set.
+// Otherwise, it reports the elements that are missing from the actual set, and the extra elements
+// that are not in the expected set.
+func AssertEquals[E comparable](
+	t testing.TB,
+	expected set.ComparableSet[E],
+	actual set.ComparableSet[E],
+) {
+	t.Helper()
+
+	missing := difference(expected, actual)
+	extra := difference(actual, expected)
+
+	if len(missing) == 0 && len(extra) == 0 {
+		return
+	}
+
+	var message strings.Builder
+	message.WriteString("expected sets to be equal")
+	fmt.Fprintf(&message, "\n  expected: %v", expected)
+	fmt.Fprintf(&message, "\n  actual:   %v", actual)
+	if len(missing) != 0 {
+		fmt.Fprintf(&message, "\n  missing:  %s", formatElements(missing))
+	}
+	if len(extra) != 0 {
+		fmt.Fprintf(&message, "\n  extra:    %s", formatElements(extra))
+	}
+
+	t.Error(message.String())
+}
+
+// AssertSubset checks that all of the elements in the given subset exist in the given superset,
+// and reports the elements that do not otherwise.
+func AssertSubset[E comparable](
+	t testing.TB,
+	subset set.ComparableSet[E],
+	superset set.ComparableSet[E],
+) {
+	t.Helper()
+
+	if notInSuperset := difference(subset, superset); len(notInSuperset) != 0 {
+		t.Errorf(
+			"expected %v to be a subset of %v, but %s not in superset",
+			subset,
+			superset,
+			formatElements(notInSuperset),
+		)
+	}
+}
+
+// AssertDisjoint checks that the given sets have no elements in common, and reports the common
+// elements otherwise.
+func AssertDisjoint[E comparable](t testing.TB, a set.ComparableSet[E], b set.ComparableSet[E]) {
+	t.Helper()
+
+	var common []E
+	for element := range a.All() {
+		if b.Contains(element) {
+			common = append(common, element)
+		}
+	}
+
+	if len(common) != 0 {
+		t.Errorf(
+			"expected %v and %v to be disjoint, but both contain %s",
+			a,
+			b,
+			formatElements(common),
+		)
+	}
+}
+
+// difference returns the elements of a that are not in b.
+func difference[E comparable](a set.ComparableSet[E], b set.ComparableSet[E]) []E {
+	var elements []E
+	for element := range a.All() {
+		if !b.Contains(element) {
+			elements = append(elements, element)
+		}
+	}
+
+	return elements
+}
+
+// formatElements formats the given elements as a list, sorted by their string representation so
+// that the output is deterministic even though set iteration order is not.
+func formatElements[E comparable](elements []E) string {
+	formatted := make([]string, len(elements))
+	for i, element := range elements {
+		formatted[i] = fmt.Sprint(element)
+	}
+	slices.Sort(formatted)
+
+	return "[" + strings.Join(formatted, ", ") + "]"
+}
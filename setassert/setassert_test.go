@@ -0,0 +1,90 @@
+package setassert_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"hermannm.dev/set"
+	"hermannm.dev/set/setassert"
+)
+
+// recordingT records failures reported by assertions, so that we can test failing assertions
+// without failing the test itself.
+type recordingT struct {
+	testing.TB
+	errors []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Error(args ...any) {
+	t.errors = append(t.errors, fmt.Sprint(args...))
+}
+
+func (t *recordingT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestPassingAssertions(t *testing.T) {
+	recorder := &recordingT{TB: t}
+
+	setassert.AssertContains(recorder, set.HashSetOf(1, 2, 3), 1, 3)
+	setassert.AssertEquals(recorder, set.HashSetOf(1, 2), set.ArraySetOf(2, 1))
+	setassert.AssertSubset(recorder, set.ArraySetOf(1), set.HashSetOf(1, 2))
+	setassert.AssertDisjoint(recorder, set.ArraySetOf(1), set.HashSetOf(2))
+
+	if len(recorder.errors) != 0 {
+		t.Errorf("expected no failures, got %v", recorder.errors)
+	}
+}
+
+func TestFailingAssertions(t *testing.T) {
+	for _, test := range []struct {
+		name            string
+		assert          func(t testing.TB)
+		expectedMessage []string
+	}{
+		{
+			name: "AssertContains",
+			assert: func(t testing.TB) {
+				setassert.AssertContains(t, set.ArraySetOf(1), 1, 2, 3)
+			},
+			expectedMessage: []string{"missing [2, 3]"},
+		},
+		{
+			name: "AssertEquals",
+			assert: func(t testing.TB) {
+				setassert.AssertEquals(t, set.ArraySetOf(1, 2, 3), set.ArraySetOf(2, 4))
+			},
+			expectedMessage: []string{"missing:  [1, 3]", "extra:    [4]"},
+		},
+		{
+			name: "AssertSubset",
+			assert: func(t testing.TB) {
+				setassert.AssertSubset(t, set.ArraySetOf(1, 5), set.ArraySetOf(1, 2))
+			},
+			expectedMessage: []string{"[5] not in superset"},
+		},
+		{
+			name: "AssertDisjoint",
+			assert: func(t testing.TB) {
+				setassert.AssertDisjoint(t, set.ArraySetOf(1, 2), set.ArraySetOf(2, 3))
+			},
+			expectedMessage: []string{"both contain [2]"},
+		},
+	} {
+		recorder := &recordingT{TB: t}
+		test.assert(recorder)
+
+		if len(recorder.errors) != 1 {
+			t.Errorf("%s: expected 1 failure, got %v", test.name, recorder.errors)
+			continue
+		}
+		for _, expected := range test.expectedMessage {
+			if !strings.Contains(recorder.errors[0], expected) {
+				t.Errorf("%s: expected failure to contain %q, got %q", test.name, expected, recorder.errors[0])
+			}
+		}
+	}
+}
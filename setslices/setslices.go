@@ -0,0 +1,214 @@
+// Package setslices provides set operations directly on slices and maps, for one-shot set semantics
+// over data that is already held in a slice or map, without constructing values from the
+// [hermannm.dev/set] package.
+//
+// Functions on slices treat each slice as a set of its unique elements, and return slices with
+// elements in order of first appearance. Functions on maps take maps with empty struct values
+// (map[E]struct{}), the same representation as [hermannm.dev/set.HashSet.ToMap], and never modify
+// their inputs.
+package setslices
+
+// smallSliceThreshold is the slice length below which functions scan slices linearly instead of
+// building a temporary map.
+const smallSliceThreshold = 16
+
+// Unique returns a new slice with the unique elements of the given slice, in order of first
+// appearance.
+func Unique[E comparable](slice []E) []E {
+	unique := make([]E, 0, len(slice))
+
+	if len(slice) <= smallSliceThreshold {
+		for _, element := range slice {
+			if !contains(unique, element) {
+				unique = append(unique, element)
+			}
+		}
+
+		return unique
+	}
+
+	seen := make(map[E]struct{}, len(slice))
+	for _, element := range slice {
+		if _, alreadySeen := seen[element]; !alreadySeen {
+			seen[element] = struct{}{}
+			unique = append(unique, element)
+		}
+	}
+
+	return unique
+}
+
+// Dedup removes duplicate elements from the given slice in place, keeping the first appearance of
+// each element, and returns the shortened slice, like [slices.Compact] does for consecutive
+// duplicates. Unlike [Unique], it does not allocate a new slice, so it is the cheaper choice when
+// the original slice is no longer needed. The elements between the new length and the original
+// length are zeroed, so they can be garbage collected.
+//
+// Like Unique, short slices are deduplicated by scanning the elements kept so far, which allocates
+// nothing. Longer slices track seen elements in a map sized for the whole slice, with one map
+// operation per element.
+func Dedup[E comparable](slice []E) []E {
+	unique := slice[:0]
+
+	if len(slice) <= smallSliceThreshold {
+		for _, element := range slice {
+			if !contains(unique, element) {
+				unique = append(unique, element)
+			}
+		}
+	} else {
+		seen := make(map[E]struct{}, len(slice))
+		for _, element := range slice {
+			// Checks if the element was already seen by whether adding it grew the map, to avoid
+			// a separate lookup.
+			sizeBefore := len(seen)
+			seen[element] = struct{}{}
+			if len(seen) != sizeBefore {
+				unique = append(unique, element)
+			}
+		}
+	}
+
+	clear(slice[len(unique):])
+	return unique
+}
+
+// Union returns a new slice with the unique elements of both of the given slices: first the
+// elements of a, then the elements of b that are not in a.
+func Union[E comparable](a []E, b []E) []E {
+	union := make([]E, 0, len(a)+len(b))
+	union = append(union, a...)
+	union = append(union, b...)
+	return Unique(union)
+}
+
+// Intersect returns a new slice with the unique elements that exist in both of the given slices,
+// in their order in a.
+func Intersect[E comparable](a []E, b []E) []E {
+	inB := containsFunc(b)
+
+	var intersection []E
+	for _, element := range Unique(a) {
+		if inB(element) {
+			intersection = append(intersection, element)
+		}
+	}
+
+	return intersection
+}
+
+// Difference returns a new slice with the unique elements of a that do not exist in b, in their
+// order in a.
+func Difference[E comparable](a []E, b []E) []E {
+	inB := containsFunc(b)
+
+	var difference []E
+	for _, element := range Unique(a) {
+		if !inB(element) {
+			difference = append(difference, element)
+		}
+	}
+
+	return difference
+}
+
+// Disjoint checks if the given slices have no elements in common.
+func Disjoint[E comparable](a []E, b []E) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+
+	inB := containsFunc(b)
+	for _, element := range a {
+		if inB(element) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// UnionMap returns a new map with the keys of both of the given maps.
+func UnionMap[E comparable](a map[E]struct{}, b map[E]struct{}) map[E]struct{} {
+	union := make(map[E]struct{}, max(len(a), len(b)))
+
+	for element := range a {
+		union[element] = struct{}{}
+	}
+	for element := range b {
+		union[element] = struct{}{}
+	}
+
+	return union
+}
+
+// IntersectMap returns a new map with the keys that exist in both of the given maps.
+func IntersectMap[E comparable](a map[E]struct{}, b map[E]struct{}) map[E]struct{} {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+
+	intersection := make(map[E]struct{}, len(a))
+	for element := range a {
+		if _, inB := b[element]; inB {
+			intersection[element] = struct{}{}
+		}
+	}
+
+	return intersection
+}
+
+// DifferenceMap returns a new map with the keys of a that do not exist in b.
+func DifferenceMap[E comparable](a map[E]struct{}, b map[E]struct{}) map[E]struct{} {
+	difference := make(map[E]struct{}, len(a))
+	for element := range a {
+		if _, inB := b[element]; !inB {
+			difference[element] = struct{}{}
+		}
+	}
+
+	return difference
+}
+
+// DisjointMap checks if the given maps have no keys in common.
+func DisjointMap[E comparable](a map[E]struct{}, b map[E]struct{}) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+
+	for element := range a {
+		if _, inB := b[element]; inB {
+			return false
+		}
+	}
+
+	return true
+}
+
+// containsFunc returns a function that checks if an element is in the given slice, which scans the
+// slice if it is small, and otherwise builds a map of its elements.
+func containsFunc[E comparable](slice []E) func(E) bool {
+	if len(slice) <= smallSliceThreshold {
+		return func(element E) bool { return contains(slice, element) }
+	}
+
+	elements := make(map[E]struct{}, len(slice))
+	for _, element := range slice {
+		elements[element] = struct{}{}
+	}
+
+	return func(element E) bool {
+		_, contains := elements[element]
+		return contains
+	}
+}
+
+func contains[E comparable](slice []E, element E) bool {
+	for _, candidate := range slice {
+		if candidate == element {
+			return true
+		}
+	}
+
+	return false
+}
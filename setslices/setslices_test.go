@@ -0,0 +1,126 @@
+package setslices_test
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"hermannm.dev/set/setslices"
+)
+
+// largeSlice returns a slice of the integers in [start, end), repeated twice, to test the map-based
+// paths used for large slices.
+func largeSlice(start int, end int) []int {
+	var slice []int
+	for range 2 {
+		for i := start; i < end; i++ {
+			slice = append(slice, i)
+		}
+	}
+	return slice
+}
+
+func TestUnique(t *testing.T) {
+	if unique := setslices.Unique([]int{3, 1, 3, 2, 1}); !slices.Equal(unique, []int{3, 1, 2}) {
+		t.Errorf("expected unique elements [3 1 2], got %v", unique)
+	}
+
+	if unique := setslices.Unique(largeSlice(0, 50)); !slices.Equal(unique, largeSlice(0, 50)[:50]) {
+		t.Errorf("expected unique elements of large slice to be 0-49 in order, got %v", unique)
+	}
+}
+
+func TestDedup(t *testing.T) {
+	slice := []int{3, 1, 3, 2, 1}
+	if deduped := setslices.Dedup(slice); !slices.Equal(deduped, []int{3, 1, 2}) {
+		t.Errorf("expected deduplicated elements [3 1 2], got %v", deduped)
+	}
+	if !slices.Equal(slice, []int{3, 1, 2, 0, 0}) {
+		t.Errorf("expected removed elements to be zeroed, got %v", slice)
+	}
+
+	if deduped := setslices.Dedup(largeSlice(0, 50)); !slices.Equal(deduped, largeSlice(0, 50)[:50]) {
+		t.Errorf("expected deduplicated large slice to be 0-49 in order, got %v", deduped)
+	}
+
+	if deduped := setslices.Dedup[int](nil); len(deduped) != 0 {
+		t.Errorf("expected deduplicated nil slice to be empty, got %v", deduped)
+	}
+
+	small := []int{1, 2, 1, 3}
+	if allocs := testing.AllocsPerRun(10, func() { setslices.Dedup(small) }); allocs != 0 {
+		t.Errorf("expected deduplicating short slice to not allocate, got %v allocations", allocs)
+	}
+}
+
+func TestSliceOperations(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		a, b []int
+	}{
+		{name: "small", a: []int{1, 2, 3, 3}, b: []int{4, 3, 2}},
+		{name: "large", a: largeSlice(1, 30), b: largeSlice(2, 40)},
+	} {
+		union := setslices.Union(test.a, test.b)
+		if len(union) != len(setslices.Unique(union)) {
+			t.Errorf("%s: expected union %v to have no duplicates", test.name, union)
+		}
+		for _, element := range append(test.a, test.b...) {
+			if !slices.Contains(union, element) {
+				t.Errorf("%s: expected union %v to contain %d", test.name, union, element)
+			}
+		}
+
+		intersection := setslices.Intersect(test.a, test.b)
+		for _, element := range setslices.Unique(test.a) {
+			inBoth := slices.Contains(test.b, element)
+			if slices.Contains(intersection, element) != inBoth {
+				t.Errorf(
+					"%s: unexpected presence of %d in intersection %v",
+					test.name,
+					element,
+					intersection,
+				)
+			}
+		}
+
+		difference := setslices.Difference(test.a, test.b)
+		if !slices.Equal(difference, []int{1}) {
+			t.Errorf("%s: expected difference [1], got %v", test.name, difference)
+		}
+
+		if setslices.Disjoint(test.a, test.b) {
+			t.Errorf("%s: expected slices to not be disjoint", test.name)
+		}
+		if !setslices.Disjoint(test.a, []int{-1, -2}) {
+			t.Errorf("%s: expected slices to be disjoint", test.name)
+		}
+	}
+}
+
+func TestMapOperations(t *testing.T) {
+	a := map[int]struct{}{1: {}, 2: {}, 3: {}}
+	b := map[int]struct{}{3: {}, 4: {}}
+
+	union := setslices.UnionMap(a, b)
+	if keys := slices.Sorted(maps.Keys(union)); !slices.Equal(keys, []int{1, 2, 3, 4}) {
+		t.Errorf("expected union with keys [1 2 3 4], got %v", union)
+	}
+
+	intersection := setslices.IntersectMap(a, b)
+	if !maps.Equal(intersection, map[int]struct{}{3: {}}) {
+		t.Errorf("expected intersection with key 3, got %v", intersection)
+	}
+
+	difference := setslices.DifferenceMap(a, b)
+	if !maps.Equal(difference, map[int]struct{}{1: {}, 2: {}}) {
+		t.Errorf("expected difference with keys 1 and 2, got %v", difference)
+	}
+
+	if setslices.DisjointMap(a, b) {
+		t.Errorf("expected %v and %v to not be disjoint", a, b)
+	}
+	if !setslices.DisjointMap(a, map[int]struct{}{5: {}}) {
+		t.Errorf("expected maps to be disjoint")
+	}
+}
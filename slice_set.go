@@ -0,0 +1,153 @@
+package set
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// A SliceSet is a read-only view of a slice as a set, for passing data that is already held in a
+// slice to APIs that take a [ComparableSet], without the allocation of [ArraySetFromSlice]. A slice
+// is turned into a SliceSet by simple type conversion:
+//
+//	ids := []int{1, 2, 3}
+//	set.SliceSet[int](ids)
+//
+// SliceSet assumes that the elements in the slice are unique, and does not deduplicate them. If
+// the slice contains duplicates, Size and Equals give incorrect results - use ArraySetFromSlice
+// instead in that case.
+//
+// Like [ArraySet], Contains is O(n), so SliceSet is only suitable for small sets.
+//
+// SliceSet implements [ComparableSet], but not [Set], since it is read-only.
+type SliceSet[E comparable] []E
+
+// Contains checks if given element is present in the slice.
+func (set SliceSet[E]) Contains(element E) bool {
+	for _, candidate := range set {
+		if element == candidate {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Size returns the number of elements in the slice.
+func (set SliceSet[E]) Size() int {
+	return len(set)
+}
+
+// IsEmpty checks if there are 0 elements in the slice.
+func (set SliceSet[E]) IsEmpty() bool {
+	return len(set) == 0
+}
+
+// Equals checks if the slice contains exactly the same elements as the other given set.
+func (set SliceSet[E]) Equals(otherSet ComparableSet[E]) bool {
+	return set.Size() == otherSet.Size() && set.IsSubsetOf(otherSet)
+}
+
+// IsSubsetOf checks if all of the elements in the slice exist in the other given set.
+func (set SliceSet[E]) IsSubsetOf(otherSet ComparableSet[E]) bool {
+	for _, element := range set {
+		if !otherSet.Contains(element) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsSupersetOf checks if the slice contains all of the elements in the other given set.
+func (set SliceSet[E]) IsSupersetOf(otherSet ComparableSet[E]) bool {
+	return otherSet.IsSubsetOf(set)
+}
+
+// Union creates a new set that contains all the elements of the slice and the other given set.
+// Since SliceSet is read-only, the underlying type of the returned set is an *ArraySet.
+func (set SliceSet[E]) Union(otherSet ComparableSet[E]) Set[E] {
+	union := ArraySetFromSlice(set)
+	union.AddFromSet(otherSet)
+	return &union
+}
+
+// Intersection creates a new set with only the elements that exist in both the slice and the
+// other given set. Since SliceSet is read-only, the underlying type of the returned set is an
+// *ArraySet.
+func (set SliceSet[E]) Intersection(otherSet ComparableSet[E]) Set[E] {
+	intersection := ArraySetWithCapacity[E](len(set))
+	for _, element := range set {
+		if otherSet.Contains(element) {
+			intersection.elements = append(intersection.elements, element)
+		}
+	}
+
+	return &intersection
+}
+
+// ToSlice returns the underlying slice.
+func (set SliceSet[E]) ToSlice() []E {
+	return set
+}
+
+// AppendTo appends the elements in the slice to the given other slice, and returns the extended
+// slice, like the append builtin.
+func (set SliceSet[E]) AppendTo(slice []E) []E {
+	return append(slice, set...)
+}
+
+// ToMap creates a map with all the slice's elements as keys.
+func (set SliceSet[E]) ToMap() map[E]struct{} {
+	m := make(map[E]struct{}, len(set))
+
+	for _, element := range set {
+		m[element] = struct{}{}
+	}
+
+	return m
+}
+
+// Copy creates a new set with all the same elements as the slice. Since SliceSet is read-only,
+// the underlying type of the returned set is an *ArraySet.
+func (set SliceSet[E]) Copy() Set[E] {
+	newSet := ArraySet[E]{elements: make([]E, len(set))}
+	copy(newSet.elements, set)
+	return &newSet
+}
+
+// String returns a string representation of the set, implementing [fmt.Stringer].
+//
+// A SliceSet of elements 1, 2 and 3 will be printed as: SliceSet{1, 2, 3}
+func (set SliceSet[E]) String() string {
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString("SliceSet{")
+
+	for i, element := range set {
+		fmt.Fprint(&stringBuilder, element)
+
+		if i < len(set)-1 {
+			stringBuilder.WriteString(", ")
+		}
+	}
+
+	stringBuilder.WriteByte('}')
+	return stringBuilder.String()
+}
+
+// All returns an iterator over the elements in the slice, which can be used in a for-range loop:
+//
+//	for element := range set.All() {
+//		fmt.Println(element)
+//	}
+//
+// Elements are iterated in the order of the slice.
+func (set SliceSet[E]) All() iter.Seq[E] {
+	return func(yield func(element E) bool) {
+		for _, element := range set {
+			if !yield(element) {
+				break
+			}
+		}
+	}
+}
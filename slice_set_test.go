@@ -0,0 +1,33 @@
+package set_test
+
+import (
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestSliceSet(t *testing.T) {
+	slice := []int{1, 2, 3}
+	sliceSet := set.SliceSet[int](slice)
+
+	assertSize(t, sliceSet, 3)
+	assertContains(t, sliceSet, 1, 2, 3)
+
+	if !sliceSet.Equals(set.HashSetOf(3, 2, 1)) {
+		t.Errorf("expected %v to equal set with same elements", sliceSet)
+	}
+
+	union := sliceSet.Union(set.ArraySetOf(3, 4))
+	assertSize(t, union, 4)
+	assertContains(t, union, 1, 2, 3, 4)
+
+	intersection := sliceSet.Intersection(set.ArraySetOf(3, 4))
+	assertSize(t, intersection, 1)
+	assertContains(t, intersection, 3)
+
+	copied := sliceSet.Copy()
+	copied.Add(4)
+	if len(slice) != 3 {
+		t.Errorf("expected adding to copy of SliceSet to not modify original slice %v", slice)
+	}
+}
@@ -0,0 +1,122 @@
+package set
+
+// A Snapshot is an immutable record of the elements in a set at the time it was taken, for rolling
+// the set back later. It is returned by the Snapshot method on [ArraySet], [HashSet], [DynamicSet]
+// and [CopyOnWriteSet], and passed to their Restore method. This is useful for speculative
+// execution: take a snapshot, try a batch of changes, and restore the snapshot if they fail.
+//
+// Compared to keeping a Copy of the set, taking a snapshot is cheaper, and restoring it reuses the
+// set's existing storage. For an ArraySet or HashSet, a snapshot is a flat slice of the elements,
+// which is cheaper to allocate than a copy of a map. For a CopyOnWriteSet, whose versions are
+// already immutable, a snapshot shares the current version, and costs no copying at all.
+//
+// A snapshot may be restored any number of times, and to a set of a different type than the one it
+// was taken from. The zero value for a Snapshot is the snapshot of an empty set.
+type Snapshot[E comparable] struct {
+	// elements are the unique elements in the snapshot, unless version is set.
+	elements []E
+	// version is the immutable version of a CopyOnWriteSet that the snapshot was taken from.
+	version *HashSet[E]
+}
+
+// Size returns the number of elements in the snapshot.
+func (snapshot Snapshot[E]) Size() int {
+	if snapshot.version != nil {
+		return snapshot.version.Size()
+	}
+	return len(snapshot.elements)
+}
+
+// appendTo appends the elements in the snapshot to the given slice.
+func (snapshot Snapshot[E]) appendTo(slice []E) []E {
+	if snapshot.version != nil {
+		return snapshot.version.AppendTo(slice)
+	}
+	return append(slice, snapshot.elements...)
+}
+
+// Snapshot returns an immutable [Snapshot] of the elements currently in the set, which can later
+// be passed to [ArraySet.Restore] to roll the set back.
+func (set ArraySet[E]) Snapshot() Snapshot[E] {
+	return Snapshot[E]{elements: set.ToSlice(), version: nil}
+}
+
+// Restore replaces the elements in the set with the elements in the given [Snapshot], reusing the
+// set's backing array if it has room.
+func (set *ArraySet[E]) Restore(snapshot Snapshot[E]) {
+	clear(set.elements)
+	set.elements = snapshot.appendTo(set.elements[:0])
+}
+
+// Snapshot returns an immutable [Snapshot] of the elements currently in the set, which can later
+// be passed to [HashSet.Restore] to roll the set back.
+func (set HashSet[E]) Snapshot() Snapshot[E] {
+	return Snapshot[E]{elements: set.ToSlice(), version: nil}
+}
+
+// Restore replaces the elements in the set with the elements in the given [Snapshot]. Since
+// clearing a Go map keeps its memory, this reuses the set's backing map.
+//
+// After Restore, maps previously returned by ToMap no longer reflect the set's elements.
+func (set *HashSet[E]) Restore(snapshot Snapshot[E]) {
+	if set.elements == nil {
+		set.elements = make(map[E]struct{}, snapshot.Size())
+	} else {
+		clear(set.elements)
+	}
+
+	if snapshot.version != nil {
+		for element := range snapshot.version.elements {
+			set.elements[element] = struct{}{}
+		}
+	} else {
+		for _, element := range snapshot.elements {
+			set.elements[element] = struct{}{}
+		}
+	}
+}
+
+// Snapshot returns an immutable [Snapshot] of the elements currently in the set, which can later
+// be passed to [DynamicSet.Restore] to roll the set back.
+func (set DynamicSet[E]) Snapshot() Snapshot[E] {
+	return Snapshot[E]{elements: set.ToSlice(), version: nil}
+}
+
+// Restore replaces the elements in the set with the elements in the given [Snapshot]. The set is
+// cleared as described on [DynamicSet.Clear], and then transforms to a HashSet if the snapshot has
+// reached the size threshold.
+func (set *DynamicSet[E]) Restore(snapshot Snapshot[E]) {
+	set.Clear()
+
+	if snapshot.version != nil {
+		set.AddFromSet(snapshot.version)
+	} else {
+		set.AddFromSlice(snapshot.elements)
+	}
+}
+
+// Snapshot returns an immutable [Snapshot] of the current version of the set, which can later be
+// passed to [CopyOnWriteSet.Restore] to roll the set back. Since versions of a CopyOnWriteSet are
+// immutable, this does not copy the set.
+func (set *CopyOnWriteSet[E]) Snapshot() Snapshot[E] {
+	return Snapshot[E]{elements: nil, version: set.snapshot.Load()}
+}
+
+// Restore atomically replaces the current version of the set with the elements in the given
+// [Snapshot]. If the snapshot was taken from a CopyOnWriteSet, its version is swapped back in
+// without copying. Readers that are currently using the previous version are unaffected.
+func (set *CopyOnWriteSet[E]) Restore(snapshot Snapshot[E]) {
+	set.writerLock.Lock()
+	defer set.writerLock.Unlock()
+
+	if snapshot.version != nil {
+		set.snapshot.Store(snapshot.version)
+		return
+	}
+
+	version := HashSetWithCapacity[E](len(snapshot.elements))
+	for _, element := range snapshot.elements {
+		version.elements[element] = struct{}{}
+	}
+	set.snapshot.Store(&version)
+}
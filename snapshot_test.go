@@ -0,0 +1,56 @@
+package set_test
+
+import (
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+type snapshotSet interface {
+	set.Set[int]
+	Snapshot() set.Snapshot[int]
+	Restore(snapshot set.Snapshot[int])
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	arraySet := set.NewArraySet[int]()
+	hashSet := set.NewHashSet[int]()
+	dynamicSet := set.NewDynamicSet[int]()
+
+	sets := map[string]snapshotSet{
+		"ArraySet":       &arraySet,
+		"HashSet":        &hashSet,
+		"DynamicSet":     &dynamicSet,
+		"CopyOnWriteSet": set.NewCopyOnWriteSet[int](),
+	}
+
+	for name, snapshotSet := range sets {
+		t.Run(name, func(t *testing.T) {
+			snapshotSet.AddMultiple(1, 2, 3)
+			snapshot := snapshotSet.Snapshot()
+
+			snapshotSet.Remove(1)
+			snapshotSet.AddFromSlice(createRandomIntSlice(50))
+
+			snapshotSet.Restore(snapshot)
+			assertSize(t, snapshotSet, 3)
+			assertContains(t, snapshotSet, 1, 2, 3)
+
+			// Restoring must not have let the snapshot alias the set.
+			snapshotSet.Add(4)
+			snapshotSet.Restore(snapshot)
+			assertSize(t, snapshotSet, 3)
+
+			for otherName, otherSet := range sets {
+				otherSet.Restore(snapshot)
+				if !otherSet.Equals(snapshotSet) {
+					t.Errorf("expected %s restored from %s snapshot to equal %v, got %v",
+						otherName, name, snapshotSet, otherSet)
+				}
+			}
+
+			snapshotSet.Restore(set.Snapshot[int]{})
+			assertSize(t, snapshotSet, 0)
+		})
+	}
+}
@@ -0,0 +1,636 @@
+package set
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// A SortedArraySet is a collection of unique elements of type E, using a sorted array as its
+// backing storage. Like [ArraySet], it has low memory overhead and fast iteration, but Contains
+// uses binary search, so it is O(log n) instead of O(n). This makes it practical for much larger
+// sets than ArraySet, in particular for sets that are built once and then mostly read. Adding and
+// removing elements is O(n), since elements after the affected position must be moved, but
+// AddFromSlice and AddFromSet sort the new elements in bulk.
+//
+// Elements are kept in the order given by the set's compare function, so iteration order is
+// deterministic. Operations between two SortedArraySets with the same order, such as Union,
+// Intersection, DifferenceSortedArraySet and IsSubsetOf, use merge joins, which are O(n + m).
+//
+// A SortedArraySet must be created with one of the constructors in this package, such as
+// [NewSortedArraySet] for ordered element types, or [NewSortedArraySetFunc] for a custom order. It
+// must not be copied after first use.
+//
+// SortedArraySet implements [Set] when passed by pointer, and [ComparableSet] when passed by value.
+type SortedArraySet[E comparable] struct {
+	elements []E
+	compare  func(a, b E) int
+}
+
+// NewSortedArraySet creates a new [SortedArraySet] for elements of type E, sorted in ascending
+// order.
+// It must not be copied after first use.
+func NewSortedArraySet[E cmp.Ordered]() SortedArraySet[E] {
+	return SortedArraySet[E]{elements: nil, compare: cmp.Compare[E]}
+}
+
+// SortedArraySetWithCapacity creates a new [SortedArraySet], sorted in ascending order, with at
+// least the given initial capacity.
+// It must not be copied after first use.
+func SortedArraySetWithCapacity[E cmp.Ordered](capacity int) SortedArraySet[E] {
+	return SortedArraySet[E]{elements: make([]E, 0, capacity), compare: cmp.Compare[E]}
+}
+
+// SortedArraySetOf creates a new [SortedArraySet] from the given elements, sorted in ascending
+// order.
+// It must not be copied after first use.
+// Duplicate elements are added only once.
+func SortedArraySetOf[E cmp.Ordered](elements ...E) SortedArraySet[E] {
+	return SortedArraySetFromSlice(elements)
+}
+
+// SortedArraySetFromSlice creates a new [SortedArraySet] from the elements in the given slice,
+// sorted in ascending order.
+// It must not be copied after first use.
+// Duplicate elements in the slice are added only once.
+func SortedArraySetFromSlice[E cmp.Ordered](elements []E) SortedArraySet[E] {
+	return SortedArraySetFromSliceFunc(elements, cmp.Compare[E])
+}
+
+// NewSortedArraySetFunc creates a new [SortedArraySet] for elements of type E, sorted by the given
+// compare function. The compare function must return a negative number when a < b, a positive
+// number when a > b, and 0 when a == b. It must only return 0 for elements that are equal by ==,
+// since the set uses it to check if elements are present.
+// It must not be copied after first use.
+func NewSortedArraySetFunc[E comparable](compare func(a, b E) int) SortedArraySet[E] {
+	return SortedArraySet[E]{elements: nil, compare: compare}
+}
+
+// SortedArraySetFromSliceFunc creates a new [SortedArraySet] from the elements in the given slice,
+// sorted by the given compare function (see [NewSortedArraySetFunc] for its requirements).
+// It must not be copied after first use.
+// Duplicate elements in the slice are added only once.
+func SortedArraySetFromSliceFunc[E comparable](
+	elements []E,
+	compare func(a, b E) int,
+) SortedArraySet[E] {
+	set := SortedArraySet[E]{elements: slices.Clone(elements), compare: compare}
+	set.sortAndDeduplicate()
+	return set
+}
+
+// Add adds the given element to the set, at its sorted position.
+// If the element is already present in the set, Add is a no-op.
+func (set *SortedArraySet[E]) Add(element E) {
+	index, found := slices.BinarySearchFunc(set.elements, element, set.compare)
+	if !found {
+		set.elements = slices.Insert(set.elements, index, element)
+	}
+}
+
+// AddMultiple adds the given elements to the set. Duplicate elements are added only once, and
+// elements already present in the set are not added.
+func (set *SortedArraySet[E]) AddMultiple(elements ...E) {
+	set.AddFromSlice(elements)
+}
+
+// AddFromSlice adds the elements from the given slice to the set. Duplicate elements are added only
+// once, and elements already present in the set are not added.
+//
+// Instead of inserting elements one by one, which would be O(n) each, the elements are appended
+// and then sorted together with the existing elements.
+func (set *SortedArraySet[E]) AddFromSlice(elements []E) {
+	if len(elements) == 0 {
+		return
+	}
+
+	set.elements = append(set.elements, elements...)
+	set.sortAndDeduplicate()
+}
+
+// AddFromSet adds elements from the given other set to the set. Like AddFromSlice, the elements
+// are sorted in bulk.
+func (set *SortedArraySet[E]) AddFromSet(otherSet ComparableSet[E]) {
+	if otherSet.IsEmpty() {
+		return
+	}
+
+	set.elements = otherSet.AppendTo(set.elements)
+	set.sortAndDeduplicate()
+}
+
+// AddFromSeq adds the elements from the given iterator to the set, without first collecting them
+// into a separate slice. Like AddFromSlice, the elements are sorted in bulk.
+func (set *SortedArraySet[E]) AddFromSeq(seq iter.Seq[E]) {
+	previousSize := len(set.elements)
+	set.elements = slices.AppendSeq(set.elements, seq)
+
+	if len(set.elements) != previousSize {
+		set.sortAndDeduplicate()
+	}
+}
+
+// Remove removes the given element from the set.
+// If the element is not present in the set, Remove is a no-op.
+func (set *SortedArraySet[E]) Remove(element E) {
+	index, found := slices.BinarySearchFunc(set.elements, element, set.compare)
+	if found {
+		set.elements = slices.Delete(set.elements, index, index+1)
+	}
+}
+
+// Clear removes all elements from the set, leaving an empty set with the same capacity as before.
+func (set *SortedArraySet[E]) Clear() {
+	set.elements = set.elements[:0]
+}
+
+// Grow increases the set's capacity, if necessary, to guarantee room for another n elements. After
+// Grow(n), at least n elements can be added to the set without another allocation.
+//
+// Panics if n is negative.
+func (set *SortedArraySet[E]) Grow(n int) {
+	checkGrowCount(n)
+	set.elements = slices.Grow(set.elements, n)
+}
+
+// Cap returns the capacity of the set's backing slice, i.e. the number of elements the set can hold
+// before it must reallocate.
+func (set SortedArraySet[E]) Cap() int {
+	return cap(set.elements)
+}
+
+// Contains checks if given element is present in the set, using binary search.
+func (set SortedArraySet[E]) Contains(element E) bool {
+	_, found := slices.BinarySearchFunc(set.elements, element, set.compare)
+	return found
+}
+
+// Size returns the number of elements in the set.
+func (set SortedArraySet[E]) Size() int {
+	return len(set.elements)
+}
+
+// IsEmpty checks if there are 0 elements in the set.
+func (set SortedArraySet[E]) IsEmpty() bool {
+	return len(set.elements) == 0
+}
+
+// Equals checks if the set contains exactly the same elements as the other given set.
+func (set SortedArraySet[E]) Equals(otherSet ComparableSet[E]) bool {
+	if set.Size() != otherSet.Size() {
+		return false
+	}
+
+	if otherElements, ok := set.sameOrderElements(otherSet); ok {
+		return slices.Equal(set.elements, otherElements)
+	}
+
+	return set.IsSubsetOf(otherSet)
+}
+
+// IsSubsetOf checks if all of the elements in the set exist in the other given set.
+func (set SortedArraySet[E]) IsSubsetOf(otherSet ComparableSet[E]) bool {
+	if otherElements, ok := set.sameOrderElements(otherSet); ok {
+		i := 0
+		for _, element := range set.elements {
+			for i < len(otherElements) && set.compare(otherElements[i], element) < 0 {
+				i++
+			}
+			if i == len(otherElements) || otherElements[i] != element {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	for _, element := range set.elements {
+		if !otherSet.Contains(element) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsSupersetOf checks if the set contains all of the elements in the other given set.
+func (set SortedArraySet[E]) IsSupersetOf(otherSet ComparableSet[E]) bool {
+	return otherSet.IsSubsetOf(set)
+}
+
+// Union creates a new set that contains all the elements of the receiver set and the other given
+// set. The underlying type of the returned set is a *SortedArraySet with the same order as the
+// receiver - to get a value type, use [SortedArraySet.UnionSortedArraySet] instead.
+func (set SortedArraySet[E]) Union(otherSet ComparableSet[E]) Set[E] {
+	union := set.UnionSortedArraySet(otherSet)
+	return &union
+}
+
+// UnionSortedArraySet creates a new SortedArraySet that contains all the elements of the receiver
+// set and the other given set, with the same order as the receiver.
+func (set SortedArraySet[E]) UnionSortedArraySet(otherSet ComparableSet[E]) SortedArraySet[E] {
+	union := SortedArraySet[E]{
+		elements: make([]E, 0, set.Size()+otherSet.Size()),
+		compare:  set.compare,
+	}
+
+	otherElements, ok := set.sameOrderElements(otherSet)
+	if !ok {
+		union.elements = append(union.elements, set.elements...)
+		union.AddFromSet(otherSet)
+		return union
+	}
+
+	// Merges the two sorted slices, taking equal elements only once.
+	i, j := 0, 0
+	for i < len(set.elements) && j < len(otherElements) {
+		switch comparison := set.compare(set.elements[i], otherElements[j]); {
+		case comparison < 0:
+			union.elements = append(union.elements, set.elements[i])
+			i++
+		case comparison > 0:
+			union.elements = append(union.elements, otherElements[j])
+			j++
+		default:
+			union.elements = append(union.elements, set.elements[i])
+			i++
+			j++
+		}
+	}
+	union.elements = append(union.elements, set.elements[i:]...)
+	union.elements = append(union.elements, otherElements[j:]...)
+
+	return union
+}
+
+// Intersection creates a new set with only the elements that exist in both the receiver set and the
+// other given set. The underlying type of the returned set is a *SortedArraySet with the same order
+// as the receiver - to get a value type, use [SortedArraySet.IntersectionSortedArraySet] instead.
+func (set SortedArraySet[E]) Intersection(otherSet ComparableSet[E]) Set[E] {
+	intersection := set.IntersectionSortedArraySet(otherSet)
+	return &intersection
+}
+
+// IntersectionSortedArraySet creates a new SortedArraySet with only the elements that exist in both
+// the receiver set and the other given set, with the same order as the receiver.
+//
+// If the other set is much smaller than the receiver, its elements are searched for in the receiver
+// with galloping search, which is O(m log(n/m)) for an other set of size m. If the receiver is much
+// smaller, each of its elements is looked up in the other set. Otherwise, sets with the same order
+// are intersected with a merge join.
+func (set SortedArraySet[E]) IntersectionSortedArraySet(
+	otherSet ComparableSet[E],
+) SortedArraySet[E] {
+	var capacity int
+	if set.Size() < otherSet.Size() {
+		capacity = set.Size()
+	} else {
+		capacity = otherSet.Size()
+	}
+
+	intersection := SortedArraySet[E]{elements: make([]E, 0, capacity), compare: set.compare}
+
+	// When one set is much smaller than the other, probing the larger set for each element of the
+	// smaller one is faster than a merge join, which would step through all of the larger set.
+	if otherSet.Size()*gallopingSizeRatio <= len(set.elements) {
+		probes := otherSet.ToSlice()
+		slices.SortFunc(probes, set.compare)
+		intersection.elements = set.appendGalloping(intersection.elements, probes)
+		return intersection
+	}
+	skewed := len(set.elements)*gallopingSizeRatio <= otherSet.Size()
+
+	// Checking for a merge join is O(m) for an other set of size m, so we skip it when the receiver
+	// is much smaller.
+
+	otherElements, ok := set.sameOrderElements(otherSet)
+	if skewed || !ok {
+		// Since we iterate over the receiver's elements in order, the intersection stays sorted.
+		for _, element := range set.elements {
+			if otherSet.Contains(element) {
+				intersection.elements = append(intersection.elements, element)
+			}
+		}
+
+		return intersection
+	}
+
+	i, j := 0, 0
+	for i < len(set.elements) && j < len(otherElements) {
+		switch comparison := set.compare(set.elements[i], otherElements[j]); {
+		case comparison < 0:
+			i++
+		case comparison > 0:
+			j++
+		default:
+			intersection.elements = append(intersection.elements, set.elements[i])
+			i++
+			j++
+		}
+	}
+
+	return intersection
+}
+
+// DifferenceSortedArraySet creates a new SortedArraySet with the elements of the receiver set that
+// do not exist in the other given set, with the same order as the receiver. If the other set is a
+// SortedArraySet with the same order, this uses a merge join, which is O(n + m), instead of looking
+// up each element in the other set.
+func (set SortedArraySet[E]) DifferenceSortedArraySet(
+	otherSet ComparableSet[E],
+) SortedArraySet[E] {
+	difference := SortedArraySet[E]{elements: make([]E, 0, len(set.elements)), compare: set.compare}
+
+	// Like for IntersectionSortedArraySet, the O(m) check for a merge join is skipped when the
+	// receiver is much smaller than the other set.
+	skewed := len(set.elements)*gallopingSizeRatio <= otherSet.Size()
+
+	otherElements, ok := set.sameOrderElements(otherSet)
+	if skewed || !ok {
+		for _, element := range set.elements {
+			if !otherSet.Contains(element) {
+				difference.elements = append(difference.elements, element)
+			}
+		}
+
+		return difference
+	}
+
+	j := 0
+	for _, element := range set.elements {
+		for j < len(otherElements) && set.compare(otherElements[j], element) < 0 {
+			j++
+		}
+		if j == len(otherElements) || otherElements[j] != element {
+			difference.elements = append(difference.elements, element)
+		}
+	}
+
+	return difference
+}
+
+// ToSlice creates a slice with all the elements in the set, in sorted order. The slice is a copy,
+// so it can be mutated freely - to access the set's backing slice without copying, use
+// [SortedArraySet.AsSlice].
+func (set SortedArraySet[E]) ToSlice() []E {
+	return slices.Clone(set.elements)
+}
+
+// AsSlice returns the set's backing slice, in sorted order, without copying it.
+//
+// Mutating the slice may invalidate the set, since it uses the same backing storage and relies on
+// it being sorted. The slice may also be changed by later operations on the set. To get a copy that
+// is safe to mutate, use ToSlice instead.
+func (set SortedArraySet[E]) AsSlice() []E {
+	return set.elements
+}
+
+// AppendTo appends the elements in the set to the given slice in sorted order, and returns the
+// extended slice, like the append builtin. Unlike ToSlice, this lets the caller reuse a buffer
+// across calls.
+func (set SortedArraySet[E]) AppendTo(slice []E) []E {
+	return append(slice, set.elements...)
+}
+
+// ToMap creates a map with all the set's elements as keys.
+func (set SortedArraySet[E]) ToMap() map[E]struct{} {
+	m := make(map[E]struct{}, len(set.elements))
+
+	for _, element := range set.elements {
+		m[element] = struct{}{}
+	}
+
+	return m
+}
+
+// Copy creates a new set with all the same elements, order and capacity as the original set.
+// The underlying type of the returned set is a *SortedArraySet - to get a value type, use
+// [SortedArraySet.CopySortedArraySet] instead.
+func (set SortedArraySet[E]) Copy() Set[E] {
+	newSet := set.CopySortedArraySet()
+	return &newSet
+}
+
+// CopySortedArraySet creates a new SortedArraySet with all the same elements, order and capacity
+// as the original set.
+func (set SortedArraySet[E]) CopySortedArraySet() SortedArraySet[E] {
+	newSet := SortedArraySet[E]{elements: make([]E, len(set.elements), cap(set.elements)),
+		compare: set.compare}
+	copy(newSet.elements, set.elements)
+	return newSet
+}
+
+// CopyCompact creates a new SortedArraySet with all the same elements and order as the original
+// set, but unlike CopySortedArraySet, with capacity for only its current elements (see
+// [ArraySet.CopyCompact]).
+func (set SortedArraySet[E]) CopyCompact() SortedArraySet[E] {
+	newSet := SortedArraySet[E]{elements: make([]E, len(set.elements)), compare: set.compare}
+	copy(newSet.elements, set.elements)
+	return newSet
+}
+
+// String returns a string representation of the set, implementing [fmt.Stringer].
+//
+// Elements are printed in sorted order. A SortedArraySet of elements 1, 2 and 3 will be printed
+// as: SortedArraySet{1, 2, 3}
+func (set SortedArraySet[E]) String() string {
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString("SortedArraySet{")
+
+	for i, element := range set.elements {
+		fmt.Fprint(&stringBuilder, element)
+
+		if i < len(set.elements)-1 {
+			stringBuilder.WriteString(", ")
+		}
+	}
+
+	stringBuilder.WriteByte('}')
+	return stringBuilder.String()
+}
+
+// All returns an iterator over the elements in the set, which can be used in a for-range loop:
+//
+//	for element := range set.All() {
+//		fmt.Println(element)
+//	}
+//
+// Elements are iterated in sorted order.
+func (set SortedArraySet[E]) All() iter.Seq[E] {
+	return func(yield func(element E) bool) {
+		for _, element := range set.elements {
+			if !yield(element) {
+				break
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the elements in the set in descending order, from the greatest
+// element to the least. Like [slices.Backward], this iterates over the backing slice directly, so
+// traversing the largest elements first does not require copying and reversing the elements.
+func (set SortedArraySet[E]) Backward() iter.Seq[E] {
+	return func(yield func(element E) bool) {
+		for i := len(set.elements) - 1; i >= 0; i-- {
+			if !yield(set.elements[i]) {
+				break
+			}
+		}
+	}
+}
+
+// Floor returns the greatest element in the set that is less than or equal to the given element.
+// If there is no such element, it returns false.
+func (set SortedArraySet[E]) Floor(element E) (floor E, ok bool) {
+	index, found := slices.BinarySearchFunc(set.elements, element, set.compare)
+	if found {
+		return set.elements[index], true
+	}
+	return set.elementAt(index - 1)
+}
+
+// Ceiling returns the least element in the set that is greater than or equal to the given element.
+// If there is no such element, it returns false.
+func (set SortedArraySet[E]) Ceiling(element E) (ceiling E, ok bool) {
+	index, _ := slices.BinarySearchFunc(set.elements, element, set.compare)
+	return set.elementAt(index)
+}
+
+// Lower returns the greatest element in the set that is strictly less than the given element.
+// If there is no such element, it returns false.
+func (set SortedArraySet[E]) Lower(element E) (lower E, ok bool) {
+	index, _ := slices.BinarySearchFunc(set.elements, element, set.compare)
+	return set.elementAt(index - 1)
+}
+
+// Higher returns the least element in the set that is strictly greater than the given element.
+// If there is no such element, it returns false.
+func (set SortedArraySet[E]) Higher(element E) (higher E, ok bool) {
+	index, found := slices.BinarySearchFunc(set.elements, element, set.compare)
+	if found {
+		index++
+	}
+	return set.elementAt(index)
+}
+
+// Range returns an iterator over the elements in the set that are greater than or equal to from,
+// and less than to, in sorted order. The given bounds do not have to be present in the set.
+//
+// Finding the start of the range is O(log n), so iterating over a small range of a large set is
+// much cheaper than filtering all of its elements.
+func (set SortedArraySet[E]) Range(from E, to E) iter.Seq[E] {
+	return func(yield func(element E) bool) {
+		start, _ := slices.BinarySearchFunc(set.elements, from, set.compare)
+
+		for _, element := range set.elements[start:] {
+			if set.compare(element, to) >= 0 || !yield(element) {
+				break
+			}
+		}
+	}
+}
+
+// Rank returns the number of elements in the set that are less than the given element. If the
+// element is present in the set, this is its index in sorted order, such that
+// set.Select(set.Rank(element)) == element.
+func (set SortedArraySet[E]) Rank(element E) int {
+	index, _ := slices.BinarySearchFunc(set.elements, element, set.compare)
+	return index
+}
+
+// Select returns the element at the given index in sorted order, where 0 is the least element.
+//
+// Panics if the index is out of range [0, Size()).
+func (set SortedArraySet[E]) Select(index int) E {
+	if index < 0 || index >= len(set.elements) {
+		panic(fmt.Sprintf(
+			"set: index %d out of range for SortedArraySet of size %d",
+			index,
+			len(set.elements),
+		))
+	}
+
+	return set.elements[index]
+}
+
+// elementAt returns the element at the given index, or false if the index is out of range.
+func (set SortedArraySet[E]) elementAt(index int) (element E, ok bool) {
+	if index < 0 || index >= len(set.elements) {
+		var zero E
+		return zero, false
+	}
+	return set.elements[index], true
+}
+
+// gallopingSizeRatio is the ratio between the sizes of two sets above which intersecting them
+// probes the larger set for each element of the smaller one, rather than using a merge join.
+const gallopingSizeRatio = 16
+
+// appendGalloping appends the given probes that are present in the set to the given slice. The
+// probes must be sorted by the set's compare function.
+//
+// Since the probes are sorted, each probe is searched for after the position of the previous one,
+// with exponential (galloping) search: the distance from the previous position is doubled until it
+// passes the probe, and then binary search finds the probe within the last step. This is
+// O(m log(n/m)) for m probes in a set of size n, which is faster than a merge join (O(n + m)) and
+// than a binary search of the whole set for each probe (O(m log n)) when m is much smaller than n.
+func (set SortedArraySet[E]) appendGalloping(found []E, probes []E) []E {
+	position := 0
+	for _, probe := range probes {
+		if position == len(set.elements) {
+			break
+		}
+
+		step := 1
+		for position+step < len(set.elements) &&
+			set.compare(set.elements[position+step], probe) < 0 {
+			step *= 2
+		}
+
+		searchStart := position + step/2
+		searchEnd := min(position+step+1, len(set.elements))
+		index, ok := slices.BinarySearchFunc(
+			set.elements[searchStart:searchEnd],
+			probe,
+			set.compare,
+		)
+		position = searchStart + index
+
+		if ok {
+			found = append(found, set.elements[position])
+			position++
+		}
+	}
+
+	return found
+}
+
+func (set *SortedArraySet[E]) sortAndDeduplicate() {
+	slices.SortFunc(set.elements, set.compare)
+	set.elements = slices.CompactFunc(set.elements, func(a, b E) bool {
+		return set.compare(a, b) == 0
+	})
+}
+
+// sameOrderElements returns the backing slice of the given other set, if it is a SortedArraySet
+// whose elements are sorted by the receiver's compare function, which allows merge joins between
+// the two sets. Since compare functions cannot be compared directly, this checks the order of the
+// other set's elements, which is O(m), and so no more expensive than the merge join itself.
+func (set SortedArraySet[E]) sameOrderElements(otherSet ComparableSet[E]) (elements []E, ok bool) {
+	var otherElements []E
+	switch otherSet := otherSet.(type) {
+	case SortedArraySet[E]:
+		otherElements = otherSet.elements
+	case *SortedArraySet[E]:
+		otherElements = otherSet.elements
+	default:
+		return nil, false
+	}
+
+	if !slices.IsSortedFunc(otherElements, set.compare) {
+		return nil, false
+	}
+	return otherElements, true
+}
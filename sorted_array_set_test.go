@@ -0,0 +1,193 @@
+package set_test
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestSortedArraySetKeepsElementsSorted(t *testing.T) {
+	sortedSet := set.NewSortedArraySet[int]()
+	expected := make(map[int]struct{})
+
+	for i := 0; i < 2000; i++ {
+		element := rand.Intn(200)
+
+		if rand.Intn(3) == 0 {
+			sortedSet.Remove(element)
+			delete(expected, element)
+		} else {
+			sortedSet.Add(element)
+			expected[element] = struct{}{}
+		}
+	}
+
+	sortedSet.AddFromSlice([]int{500, 300, 300, 400})
+	for _, element := range []int{300, 400, 500} {
+		expected[element] = struct{}{}
+	}
+
+	assertSize(t, sortedSet, len(expected))
+	if !slices.IsSorted(sortedSet.ToSlice()) {
+		t.Errorf("expected elements of %v to be sorted", sortedSet)
+	}
+	for element := range expected {
+		if !sortedSet.Contains(element) {
+			t.Fatalf("expected %v to contain %d", sortedSet, element)
+		}
+	}
+}
+
+func TestSortedArraySetFunc(t *testing.T) {
+	descending := func(a, b string) int { return strings.Compare(b, a) }
+
+	sortedSet := set.SortedArraySetFromSliceFunc([]string{"b", "c", "a", "b"}, descending)
+	sortedSet.Add("d")
+
+	if str := sortedSet.String(); str != "SortedArraySet{d, c, b, a}" {
+		t.Errorf("unexpected string for descending SortedArraySet: %s", str)
+	}
+}
+
+func TestSortedArraySetMergeJoins(t *testing.T) {
+	set1 := set.SortedArraySetOf(1, 3, 5, 7, 9)
+	set2 := set.SortedArraySetOf(3, 4, 5, 6, 7)
+
+	intersection := set1.IntersectionSortedArraySet(set2)
+	if expected := []int{3, 5, 7}; !slices.Equal(intersection.ToSlice(), expected) {
+		t.Errorf("expected intersection %v, got %v", expected, intersection)
+	}
+
+	union := set1.UnionSortedArraySet(&set2)
+	if expected := []int{1, 3, 4, 5, 6, 7, 9}; !slices.Equal(union.ToSlice(), expected) {
+		t.Errorf("expected union %v, got %v", expected, union)
+	}
+
+	if !intersection.IsSubsetOf(set1) || !intersection.IsSubsetOf(set2) {
+		t.Errorf("expected %v to be subset of %v and %v", intersection, set1, set2)
+	}
+	if !set1.IsSubsetOf(union) || union.IsSubsetOf(set1) {
+		t.Errorf("unexpected subset result between %v and %v", set1, union)
+	}
+
+	// Sets with a different order must not be merge-joined with the receiver's order.
+	descendingSet := set.SortedArraySetFromSliceFunc(
+		[]int{3, 4, 5, 6, 7},
+		func(a, b int) int { return b - a },
+	)
+	if !set2.Equals(descendingSet) {
+		t.Errorf("expected %v to equal %v", set2, descendingSet)
+	}
+	intersection = set1.IntersectionSortedArraySet(descendingSet)
+	if expected := []int{3, 5, 7}; !slices.Equal(intersection.ToSlice(), expected) {
+		t.Errorf("expected intersection with differently ordered set %v, got %v", expected, intersection)
+	}
+}
+
+func TestSortedArraySetRangeQueries(t *testing.T) {
+	sortedSet := set.SortedArraySetOf(10, 20, 30, 40)
+
+	testCases := []struct {
+		name     string
+		lookup   func(int) (int, bool)
+		input    int
+		expected int
+		ok       bool
+	}{
+		{"Floor", sortedSet.Floor, 25, 20, true},
+		{"FloorExact", sortedSet.Floor, 20, 20, true},
+		{"FloorNone", sortedSet.Floor, 5, 0, false},
+		{"Ceiling", sortedSet.Ceiling, 25, 30, true},
+		{"CeilingExact", sortedSet.Ceiling, 30, 30, true},
+		{"CeilingNone", sortedSet.Ceiling, 45, 0, false},
+		{"Lower", sortedSet.Lower, 20, 10, true},
+		{"LowerNone", sortedSet.Lower, 10, 0, false},
+		{"Higher", sortedSet.Higher, 20, 30, true},
+		{"HigherNone", sortedSet.Higher, 40, 0, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual, ok := testCase.lookup(testCase.input)
+			if actual != testCase.expected || ok != testCase.ok {
+				t.Errorf(
+					"expected %s(%d) == (%d, %t), got (%d, %t)",
+					testCase.name, testCase.input, testCase.expected, testCase.ok, actual, ok,
+				)
+			}
+		})
+	}
+
+	if inRange := slices.Collect(sortedSet.Range(15, 40)); !slices.Equal(inRange, []int{20, 30}) {
+		t.Errorf("expected Range(15, 40) to yield [20 30], got %v", inRange)
+	}
+
+	if rank := sortedSet.Rank(30); rank != 2 {
+		t.Errorf("expected Rank(30) == 2, got %d", rank)
+	}
+	if rank := sortedSet.Rank(35); rank != 3 {
+		t.Errorf("expected Rank(35) == 3, got %d", rank)
+	}
+	if selected := sortedSet.Select(sortedSet.Rank(40)); selected != 40 {
+		t.Errorf("expected Select(Rank(40)) == 40, got %d", selected)
+	}
+}
+
+func TestSortedArraySetBackward(t *testing.T) {
+	sortedSet := set.SortedArraySetOf(3, 1, 4, 2)
+
+	var largestTwo []int
+	for element := range sortedSet.Backward() {
+		largestTwo = append(largestTwo, element)
+		if len(largestTwo) == 2 {
+			break
+		}
+	}
+
+	if !slices.Equal(largestTwo, []int{4, 3}) {
+		t.Errorf("expected Backward to yield 4, 3 first, got %v", largestTwo)
+	}
+}
+
+func TestSortedArraySetIntersectionSkewed(t *testing.T) {
+	large := set.SortedArraySetFromSlice(createRandomIntSlice(1000))
+	smallElements := append(createRandomIntSlice(10), -1, 5000)
+	small := set.HashSetFromSlice(smallElements)
+
+	var expected []int
+	for _, element := range smallElements {
+		if large.Contains(element) {
+			expected = append(expected, element)
+		}
+	}
+	slices.Sort(expected)
+
+	// Both directions, with the small set being a HashSet and a SortedArraySet.
+	for _, intersection := range []set.SortedArraySet[int]{
+		large.IntersectionSortedArraySet(small),
+		large.IntersectionSortedArraySet(set.SortedArraySetFromSlice(smallElements)),
+		set.SortedArraySetFromSlice(smallElements).IntersectionSortedArraySet(large),
+	} {
+		if !slices.Equal(intersection.AsSlice(), expected) {
+			t.Errorf("expected intersection %v, got %v", expected, intersection)
+		}
+	}
+}
+
+func TestSortedArraySetDifference(t *testing.T) {
+	a := set.SortedArraySetOf(1, 2, 3, 4, 5)
+
+	for _, other := range []set.ComparableSet[int]{
+		set.SortedArraySetOf(0, 2, 4, 6),
+		set.HashSetOf(0, 2, 4, 6),
+		set.SortedArraySetFromSliceFunc([]int{0, 2, 4, 6}, func(a, b int) int { return b - a }),
+	} {
+		difference := a.DifferenceSortedArraySet(other)
+		if expected := []int{1, 3, 5}; !slices.Equal(difference.AsSlice(), expected) {
+			t.Errorf("expected %v minus %v to be %v, got %v", a, other, expected, difference)
+		}
+	}
+}
@@ -0,0 +1,359 @@
+package set
+
+import (
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+	"strings"
+)
+
+// A SparseSet is a collection of unique non-negative integers, optimized for dense small-integer
+// IDs, such as entity IDs in an entity-component system. It keeps its elements packed in a dense
+// slice, alongside a sparse slice indexed by element value that points into the dense slice. This
+// gives O(1) Add, Remove and Contains, and iteration over a contiguous slice.
+//
+// The sparse slice grows to the value of the largest element added to the set, so a SparseSet is
+// only suitable for elements with a small maximum value. Elements larger than [math.MaxInt32] are
+// not supported, and adding one panics.
+//
+// The zero value for a SparseSet is ready to use. It must not be copied after first use.
+//
+// SparseSet implements [Set] when passed by pointer, and [ComparableSet] when passed by value.
+type SparseSet[E Integer] struct {
+	dense  []E
+	sparse []int
+}
+
+// maxSparseSetElement is the largest element that a [SparseSet] can hold. It bounds the length of
+// the sparse slice, so that int(element)+1 cannot overflow, even on 32-bit platforms.
+const maxSparseSetElement = math.MaxInt32
+
+// Integer is a constraint for the element type of a [SparseSet], permitting any integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// NewSparseSet creates a new [SparseSet] for elements of type E.
+// It must not be copied after first use.
+func NewSparseSet[E Integer]() SparseSet[E] {
+	return SparseSet[E]{dense: nil, sparse: nil}
+}
+
+// SparseSetWithCapacity creates a new [SparseSet], with room for elements in the range
+// [0, capacity) without further allocation.
+// It must not be copied after first use.
+func SparseSetWithCapacity[E Integer](capacity int) SparseSet[E] {
+	return SparseSet[E]{dense: make([]E, 0, capacity), sparse: make([]int, capacity)}
+}
+
+// SparseSetOf creates a new [SparseSet] from the given elements.
+// It must not be copied after first use.
+// Duplicate elements are added only once.
+//
+// Panics if any of the elements are negative or larger than [math.MaxInt32].
+func SparseSetOf[E Integer](elements ...E) SparseSet[E] {
+	return SparseSetFromSlice(elements)
+}
+
+// SparseSetFromSlice creates a new [SparseSet] from the elements in the given slice.
+// It must not be copied after first use.
+// Duplicate elements in the slice are added only once.
+//
+// Panics if any of the elements are negative or larger than [math.MaxInt32].
+func SparseSetFromSlice[E Integer](elements []E) SparseSet[E] {
+	set := SparseSet[E]{dense: make([]E, 0, len(elements))}
+	set.AddFromSlice(elements)
+	return set
+}
+
+// Add adds the given element to the set.
+// If the element is already present in the set, Add is a no-op.
+//
+// Panics if the element is negative or larger than [math.MaxInt32].
+func (set *SparseSet[E]) Add(element E) {
+	if element < 0 {
+		panic(fmt.Sprintf("set: SparseSet cannot hold negative element %d", element))
+	}
+	if uint64(element) > maxSparseSetElement {
+		panic(fmt.Sprintf(
+			"set: SparseSet cannot hold element %d, max supported value is %d",
+			element,
+			maxSparseSetElement,
+		))
+	}
+
+	if set.Contains(element) {
+		return
+	}
+
+	if uint64(element) >= uint64(len(set.sparse)) {
+		set.growSparse(element)
+	}
+
+	set.sparse[element] = len(set.dense)
+	set.dense = append(set.dense, element)
+}
+
+// AddMultiple adds the given elements to the set. Duplicate elements are added only once, and
+// elements already present in the set are not added.
+//
+// Panics if any of the elements are negative or larger than [math.MaxInt32].
+func (set *SparseSet[E]) AddMultiple(elements ...E) {
+	set.AddFromSlice(elements)
+}
+
+// AddFromSlice adds the elements from the given slice to the set. Duplicate elements are added only
+// once, and elements already present in the set are not added.
+//
+// Panics if any of the elements are negative or larger than [math.MaxInt32].
+func (set *SparseSet[E]) AddFromSlice(elements []E) {
+	set.dense = slices.Grow(set.dense, len(elements))
+
+	for _, element := range elements {
+		set.Add(element)
+	}
+}
+
+// AddFromSet adds elements from the given other set to the set.
+//
+// Panics if any of the elements are negative or larger than [math.MaxInt32].
+func (set *SparseSet[E]) AddFromSet(otherSet ComparableSet[E]) {
+	set.dense = slices.Grow(set.dense, otherSet.Size())
+
+	for element := range otherSet.All() {
+		set.Add(element)
+	}
+}
+
+// AddFromSeq adds the elements from the given iterator to the set, without first collecting them
+// into a slice. Duplicate elements are added only once, and elements already present in the set are
+// not added.
+//
+// Panics if any of the elements are negative or larger than [math.MaxInt32].
+func (set *SparseSet[E]) AddFromSeq(seq iter.Seq[E]) {
+	for element := range seq {
+		set.Add(element)
+	}
+}
+
+// Remove removes the given element from the set.
+// If the element is not present in the set, Remove is a no-op.
+//
+// The last element in the dense slice is moved into the removed element's place, so Remove is O(1).
+func (set *SparseSet[E]) Remove(element E) {
+	if !set.Contains(element) {
+		return
+	}
+
+	index := set.sparse[element]
+	last := set.dense[len(set.dense)-1]
+
+	set.dense[index] = last
+	set.sparse[last] = index
+	set.dense = set.dense[:len(set.dense)-1]
+}
+
+// Clear removes all elements from the set, leaving an empty set with the same capacity as before.
+// Clear is O(1), since stale entries in the sparse slice are ignored.
+func (set *SparseSet[E]) Clear() {
+	set.dense = set.dense[:0]
+}
+
+// Grow increases the capacity of the set's dense slice, if necessary, to guarantee room for another
+// n elements. Since the sparse slice is indexed by element value, it cannot be grown ahead of time
+// without knowing the elements - to reserve room for elements in a known range, use
+// [SparseSetWithCapacity].
+//
+// Panics if n is negative.
+func (set *SparseSet[E]) Grow(n int) {
+	checkGrowCount(n)
+	set.dense = slices.Grow(set.dense, n)
+}
+
+// Cap returns the capacity of the set's dense slice, i.e. the number of elements the set can hold
+// before the dense slice must reallocate. Adding elements greater than any previously added element
+// may still grow the sparse slice.
+func (set SparseSet[E]) Cap() int {
+	return cap(set.dense)
+}
+
+// Contains checks if given element is present in the set.
+func (set SparseSet[E]) Contains(element E) bool {
+	if element < 0 || uint64(element) >= uint64(len(set.sparse)) {
+		return false
+	}
+
+	index := set.sparse[element]
+	return index < len(set.dense) && set.dense[index] == element
+}
+
+// Size returns the number of elements in the set.
+func (set SparseSet[E]) Size() int {
+	return len(set.dense)
+}
+
+// IsEmpty checks if there are 0 elements in the set.
+func (set SparseSet[E]) IsEmpty() bool {
+	return len(set.dense) == 0
+}
+
+// Equals checks if the set contains exactly the same elements as the other given set.
+func (set SparseSet[E]) Equals(otherSet ComparableSet[E]) bool {
+	return set.Size() == otherSet.Size() && set.IsSubsetOf(otherSet)
+}
+
+// IsSubsetOf checks if all of the elements in the set exist in the other given set.
+func (set SparseSet[E]) IsSubsetOf(otherSet ComparableSet[E]) bool {
+	for _, element := range set.dense {
+		if !otherSet.Contains(element) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsSupersetOf checks if the set contains all of the elements in the other given set.
+func (set SparseSet[E]) IsSupersetOf(otherSet ComparableSet[E]) bool {
+	return otherSet.IsSubsetOf(set)
+}
+
+// Union creates a new set that contains all the elements of the receiver set and the other given
+// set. The underlying type of the returned set is a *SparseSet - to get a value type, use
+// [SparseSet.UnionSparseSet] instead.
+func (set SparseSet[E]) Union(otherSet ComparableSet[E]) Set[E] {
+	union := set.UnionSparseSet(otherSet)
+	return &union
+}
+
+// UnionSparseSet creates a new SparseSet that contains all the elements of the receiver set and
+// the other given set.
+//
+// Panics if any of the elements in the other set are negative or larger than [math.MaxInt32].
+func (set SparseSet[E]) UnionSparseSet(otherSet ComparableSet[E]) SparseSet[E] {
+	union := set.CopySparseSet()
+	union.AddFromSet(otherSet)
+	return union
+}
+
+// Intersection creates a new set with only the elements that exist in both the receiver set and the
+// other given set. The underlying type of the returned set is a *SparseSet - to get a value type,
+// use [SparseSet.IntersectionSparseSet] instead.
+func (set SparseSet[E]) Intersection(otherSet ComparableSet[E]) Set[E] {
+	intersection := set.IntersectionSparseSet(otherSet)
+	return &intersection
+}
+
+// IntersectionSparseSet creates a new SparseSet with only the elements that exist in both the
+// receiver set and the other given set.
+func (set SparseSet[E]) IntersectionSparseSet(otherSet ComparableSet[E]) SparseSet[E] {
+	var intersection SparseSet[E]
+	for _, element := range set.dense {
+		if otherSet.Contains(element) {
+			intersection.Add(element)
+		}
+	}
+
+	return intersection
+}
+
+// ToSlice creates a slice with all the elements in the set. The slice is a copy, so it can be
+// mutated freely - to access the set's dense slice without copying, use [SparseSet.AsSlice].
+func (set SparseSet[E]) ToSlice() []E {
+	return slices.Clone(set.dense)
+}
+
+// AsSlice returns the set's dense slice of elements, without copying it.
+//
+// Mutating the slice may invalidate the set, since it uses the same backing storage. The slice may
+// also be changed by later operations on the set. To get a copy that is safe to mutate, use
+// ToSlice instead.
+func (set SparseSet[E]) AsSlice() []E {
+	return set.dense
+}
+
+// AppendTo appends the elements in the set to the given slice, and returns the extended slice,
+// like the append builtin. Unlike ToSlice, this lets the caller reuse a buffer across calls.
+func (set SparseSet[E]) AppendTo(slice []E) []E {
+	return append(slice, set.dense...)
+}
+
+// ToMap creates a map with all the set's elements as keys.
+func (set SparseSet[E]) ToMap() map[E]struct{} {
+	m := make(map[E]struct{}, len(set.dense))
+
+	for _, element := range set.dense {
+		m[element] = struct{}{}
+	}
+
+	return m
+}
+
+// Copy creates a new set with all the same elements and capacity as the original set.
+// The underlying type of the returned set is a *SparseSet - to get a value type, use
+// [SparseSet.CopySparseSet] instead.
+func (set SparseSet[E]) Copy() Set[E] {
+	newSet := set.CopySparseSet()
+	return &newSet
+}
+
+// CopySparseSet creates a new SparseSet with all the same elements and capacity as the original
+// set.
+func (set SparseSet[E]) CopySparseSet() SparseSet[E] {
+	newSet := SparseSet[E]{
+		dense:  make([]E, len(set.dense), cap(set.dense)),
+		sparse: make([]int, len(set.sparse)),
+	}
+	copy(newSet.dense, set.dense)
+	copy(newSet.sparse, set.sparse)
+	return newSet
+}
+
+// String returns a string representation of the set, implementing [fmt.Stringer].
+//
+// A SparseSet of elements 1, 2 and 3 will be printed as: SparseSet{1, 2, 3} (though the order may
+// vary, as removing elements moves other elements).
+func (set SparseSet[E]) String() string {
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString("SparseSet{")
+
+	for i, element := range set.dense {
+		fmt.Fprint(&stringBuilder, element)
+
+		if i < len(set.dense)-1 {
+			stringBuilder.WriteString(", ")
+		}
+	}
+
+	stringBuilder.WriteByte('}')
+	return stringBuilder.String()
+}
+
+// All returns an iterator over the elements in the set, which can be used in a for-range loop:
+//
+//	for element := range set.All() {
+//		fmt.Println(element)
+//	}
+//
+// Since sets are unordered, iteration order is non-deterministic.
+func (set SparseSet[E]) All() iter.Seq[E] {
+	return func(yield func(element E) bool) {
+		for _, element := range set.dense {
+			if !yield(element) {
+				break
+			}
+		}
+	}
+}
+
+func (set *SparseSet[E]) growSparse(element E) {
+	newLength := 2 * len(set.sparse)
+	if minLength := int(element) + 1; newLength < minLength {
+		newLength = minLength
+	}
+
+	newSparse := make([]int, newLength)
+	copy(newSparse, set.sparse)
+	set.sparse = newSparse
+}
@@ -0,0 +1,56 @@
+package set_test
+
+import (
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestSparseSetRemoveAndReAdd(t *testing.T) {
+	set := set.SparseSetOf[uint32](5, 100, 7)
+
+	set.Remove(5)
+	assertSize(t, set, 2)
+	assertContains[uint32](t, set, 100, 7)
+
+	set.Clear()
+	set.Add(7)
+	assertSize(t, set, 1)
+	assertContains[uint32](t, set, 7)
+	if set.Contains(100) {
+		t.Errorf("expected %v to not contain cleared element 100", set)
+	}
+}
+
+func TestSparseSetNegativeElement(t *testing.T) {
+	set := set.NewSparseSet[int]()
+
+	if set.Contains(-1) {
+		t.Errorf("expected %v.Contains(-1) == false", set)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected adding negative element to SparseSet to panic")
+		}
+	}()
+	set.Add(-1)
+}
+
+func TestSparseSetTooLargeElement(t *testing.T) {
+	set := set.NewSparseSet[uint64]()
+
+	if set.Contains(1 << 62) {
+		t.Errorf("expected %v.Contains(1 << 62) == false", set)
+	}
+
+	defer func() {
+		message, _ := recover().(string)
+		expected := "set: SparseSet cannot hold element 4611686018427387904, " +
+			"max supported value is 2147483647"
+		if message != expected {
+			t.Errorf("expected adding too large element to panic with %q, got %q", expected, message)
+		}
+	}()
+	set.Add(1 << 62)
+}
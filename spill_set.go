@@ -0,0 +1,372 @@
+package set
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/maphash"
+	"io"
+	"iter"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// A SpillSet is a set for deduplicating streams that are too large to fit in memory. It keeps up to
+// a given number of elements in memory, and when that limit is reached, spills them to temporary
+// files on disk. Elements on disk are spread over 64 partition files by their hash, so that looking
+// up an element only reads the one partition it may be in.
+//
+// Since lookups may read from disk, they are much slower than for in-memory sets, and they can
+// fail, so the methods of SpillSet return errors, and it does not implement [Set]. To amortize the
+// cost of reading partitions, use [SpillSet.AddBatch] and [SpillSet.ContainsBatch], which read each
+// partition at most once per batch:
+//
+//	seen, err := set.NewSpillSet[string]("", 1_000_000)
+//	if err != nil { ... }
+//	defer seen.Close()
+//
+//	for batch := range batches {
+//		newRows, err := seen.AddBatch(batch)
+//		if err != nil { ... }
+//		// ...
+//	}
+//
+// Elements are written to disk in the format of MarshalBinary, so the same element types are
+// supported. SpillSet is not safe for concurrent use. Call [SpillSet.Close] when done with the set,
+// to remove its files.
+type SpillSet[E comparable] struct {
+	codec          binaryElementCodec[E]
+	dir            string
+	memory         HashSet[E]
+	memoryLimit    int
+	partitionSizes [spillPartitionCount]int
+	seed           maphash.Seed
+	size           int
+}
+
+const spillPartitionCount = 64
+
+// NewSpillSet creates a new [SpillSet], which keeps up to memoryLimit elements in memory before
+// spilling them to disk. Its files are stored in a new temporary directory inside the given
+// directory, or inside the default directory for temporary files if dir is "" (see
+// [os.MkdirTemp]). Call [SpillSet.Close] to remove the files.
+//
+// Returns an error if E is not supported by the binary encoding, or if the directory could not be
+// created. Panics if memoryLimit is less than 1.
+func NewSpillSet[E comparable](dir string, memoryLimit int) (*SpillSet[E], error) {
+	if memoryLimit < 1 {
+		panic(fmt.Sprintf("set: SpillSet memory limit must be at least 1, got %d", memoryLimit))
+	}
+
+	codec, err := newBinaryElementCodec[E]()
+	if err != nil {
+		return nil, err
+	}
+
+	spillDir, err := os.MkdirTemp(dir, "set-spill-*")
+	if err != nil {
+		return nil, fmt.Errorf("set: failed to create spill directory: %w", err)
+	}
+
+	return &SpillSet[E]{
+		codec:       codec,
+		dir:         spillDir,
+		memory:      HashSetWithCapacity[E](memoryLimit),
+		memoryLimit: memoryLimit,
+		seed:        maphash.MakeSeed(),
+	}, nil
+}
+
+// Add adds the given element to the set, and returns true if it was not already present.
+func (set *SpillSet[E]) Add(element E) (added bool, err error) {
+	addedElements, err := set.AddBatch([]E{element})
+	return len(addedElements) != 0, err
+}
+
+// AddBatch adds the given elements to the set, and returns the elements that were not already
+// present, in the order they were given. Duplicates within the batch are added (and returned) only
+// once. Each partition on disk is read at most once per call.
+//
+// If an error occurs while reading from or writing to disk, the set may be left with only some of
+// the elements added.
+func (set *SpillSet[E]) AddBatch(elements []E) (added []E, err error) {
+	return set.AddBatchContext(context.Background(), elements)
+}
+
+// AddBatchContext is like [SpillSet.AddBatch], but stops reading from disk if the given context is
+// canceled, returning a [PartialResultError] without adding any of the elements.
+func (set *SpillSet[E]) AddBatchContext(ctx context.Context, elements []E) (added []E, err error) {
+	candidates := make(map[E]struct{}, len(elements))
+	for _, element := range elements {
+		if !set.memory.Contains(element) {
+			candidates[element] = struct{}{}
+		}
+	}
+
+	if err := set.removeFoundOnDisk(ctx, candidates); err != nil {
+		return nil, err
+	}
+
+	for _, element := range elements {
+		if _, ok := candidates[element]; !ok {
+			continue
+		}
+		delete(candidates, element)
+
+		set.memory.Add(element)
+		set.size++
+		added = append(added, element)
+
+		if set.memory.Size() >= set.memoryLimit {
+			if err := set.spill(); err != nil {
+				return added, err
+			}
+		}
+	}
+
+	return added, nil
+}
+
+// Contains checks if the given element is present in the set.
+func (set *SpillSet[E]) Contains(element E) (bool, error) {
+	contained, err := set.ContainsBatch([]E{element})
+	if err != nil {
+		return false, err
+	}
+	return contained[0], nil
+}
+
+// ContainsBatch checks if each of the given elements is present in the set, returning a slice with
+// the result for the element at each index. Each partition on disk is read at most once per call.
+func (set *SpillSet[E]) ContainsBatch(elements []E) ([]bool, error) {
+	return set.ContainsBatchContext(context.Background(), elements)
+}
+
+// ContainsBatchContext is like [SpillSet.ContainsBatch], but stops reading from disk if the given
+// context is canceled, returning a [PartialResultError].
+func (set *SpillSet[E]) ContainsBatchContext(ctx context.Context, elements []E) ([]bool, error) {
+	missing := make(map[E]struct{}, len(elements))
+	for _, element := range elements {
+		if !set.memory.Contains(element) {
+			missing[element] = struct{}{}
+		}
+	}
+
+	if err := set.removeFoundOnDisk(ctx, missing); err != nil {
+		return nil, err
+	}
+
+	contained := make([]bool, len(elements))
+	for i, element := range elements {
+		_, isMissing := missing[element]
+		contained[i] = !isMissing
+	}
+	return contained, nil
+}
+
+// Size returns the number of elements in the set, both in memory and on disk.
+func (set *SpillSet[E]) Size() int {
+	return set.size
+}
+
+// IsEmpty checks if there are 0 elements in the set.
+func (set *SpillSet[E]) IsEmpty() bool {
+	return set.size == 0
+}
+
+// All returns an iterator over the elements in the set, and errors from reading them from disk.
+// Iteration stops after an error.
+//
+// Since sets are unordered, iteration order is non-deterministic.
+func (set *SpillSet[E]) All() iter.Seq2[E, error] {
+	return func(yield func(element E, err error) bool) {
+		for element := range set.memory.All() {
+			if !yield(element, nil) {
+				return
+			}
+		}
+
+		for partition := range spillPartitionCount {
+			stopped := false
+			err := set.readPartition(partition, func(element E) bool {
+				stopped = !yield(element, nil)
+				return !stopped
+			})
+			if stopped {
+				return
+			}
+			if err != nil {
+				var zero E
+				yield(zero, err)
+				return
+			}
+		}
+	}
+}
+
+// Close removes the set's files from disk. The set must not be used after it is closed.
+func (set *SpillSet[E]) Close() error {
+	set.memory.Clear()
+	if err := os.RemoveAll(set.dir); err != nil {
+		return fmt.Errorf("set: failed to remove spill directory: %w", err)
+	}
+	return nil
+}
+
+// removeFoundOnDisk removes the elements that are present on disk from the given elements, reading
+// each partition that may contain one of them once. The context is checked before reading anything,
+// so that a canceled context fails consistently, regardless of which partitions are on disk.
+func (set *SpillSet[E]) removeFoundOnDisk(ctx context.Context, elements map[E]struct{}) error {
+	if err := checkContext(ctx, 0); err != nil {
+		return err
+	}
+
+	var wantedPartitions [spillPartitionCount]int
+	for element := range elements {
+		partition := set.partitionOf(element)
+		if set.partitionSizes[partition] != 0 {
+			wantedPartitions[partition]++
+		}
+	}
+
+	processed := 0
+	for partition, wanted := range wantedPartitions {
+		if wanted == 0 {
+			continue
+		}
+
+		var canceledErr error
+		err := set.readPartition(partition, func(element E) bool {
+			if canceledErr = checkContext(ctx, processed); canceledErr != nil {
+				return false
+			}
+			processed++
+
+			if _, ok := elements[element]; ok {
+				delete(elements, element)
+				wanted--
+			}
+			return wanted != 0
+		})
+		if err != nil {
+			return err
+		}
+		if canceledErr != nil {
+			return canceledErr
+		}
+	}
+
+	return nil
+}
+
+// spill appends the elements in memory to their partition files, and clears the memory.
+func (set *SpillSet[E]) spill() (returnedErr error) {
+	var writers [spillPartitionCount]*bufio.Writer
+	var files [spillPartitionCount]*os.File
+	defer func() {
+		for _, file := range files {
+			if file != nil {
+				if err := file.Close(); err != nil && returnedErr == nil {
+					returnedErr = fmt.Errorf("set: failed to close spill file: %w", err)
+				}
+			}
+		}
+	}()
+
+	var buffer []byte
+	for element := range set.memory.All() {
+		partition := set.partitionOf(element)
+
+		if writers[partition] == nil {
+			file, err := os.OpenFile(
+				set.partitionPath(partition),
+				os.O_WRONLY|os.O_CREATE|os.O_APPEND,
+				0o600,
+			)
+			if err != nil {
+				return fmt.Errorf("set: failed to open spill file: %w", err)
+			}
+			files[partition] = file
+			writers[partition] = bufio.NewWriter(file)
+		}
+
+		// Each element is prefixed by its encoded length, so that partitions can be read as a
+		// stream of records.
+		encoded, err := set.codec.encode(nil, element)
+		if err != nil {
+			return fmt.Errorf("set: failed to encode element for spilling: %w", err)
+		}
+		buffer = binary.AppendUvarint(buffer[:0], uint64(len(encoded)))
+		buffer = append(buffer, encoded...)
+		if _, err := writers[partition].Write(buffer); err != nil {
+			return fmt.Errorf("set: failed to write spill file: %w", err)
+		}
+
+		set.partitionSizes[partition]++
+	}
+
+	for _, writer := range writers {
+		if writer != nil {
+			if err := writer.Flush(); err != nil {
+				return fmt.Errorf("set: failed to write spill file: %w", err)
+			}
+		}
+	}
+
+	set.memory.Clear()
+	return nil
+}
+
+// readPartition calls the given function with each element in the given partition on disk, until
+// it returns false.
+func (set *SpillSet[E]) readPartition(partition int, handle func(element E) bool) error {
+	if set.partitionSizes[partition] == 0 {
+		return nil
+	}
+
+	file, err := os.Open(set.partitionPath(partition))
+	if err != nil {
+		return fmt.Errorf("set: failed to open spill file: %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	var record []byte
+	for {
+		length, err := binary.ReadUvarint(reader)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("set: failed to read spill file: %w", err)
+		}
+
+		if uint64(cap(record)) < length {
+			record = make([]byte, length)
+		}
+		record = record[:length]
+		if _, err := io.ReadFull(reader, record); err != nil {
+			return fmt.Errorf("set: failed to read spill file: %w", err)
+		}
+
+		element, _, err := set.codec.decode(record)
+		if err != nil {
+			return fmt.Errorf("set: failed to decode spilled element: %w", err)
+		}
+
+		if !handle(element) {
+			return nil
+		}
+	}
+}
+
+func (set *SpillSet[E]) partitionOf(element E) int {
+	return int(maphash.Comparable(set.seed, element) % spillPartitionCount)
+}
+
+func (set *SpillSet[E]) partitionPath(partition int) string {
+	return filepath.Join(set.dir, strconv.Itoa(partition))
+}
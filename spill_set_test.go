@@ -0,0 +1,104 @@
+package set_test
+
+import (
+	"math/rand"
+	"os"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestSpillSet(t *testing.T) {
+	spillSet, err := set.NewSpillSet[int](t.TempDir(), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer spillSet.Close()
+
+	expected := make(map[int]struct{})
+	for range 50 {
+		batch := make([]int, 100)
+		for i := range batch {
+			batch[i] = rand.Intn(3000)
+		}
+
+		added, err := spillSet.AddBatch(batch)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedAdded := 0
+		for _, element := range batch {
+			if _, ok := expected[element]; !ok {
+				expected[element] = struct{}{}
+				expectedAdded++
+			}
+		}
+		if len(added) != expectedAdded {
+			t.Fatalf("expected AddBatch to add %d new elements, got %d", expectedAdded, len(added))
+		}
+	}
+
+	if spillSet.Size() != len(expected) {
+		t.Errorf("expected size %d, got %d", len(expected), spillSet.Size())
+	}
+
+	added, err := spillSet.Add(-1)
+	if err != nil || !added {
+		t.Errorf("expected Add(-1) to add new element, got %v, %v", added, err)
+	}
+	added, err = spillSet.Add(-1)
+	if err != nil || added {
+		t.Errorf("expected second Add(-1) to not add element, got %v, %v", added, err)
+	}
+	expected[-1] = struct{}{}
+
+	lookups := []int{-1, -2, 3000}
+	for element := range expected {
+		lookups = append(lookups, element)
+	}
+	contained, err := spillSet.ContainsBatch(lookups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, element := range lookups {
+		if _, ok := expected[element]; ok != contained[i] {
+			t.Errorf("expected Contains(%d) == %v, got %v", element, ok, contained[i])
+		}
+	}
+
+	iterated := make(map[int]struct{})
+	for element, err := range spillSet.All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		iterated[element] = struct{}{}
+	}
+	if len(iterated) != len(expected) {
+		t.Errorf("expected to iterate %d unique elements, got %d", len(expected), len(iterated))
+	}
+}
+
+func TestSpillSetCloseRemovesFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	spillSet, err := set.NewSpillSet[string](dir, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := spillSet.AddBatch([]string{"a", "b", "c"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := spillSet.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected spill directory to be removed after Close, found %v", entries)
+	}
+}
@@ -0,0 +1,225 @@
+package set
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// The database/sql support on the set types uses the PostgreSQL array literal format, such as
+// {1,2,3} or {"a","b","c"}, which can be stored in both Postgres array columns and plain text
+// columns. Strings, numbers, bools and element types implementing [encoding.TextMarshaler] (with
+// pointers to the element type implementing [encoding.TextUnmarshaler]) are supported.
+
+func sqlValueFromSet[E comparable](set ComparableSet[E]) (driver.Value, error) {
+	var stringBuilder strings.Builder
+	stringBuilder.WriteByte('{')
+
+	first := true
+	for element := range set.All() {
+		if !first {
+			stringBuilder.WriteByte(',')
+		}
+		first = false
+
+		text, quote, err := formatElementText(element)
+		if err != nil {
+			return nil, fmt.Errorf("set: failed to convert set to SQL value: %w", err)
+		}
+
+		if quote {
+			writeQuotedSQLArrayElement(&stringBuilder, text)
+		} else {
+			stringBuilder.WriteString(text)
+		}
+	}
+
+	stringBuilder.WriteByte('}')
+	return stringBuilder.String(), nil
+}
+
+// scanSQLValueIntoSet clears the given set, and adds the elements parsed from the given SQL value.
+// A nil value results in an empty set.
+func scanSQLValueIntoSet[E comparable](src any, set Set[E]) error {
+	var text string
+	switch src := src.(type) {
+	case string:
+		text = src
+	case []byte:
+		text = string(src)
+	case nil:
+		set.Clear()
+		return nil
+	default:
+		return fmt.Errorf("set: cannot scan SQL value of type %T into set", src)
+	}
+
+	elements, err := parseSQLArray[E](text)
+	if err != nil {
+		return fmt.Errorf("set: failed to scan SQL value into set: %w", err)
+	}
+
+	set.Clear()
+	set.AddFromSlice(elements)
+	return nil
+}
+
+func parseSQLArray[E comparable](text string) ([]E, error) {
+	if len(text) < 2 || text[0] != '{' || text[len(text)-1] != '}' {
+		return nil, fmt.Errorf("expected array literal enclosed in {}, got '%s'", text)
+	}
+	text = text[1 : len(text)-1]
+
+	var elements []E
+	for len(strings.TrimSpace(text)) > 0 {
+		text = strings.TrimLeft(text, " ")
+
+		var elementText string
+		if text[0] == '"' {
+			var unquoted strings.Builder
+			i := 1
+			for ; i < len(text) && text[i] != '"'; i++ {
+				if text[i] == '\\' {
+					i++
+					if i == len(text) {
+						break
+					}
+				}
+				unquoted.WriteByte(text[i])
+			}
+			if i >= len(text) {
+				return nil, errors.New("unterminated quoted array element")
+			}
+
+			elementText = unquoted.String()
+			text = strings.TrimLeft(text[i+1:], " ")
+		} else {
+			end := strings.IndexByte(text, ',')
+			if end == -1 {
+				end = len(text)
+			}
+
+			elementText = strings.TrimSpace(text[:end])
+			if elementText == "" {
+				return nil, errors.New("empty array element (empty strings must be quoted)")
+			}
+			if strings.EqualFold(elementText, "NULL") {
+				return nil, errors.New("NULL array elements are not supported in sets")
+			}
+			text = text[end:]
+		}
+
+		element, err := parseElementText[E](elementText)
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+
+		if len(text) > 0 {
+			if text[0] != ',' {
+				return nil, fmt.Errorf("expected ',' between array elements, got '%c'", text[0])
+			}
+			text = text[1:]
+			if strings.TrimSpace(text) == "" {
+				return nil, errors.New("trailing ',' after last array element")
+			}
+		}
+	}
+
+	return elements, nil
+}
+
+func writeQuotedSQLArrayElement(stringBuilder *strings.Builder, text string) {
+	stringBuilder.WriteByte('"')
+	for i := 0; i < len(text); i++ {
+		if text[i] == '"' || text[i] == '\\' {
+			stringBuilder.WriteByte('\\')
+		}
+		stringBuilder.WriteByte(text[i])
+	}
+	stringBuilder.WriteByte('"')
+}
+
+// formatElementText formats the given element as text, returning quote = true if the element is
+// textual (a string or a TextMarshaler), and so may need quoting.
+func formatElementText[E comparable](element E) (text string, quote bool, err error) {
+	if marshaler, ok := any(element).(encoding.TextMarshaler); ok {
+		bytes, err := marshaler.MarshalText()
+		if err != nil {
+			return "", false, err
+		}
+		return string(bytes), true, nil
+	}
+
+	value := reflect.ValueOf(&element).Elem()
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), false, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		return strconv.FormatUint(value.Uint(), 10), false, nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'g', -1, value.Type().Bits()), false, nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), false, nil
+	default:
+		return "", false, fmt.Errorf("unsupported element type %v", value.Type())
+	}
+}
+
+// parseElementText parses the given text into an element of type E, supporting the same element
+// types as formatElementText.
+func parseElementText[E comparable](text string) (E, error) {
+	var element E
+
+	if unmarshaler, ok := any(&element).(encoding.TextUnmarshaler); ok {
+		err := unmarshaler.UnmarshalText([]byte(text))
+		return element, err
+	}
+
+	value := reflect.ValueOf(&element).Elem()
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(text)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(text, 10, value.Type().Bits())
+		if err != nil {
+			return element, err
+		}
+		value.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		parsed, err := strconv.ParseUint(text, 10, value.Type().Bits())
+		if err != nil {
+			return element, err
+		}
+		value.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(text, value.Type().Bits())
+		if err != nil {
+			return element, err
+		}
+		value.SetFloat(parsed)
+	case reflect.Bool:
+		switch strings.ToLower(text) {
+		case "t", "true":
+			value.SetBool(true)
+		case "f", "false":
+			value.SetBool(false)
+		default:
+			return element, fmt.Errorf("invalid bool value '%s'", text)
+		}
+	default:
+		return element, fmt.Errorf("unsupported element type %v", value.Type())
+	}
+
+	return element, nil
+}
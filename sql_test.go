@@ -0,0 +1,63 @@
+package set_test
+
+import (
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestSQLRoundTrip(t *testing.T) {
+	original := set.HashSetOf(`a`, `b c`, `quote"d`, `back\slash`, `comma,`)
+
+	value, err := original.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var scanned set.ArraySet[string]
+	if err := scanned.Scan(value); err != nil {
+		t.Fatal(err)
+	}
+
+	if !scanned.Equals(original) {
+		t.Errorf("expected %v to equal %v after SQL round trip via %v", scanned, original, value)
+	}
+}
+
+func TestSQLValue(t *testing.T) {
+	value, err := set.ArraySetOf(1, 2, 3).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value != "{1,2,3}" {
+		t.Errorf("expected SQL value {1,2,3}, got %v", value)
+	}
+}
+
+func TestSQLScan(t *testing.T) {
+	var ints set.DynamicSet[int]
+	if err := ints.Scan([]byte("{1, 2,3}")); err != nil {
+		t.Fatal(err)
+	}
+	assertSize(t, ints, 3)
+	assertContains(t, ints, 1, 2, 3)
+
+	if err := ints.Scan(nil); err != nil {
+		t.Fatal(err)
+	}
+	assertSize(t, ints, 0)
+
+	for _, invalid := range []any{"1,2,3", "{1,NULL}", `{"1}`, "{a}", "{1,}", "{1,,2}", 5} {
+		if err := ints.Scan(invalid); err == nil {
+			t.Errorf("expected error when scanning invalid value %v", invalid)
+		}
+	}
+
+	var strs set.ArraySet[string]
+	for _, invalid := range []string{`{"a",}`, "{a, }", "{a,,b}"} {
+		if err := strs.Scan(invalid); err == nil {
+			t.Errorf("expected error when scanning invalid value %v", invalid)
+		}
+	}
+}
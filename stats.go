@@ -0,0 +1,152 @@
+package set
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// Stats describes the internal state of a set, for deciding capacity hints and DynamicSet
+// thresholds from production data. It is returned by the Stats method on [ArraySet], [HashSet],
+// [DynamicSet], [OpenHashSet] and [SynchronizedSet].
+type Stats struct {
+	// Storage is the kind of backing storage currently used by the set.
+	Storage StorageKind
+
+	// Size is the number of elements in the set.
+	Size int
+
+	// Capacity is the number of elements the set can hold before its backing storage must grow.
+	//
+	// Go maps do not expose their capacity, so for [HashSet], this is an estimate of the capacity
+	// of a map that has grown to the set's current size by adding elements. Maps that were created
+	// with a larger capacity, or that have had elements removed, may have more capacity.
+	Capacity int
+
+	// LoadFactor is the fraction of hash table slots that are in use, for hash storage. For
+	// [HashSet], this is estimated like Capacity. For array storage, it is 0.
+	LoadFactor float64
+
+	// SizeThreshold and DowngradeThreshold are the thresholds at which a [DynamicSet] transforms
+	// between array and hash storage. They are 0 for other set types.
+	SizeThreshold      int
+	DowngradeThreshold int
+}
+
+// StorageKind is the kind of backing storage used by a set, as reported by [Stats].
+type StorageKind int
+
+const (
+	// StorageArray is a slice of elements, searched linearly.
+	StorageArray StorageKind = iota + 1
+
+	// StorageHash is a hash table.
+	StorageHash
+)
+
+// String returns the name of the storage kind, implementing [fmt.Stringer].
+func (kind StorageKind) String() string {
+	switch kind {
+	case StorageArray:
+		return "StorageArray"
+	case StorageHash:
+		return "StorageHash"
+	default:
+		return fmt.Sprintf("StorageKind(%d)", int(kind))
+	}
+}
+
+// Stats returns diagnostics about the set's backing storage.
+func (set ArraySet[E]) Stats() Stats {
+	return Stats{Storage: StorageArray, Size: len(set.elements), Capacity: cap(set.elements)}
+}
+
+// Stats returns diagnostics about the set's backing storage. Since Go maps do not expose their
+// capacity, Capacity and LoadFactor are estimates (see [Stats]).
+func (set HashSet[E]) Stats() Stats {
+	slots := estimatedMapSlots(len(set.elements))
+
+	// Small maps with a single group can fill all of its slots.
+	capacity := slots
+	if slots > goMapGroupSize {
+		capacity = slots * goMapMaxLoadNumerator / goMapMaxLoadDenominator
+	}
+
+	stats := Stats{Storage: StorageHash, Size: len(set.elements), Capacity: capacity}
+	if slots != 0 {
+		stats.LoadFactor = float64(len(set.elements)) / float64(slots)
+	}
+	return stats
+}
+
+// Stats returns diagnostics about the set's current backing storage, and its transformation
+// thresholds.
+func (set DynamicSet[E]) Stats() Stats {
+	var stats Stats
+	if set.IsArraySet() {
+		stats = set.array.Stats()
+	} else {
+		stats = set.hash.Stats()
+	}
+
+	stats.SizeThreshold = set.SizeThreshold()
+	stats.DowngradeThreshold = set.DowngradeThreshold()
+	return stats
+}
+
+// Stats returns diagnostics about the set's backing storage. Unlike for [HashSet], Capacity and
+// LoadFactor are exact. Slots holding tombstones of removed elements count towards LoadFactor,
+// since they are only reclaimed when the set is rehashed.
+func (set OpenHashSet[E]) Stats() Stats {
+	slots := len(set.groups) * openHashGroupSize
+
+	stats := Stats{
+		Storage:  StorageHash,
+		Size:     set.size,
+		Capacity: len(set.groups)*openHashMaxLoadPerGroup - set.tombstones,
+	}
+	if slots != 0 {
+		stats.LoadFactor = float64(set.size+set.tombstones) / float64(slots)
+	}
+	return stats
+}
+
+// Stats returns diagnostics about the wrapped set's backing storage, under the set's read lock. If
+// the wrapped set does not have a Stats method, only Size is set.
+func (set *SynchronizedSet[E]) Stats() Stats {
+	set.lock.RLock()
+	defer set.lock.RUnlock()
+
+	// After Watch, inner is an ObservableSet wrapping the original set.
+	inner := set.inner
+	if set.observed != nil {
+		inner = set.observed.inner
+	}
+
+	if statsSet, ok := inner.(interface{ Stats() Stats }); ok {
+		return statsSet.Stats()
+	}
+	return Stats{Size: inner.Size()}
+}
+
+const (
+	// The max load factor of Go's swiss table maps (7/8), and their slots per group (8), used to
+	// estimate map capacity for HashSet.Stats.
+	goMapMaxLoadNumerator   = 7
+	goMapMaxLoadDenominator = 8
+	goMapGroupSize          = 8
+)
+
+// estimatedMapSlots estimates the number of slots in a Go map that has grown to the given size.
+// Small maps use a single group, and larger maps grow their table by doubling when the max load
+// factor is reached.
+func estimatedMapSlots(size int) int {
+	if size == 0 {
+		return 0
+	}
+	if size <= goMapGroupSize {
+		return goMapGroupSize
+	}
+
+	minSlots := (size*goMapMaxLoadDenominator + goMapMaxLoadNumerator - 1) / goMapMaxLoadNumerator
+	return 1 << bits.Len(uint(minSlots-1))
+}
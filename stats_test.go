@@ -0,0 +1,86 @@
+package set_test
+
+import (
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestStats(t *testing.T) {
+	arraySet := set.ArraySetWithCapacity[int](10)
+	arraySet.AddMultiple(1, 2, 3)
+	if stats := arraySet.Stats(); stats.Storage != set.StorageArray || stats.Size != 3 ||
+		stats.Capacity != 10 {
+		t.Errorf("unexpected ArraySet stats: %+v", stats)
+	}
+
+	hashSet := set.HashSetFromSlice(createRandomIntSlice(100))
+	stats := hashSet.Stats()
+	if stats.Storage != set.StorageHash || stats.Size != 100 || stats.Capacity < 100 ||
+		stats.LoadFactor <= 0 || stats.LoadFactor > 1 {
+		t.Errorf("unexpected HashSet stats: %+v", stats)
+	}
+
+	dynamicSet := set.DynamicSetOf(1, 2, 3)
+	stats = dynamicSet.Stats()
+	if stats.Storage != set.StorageArray ||
+		stats.SizeThreshold != set.DefaultDynamicSetSizeThreshold ||
+		stats.DowngradeThreshold != set.DefaultDynamicSetSizeThreshold/2 {
+		t.Errorf("unexpected DynamicSet stats: %+v", stats)
+	}
+
+	openHashSet := set.OpenHashSetFromSlice(createRandomIntSlice(100))
+	stats = openHashSet.Stats()
+	if stats.Storage != set.StorageHash || stats.Size != 100 || stats.Capacity < 100 ||
+		stats.LoadFactor <= 0 || stats.LoadFactor > 1 {
+		t.Errorf("unexpected OpenHashSet stats: %+v", stats)
+	}
+}
+
+func TestCap(t *testing.T) {
+	arraySet := set.ArraySetWithCapacity[int](10)
+	arraySet.AddMultiple(1, 2, 3)
+	if capacity := arraySet.Cap(); capacity != 10 {
+		t.Errorf("expected ArraySet.Cap() == 10, got %d", capacity)
+	}
+
+	arraySet.Clip()
+	if capacity := arraySet.Cap(); capacity != 3 {
+		t.Errorf("expected ArraySet.Cap() == 3 after Clip, got %d", capacity)
+	}
+
+	hashSet := set.HashSetFromSlice(createRandomIntSlice(100))
+	if capacity := hashSet.Cap(); capacity < hashSet.Size() {
+		t.Errorf("expected HashSet.Cap() >= %d, got %d", hashSet.Size(), capacity)
+	}
+
+	openHashSet := set.NewOpenHashSet[int]()
+	openHashSet.Grow(50)
+	if capacity := openHashSet.Cap(); capacity < 50 {
+		t.Errorf("expected OpenHashSet.Cap() >= 50 after Grow(50), got %d", capacity)
+	}
+}
+
+func TestCopyCompact(t *testing.T) {
+	arraySet := set.ArraySetWithCapacity[int](100)
+	arraySet.AddMultiple(1, 2, 3)
+
+	compactArraySet := arraySet.CopyCompact()
+	assertSize(t, &compactArraySet, 3)
+	assertContains(t, &compactArraySet, 1, 2, 3)
+	if capacity := compactArraySet.Cap(); capacity != 3 {
+		t.Errorf("expected ArraySet.CopyCompact() to have capacity 3, got %d", capacity)
+	}
+
+	sortedSet := set.SortedArraySetWithCapacity[int](100)
+	sortedSet.AddMultiple(3, 1, 2)
+	if capacity := sortedSet.CopyCompact().Cap(); capacity != 3 {
+		t.Errorf("expected SortedArraySet.CopyCompact() to have capacity 3, got %d", capacity)
+	}
+
+	dynamicSet := set.DynamicSetWithCapacity[int](10)
+	dynamicSet.AddMultiple(1, 2)
+	if capacity := dynamicSet.CopyCompact().Cap(); capacity != 2 {
+		t.Errorf("expected DynamicSet.CopyCompact() to have capacity 2, got %d", capacity)
+	}
+}
@@ -0,0 +1,355 @@
+package set
+
+import (
+	"iter"
+	"strings"
+	"sync"
+)
+
+// A SynchronizedSet wraps another [Set] with a read-write mutex, making it safe for concurrent use
+// by multiple goroutines. This lets any set type be made thread-safe, without a dedicated concurrent
+// implementation for each. Unlike [CopyOnWriteSet], writes do not copy the set, but reads wait for
+// writes to finish.
+//
+// Methods that take another set hold the read or write lock while accessing the other set. If the
+// other set is also a SynchronizedSet, its lock is taken as well, so two goroutines calling such
+// methods on the same pair of sets in opposite order may deadlock. The wrapped set must not be
+// accessed directly after being passed to [Synchronize].
+//
+// To receive changes to the set on a channel as they happen, use [SynchronizedSet.Watch].
+//
+// SynchronizedSet implements [Set] and [ComparableSet] when passed by pointer.
+type SynchronizedSet[E comparable] struct {
+	lock  sync.RWMutex
+	inner Set[E]
+
+	// observed wraps the original inner set once Watch has been called, and is then also stored in
+	// inner, so that changes are sent to watchers.
+	observed *ObservableSet[E]
+	watchers []*Watcher[E]
+}
+
+// Synchronize wraps the given set in a [SynchronizedSet], making it safe for concurrent use.
+//
+// The wrapped set must not be accessed directly afterwards, as that would bypass the lock.
+func Synchronize[E comparable](set Set[E]) *SynchronizedSet[E] {
+	return &SynchronizedSet[E]{inner: set}
+}
+
+// Add adds the given element to the set.
+// If the element is already present in the set, Add is a no-op.
+func (set *SynchronizedSet[E]) Add(element E) {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	set.inner.Add(element)
+}
+
+// AddStrict adds the given element to the set, or returns an [ElementExistsError] if the element
+// is already present. Unlike Add, this does not silently accept adding an element twice, which is
+// useful for enforcing uniqueness, such as when claiming IDs.
+//
+// The check and the change are made under the same lock, so of concurrent calls with the same
+// element, only one succeeds.
+func (set *SynchronizedSet[E]) AddStrict(element E) error {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+
+	if set.inner.Contains(element) {
+		return ElementExistsError[E]{Element: element}
+	}
+
+	set.inner.Add(element)
+	return nil
+}
+
+// TryAdd adds the given element to the set, unless the set already holds maxSize elements, in
+// which case it returns a [SetFullError] without adding it. Adding an element that is already
+// present is a no-op, and succeeds even when the set is full. This is useful for enforcing limits,
+// such as a maximum number of labels per tenant, without checking Size around every Add.
+//
+// The check and the change are made under the same lock, so concurrent calls never bring the
+// set above maxSize.
+func (set *SynchronizedSet[E]) TryAdd(element E, maxSize int) error {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+
+	if set.inner.Contains(element) {
+		return nil
+	}
+	if set.inner.Size() >= maxSize {
+		return SetFullError[E]{Element: element, MaxSize: maxSize}
+	}
+
+	set.inner.Add(element)
+	return nil
+}
+
+// AddMultiple adds the given elements to the set. Duplicate elements are added only once, and
+// elements already present in the set are not added.
+func (set *SynchronizedSet[E]) AddMultiple(elements ...E) {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	set.inner.AddMultiple(elements...)
+}
+
+// AddFromSlice adds the elements from the given slice to the set. Duplicate elements are added only
+// once, and elements already present in the set are not added.
+func (set *SynchronizedSet[E]) AddFromSlice(elements []E) {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	set.inner.AddFromSlice(elements)
+}
+
+// AddFromSet adds elements from the given other set to the set.
+func (set *SynchronizedSet[E]) AddFromSet(otherSet ComparableSet[E]) {
+	if set.isSameSet(otherSet) {
+		return
+	}
+
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	set.inner.AddFromSet(otherSet)
+}
+
+// AddFromSeq adds the elements from the given iterator to the set. The write lock is held while
+// consuming the iterator, so the iterator must not access the set.
+func (set *SynchronizedSet[E]) AddFromSeq(seq iter.Seq[E]) {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	set.inner.AddFromSeq(seq)
+}
+
+// Remove removes the given element from the set.
+// If the element is not present in the set, Remove is a no-op.
+func (set *SynchronizedSet[E]) Remove(element E) {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	set.inner.Remove(element)
+}
+
+// RemoveStrict removes the given element from the set, or returns an [ElementNotFoundError] if
+// the element is not present. Unlike Remove, this does not silently accept removing a missing
+// element.
+//
+// The check and the change are made under the same lock, so of concurrent calls with the same
+// element, only one succeeds.
+func (set *SynchronizedSet[E]) RemoveStrict(element E) error {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+
+	if !set.inner.Contains(element) {
+		return ElementNotFoundError[E]{Element: element}
+	}
+
+	set.inner.Remove(element)
+	return nil
+}
+
+// Clear removes all elements from the set.
+func (set *SynchronizedSet[E]) Clear() {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	set.inner.Clear()
+}
+
+// Grow increases the set's capacity, if necessary, to guarantee room for another n elements.
+//
+// Panics if n is negative.
+func (set *SynchronizedSet[E]) Grow(n int) {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	set.inner.Grow(n)
+}
+
+// Contains checks if given element is present in the set.
+func (set *SynchronizedSet[E]) Contains(element E) bool {
+	set.lock.RLock()
+	defer set.lock.RUnlock()
+	return set.inner.Contains(element)
+}
+
+// Size returns the number of elements in the set.
+func (set *SynchronizedSet[E]) Size() int {
+	set.lock.RLock()
+	defer set.lock.RUnlock()
+	return set.inner.Size()
+}
+
+// IsEmpty checks if there are 0 elements in the set.
+func (set *SynchronizedSet[E]) IsEmpty() bool {
+	set.lock.RLock()
+	defer set.lock.RUnlock()
+	return set.inner.IsEmpty()
+}
+
+// Equals checks if the set contains exactly the same elements as the other given set.
+func (set *SynchronizedSet[E]) Equals(otherSet ComparableSet[E]) bool {
+	if set.isSameSet(otherSet) {
+		return true
+	}
+
+	set.lock.RLock()
+	defer set.lock.RUnlock()
+	return set.inner.Equals(otherSet)
+}
+
+// IsSubsetOf checks if all of the elements in the set exist in the other given set.
+func (set *SynchronizedSet[E]) IsSubsetOf(otherSet ComparableSet[E]) bool {
+	if set.isSameSet(otherSet) {
+		return true
+	}
+
+	set.lock.RLock()
+	defer set.lock.RUnlock()
+	return set.inner.IsSubsetOf(otherSet)
+}
+
+// IsSupersetOf checks if the set contains all of the elements in the other given set.
+func (set *SynchronizedSet[E]) IsSupersetOf(otherSet ComparableSet[E]) bool {
+	if set.isSameSet(otherSet) {
+		return true
+	}
+
+	set.lock.RLock()
+	defer set.lock.RUnlock()
+	return otherSet.IsSubsetOf(set.inner)
+}
+
+// Union creates a new set that contains all the elements of the receiver set and the other given
+// set. The underlying type of the returned set is a *SynchronizedSet, wrapping the result of Union
+// on the wrapped set.
+func (set *SynchronizedSet[E]) Union(otherSet ComparableSet[E]) Set[E] {
+	set.lock.RLock()
+	defer set.lock.RUnlock()
+
+	if set.isSameSet(otherSet) {
+		return Synchronize(set.inner.Copy())
+	}
+	return Synchronize(set.inner.Union(otherSet))
+}
+
+// Intersection creates a new set with only the elements that exist in both the receiver set and the
+// other given set. The underlying type of the returned set is a *SynchronizedSet, wrapping the
+// result of Intersection on the wrapped set.
+func (set *SynchronizedSet[E]) Intersection(otherSet ComparableSet[E]) Set[E] {
+	set.lock.RLock()
+	defer set.lock.RUnlock()
+
+	if set.isSameSet(otherSet) {
+		return Synchronize(set.inner.Copy())
+	}
+	return Synchronize(set.inner.Intersection(otherSet))
+}
+
+// ToSlice creates a slice with all the elements in the set.
+func (set *SynchronizedSet[E]) ToSlice() []E {
+	set.lock.RLock()
+	defer set.lock.RUnlock()
+	return set.inner.ToSlice()
+}
+
+// AppendTo appends the elements in the set to the given slice, and returns the extended slice,
+// like the append builtin. Unlike ToSlice, this lets the caller reuse a buffer across calls.
+func (set *SynchronizedSet[E]) AppendTo(slice []E) []E {
+	set.lock.RLock()
+	defer set.lock.RUnlock()
+	return set.inner.AppendTo(slice)
+}
+
+// ToMap creates a map with all the set's elements as keys.
+//
+// Unlike [HashSet.ToMap], the returned map is always a copy, since the set's storage must not be
+// accessed without holding the lock.
+func (set *SynchronizedSet[E]) ToMap() map[E]struct{} {
+	set.lock.RLock()
+	defer set.lock.RUnlock()
+
+	m := make(map[E]struct{}, set.inner.Size())
+	for element := range set.inner.All() {
+		m[element] = struct{}{}
+	}
+	return m
+}
+
+// Copy creates a new set with all the same elements as the original set. The underlying type of
+// the returned set is a *SynchronizedSet, wrapping a copy of the wrapped set.
+func (set *SynchronizedSet[E]) Copy() Set[E] {
+	set.lock.RLock()
+	defer set.lock.RUnlock()
+	return Synchronize(set.inner.Copy())
+}
+
+// String returns a string representation of the set, implementing [fmt.Stringer].
+//
+// The string is the string of the wrapped set, prefixed by "Synchronized". A SynchronizedSet
+// wrapping an ArraySet of elements 1, 2 and 3 will be printed as: SynchronizedArraySet{1, 2, 3}
+func (set *SynchronizedSet[E]) String() string {
+	set.lock.RLock()
+	defer set.lock.RUnlock()
+
+	inner := set.inner
+	if set.observed != nil {
+		inner = set.observed.inner
+	}
+
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString("Synchronized")
+	stringBuilder.WriteString(inner.String())
+	return stringBuilder.String()
+}
+
+// All returns an iterator over the elements in the set, which can be used in a for-range loop:
+//
+//	for element := range set.All() {
+//		fmt.Println(element)
+//	}
+//
+// The read lock is held for the duration of the iteration, so writers wait until the loop is done.
+// The loop body must therefore not modify the set, as that would deadlock. To run arbitrary code in
+// the loop body, use [SynchronizedSet.AllSnapshot] instead.
+//
+// Since sets are unordered, iteration order is non-deterministic.
+func (set *SynchronizedSet[E]) All() iter.Seq[E] {
+	return func(yield func(element E) bool) {
+		set.lock.RLock()
+		defer set.lock.RUnlock()
+
+		for element := range set.inner.All() {
+			if !yield(element) {
+				break
+			}
+		}
+	}
+}
+
+// AllSnapshot returns an iterator over a snapshot of the elements in the set, taken when iteration
+// starts. Unlike All, the lock is only held while taking the snapshot, so the loop body may modify
+// the set, and writers are not blocked by slow loop bodies:
+//
+//	for element := range set.AllSnapshot() {
+//		if shouldRemove(element) {
+//			set.Remove(element)
+//		}
+//	}
+//
+// Every element that is present in the set for the whole iteration is yielded exactly once.
+// Elements added during iteration are not yielded, and elements removed during iteration may still
+// be yielded. Taking the snapshot copies the elements, so this costs an allocation of O(n).
+//
+// Since sets are unordered, iteration order is non-deterministic.
+func (set *SynchronizedSet[E]) AllSnapshot() iter.Seq[E] {
+	return func(yield func(element E) bool) {
+		for _, element := range set.ToSlice() {
+			if !yield(element) {
+				break
+			}
+		}
+	}
+}
+
+// isSameSet checks if the given other set is the receiver itself, in which case methods must not
+// take the receiver's lock again through the other set's methods.
+func (set *SynchronizedSet[E]) isSameSet(otherSet ComparableSet[E]) bool {
+	other, ok := otherSet.(*SynchronizedSet[E])
+	return ok && other == set
+}
@@ -0,0 +1,85 @@
+package set_test
+
+import (
+	"sync"
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestSynchronizedSetConcurrentAccess(t *testing.T) {
+	synchronizedSet := set.Synchronize[int](&set.ArraySet[int]{})
+
+	var waitGroup sync.WaitGroup
+	for goroutine := range 8 {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+
+			for i := range 100 {
+				synchronizedSet.Add(goroutine*100 + i)
+				synchronizedSet.Contains(i)
+				for range synchronizedSet.All() {
+					break
+				}
+			}
+		}()
+	}
+	waitGroup.Wait()
+
+	assertSize(t, synchronizedSet, 800)
+}
+
+func TestSynchronizedSetOperationsWithItself(t *testing.T) {
+	synchronizedSet := set.Synchronize[int](&set.HashSet[int]{})
+	synchronizedSet.AddMultiple(1, 2, 3)
+
+	synchronizedSet.AddFromSet(synchronizedSet)
+	assertSize(t, synchronizedSet, 3)
+
+	if !synchronizedSet.Equals(synchronizedSet) {
+		t.Errorf("expected %v to equal itself", synchronizedSet)
+	}
+
+	union := synchronizedSet.Union(synchronizedSet)
+	assertSize(t, union, 3)
+	assertContains(t, union, 1, 2, 3)
+}
+
+func TestSynchronizedSetAllSnapshot(t *testing.T) {
+	synchronizedSet := set.Synchronize[int](&set.HashSet[int]{})
+	synchronizedSet.AddMultiple(1, 2, 3, 4)
+
+	yielded := 0
+	for element := range synchronizedSet.AllSnapshot() {
+		// Modifying the set in the loop body would deadlock with All.
+		synchronizedSet.Remove(element)
+		synchronizedSet.Add(element + 10)
+		yielded++
+	}
+
+	if yielded != 4 {
+		t.Errorf("expected snapshot iteration to yield 4 elements, got %d", yielded)
+	}
+	assertSize(t, synchronizedSet, 4)
+	assertContains(t, synchronizedSet, 11, 12, 13, 14)
+}
+
+func TestSynchronizedSetTryAddIsAtomic(t *testing.T) {
+	synchronizedSet := set.Synchronize[int](&set.HashSet[int]{})
+
+	var waitGroup sync.WaitGroup
+	for goroutine := range 8 {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+
+			for i := range 100 {
+				_ = synchronizedSet.TryAdd(goroutine*100+i, 10)
+			}
+		}()
+	}
+	waitGroup.Wait()
+
+	assertSize(t, synchronizedSet, 10)
+}
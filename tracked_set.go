@@ -0,0 +1,92 @@
+package set
+
+import (
+	"strings"
+)
+
+// A TrackedSet wraps another [Set], and records the elements added to and removed from the set
+// since the last call to [TrackedSet.Flush]. This is useful for syncing set membership to another
+// system, by sending only what changed since the last sync, without keeping a full copy of the
+// previously synced set.
+//
+// Changes are recorded as a net delta: an element that is added and then removed again between
+// flushes is in neither the added nor the removed set, and likewise for an element that is removed
+// and then added again.
+//
+// TrackedSet embeds an [ObservableSet], and so has all of its methods, including OnAdd and OnRemove
+// for registering further hooks. Like ObservableSet, it is not safe for concurrent use, and the
+// wrapped set must not be modified directly after being passed to [Track].
+//
+// TrackedSet implements [Set] and [ComparableSet] when passed by pointer.
+type TrackedSet[E comparable] struct {
+	ObservableSet[E]
+	added   *DynamicSet[E]
+	removed *DynamicSet[E]
+}
+
+// Track wraps the given set in a [TrackedSet], to record changes to it. Elements already in the
+// set are not recorded as added.
+//
+// The wrapped set must not be modified directly afterwards, as that would bypass the tracking.
+func Track[E comparable](set Set[E]) *TrackedSet[E] {
+	added, removed := NewDynamicSet[E](), NewDynamicSet[E]()
+	tracked := &TrackedSet[E]{
+		ObservableSet: ObservableSet[E]{inner: set},
+		added:         &added,
+		removed:       &removed,
+	}
+
+	tracked.OnAdd(func(element E) {
+		if tracked.removed.Contains(element) {
+			tracked.removed.Remove(element)
+		} else {
+			tracked.added.Add(element)
+		}
+	})
+	tracked.OnRemove(func(element E) {
+		if tracked.added.Contains(element) {
+			tracked.added.Remove(element)
+		} else {
+			tracked.removed.Add(element)
+		}
+	})
+
+	return tracked
+}
+
+// Changes returns the elements that have been added to and removed from the set since the last
+// call to Flush (or since the set was created by [Track]), without resetting them. The underlying
+// types of the returned sets are *DynamicSet, and they are copies that are not affected by later
+// changes.
+func (set *TrackedSet[E]) Changes() (added Set[E], removed Set[E]) {
+	return set.added.Copy(), set.removed.Copy()
+}
+
+// Flush returns the elements that have been added to and removed from the set since the last call
+// to Flush (or since the set was created by [Track]), and resets the recorded changes. The
+// underlying types of the returned sets are *DynamicSet. The returned sets are handed over to the
+// caller, and new sets are used to record later changes.
+func (set *TrackedSet[E]) Flush() (added Set[E], removed Set[E]) {
+	added, removed = set.added, set.removed
+
+	newAdded, newRemoved := NewDynamicSet[E](), NewDynamicSet[E]()
+	set.added, set.removed = &newAdded, &newRemoved
+
+	return added, removed
+}
+
+// HasChanges checks if any elements have been added or removed since the last call to Flush.
+func (set *TrackedSet[E]) HasChanges() bool {
+	return !set.added.IsEmpty() || !set.removed.IsEmpty()
+}
+
+// String returns a string representation of the set, implementing [fmt.Stringer].
+//
+// The string is the string of the wrapped set, prefixed by "Tracked". A TrackedSet wrapping an
+// ArraySet of elements 1, 2 and 3 will be printed as: TrackedArraySet{1, 2, 3}
+func (set *TrackedSet[E]) String() string {
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString("Tracked")
+	stringBuilder.WriteString(set.inner.String())
+	return stringBuilder.String()
+}
@@ -0,0 +1,64 @@
+package set_test
+
+import (
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestTrackedSetChanges(t *testing.T) {
+	initial := set.HashSetOf(1, 2, 3)
+	trackedSet := set.Track[int](&initial)
+
+	if trackedSet.HasChanges() {
+		t.Errorf("expected no changes for initial elements, got %v", trackedSet)
+	}
+
+	trackedSet.AddMultiple(3, 4, 5)
+	trackedSet.Remove(1)
+	trackedSet.Remove(5)
+	trackedSet.Remove(2)
+	trackedSet.Add(2)
+
+	added, removed := trackedSet.Changes()
+	assertSize(t, added, 1)
+	assertContains(t, added, 4)
+	assertSize(t, removed, 1)
+	assertContains(t, removed, 1)
+
+	added, removed = trackedSet.Flush()
+	assertSize(t, added, 1)
+	assertSize(t, removed, 1)
+	if trackedSet.HasChanges() {
+		t.Errorf("expected no changes after Flush, got %v", trackedSet)
+	}
+
+	trackedSet.Clear()
+	added, removed = trackedSet.Changes()
+	assertSize(t, added, 0)
+	assertSize(t, removed, 3)
+	assertContains(t, removed, 2, 3, 4)
+}
+
+func TestTrackedSetFlushedSetsAreIndependent(t *testing.T) {
+	initial := set.HashSetOf(1, 2)
+	trackedSet := set.Track[int](&initial)
+	trackedSet.Add(3)
+	trackedSet.Remove(1)
+
+	added, removed := trackedSet.Flush()
+	added.Add(10)
+	added.Clear()
+	removed.Add(20)
+	removed.Remove(1)
+
+	assertSize(t, added, 0)
+	assertSize(t, removed, 1)
+	assertContains(t, removed, 20)
+
+	trackedSet.Add(4)
+	added, removed = trackedSet.Changes()
+	assertSize(t, added, 1)
+	assertContains(t, added, 4)
+	assertSize(t, removed, 0)
+}
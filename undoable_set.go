@@ -0,0 +1,206 @@
+package set
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// An UndoableSet wraps another [Set], and keeps a history of changes to it that can be stepped
+// through with [UndoableSet.Undo] and [UndoableSet.Redo]. This is useful for editor-style features,
+// such as tag selections or filter chips, where users expect to undo changes to a selection.
+//
+// Each call to a method that modifies the set is one step in the history, so undoing an
+// AddFromSlice or a Clear reverts all of its changes at once. Calls that do not change the set,
+// such as adding an element that is already present, are not recorded. Making a new change after
+// undoing discards the steps that could have been redone. The history holds at most the max depth
+// given to [NewUndoableSet], discarding the oldest steps beyond that.
+//
+// UndoableSet embeds an [ObservableSet], and so has all of its methods, including OnAdd and
+// OnRemove for registering further hooks, which are also called for changes made by Undo and Redo.
+// Like ObservableSet, it is not safe for concurrent use, and the wrapped set must not be modified
+// directly after being passed to NewUndoableSet.
+//
+// UndoableSet implements [Set] and [ComparableSet] when passed by pointer.
+type UndoableSet[E comparable] struct {
+	ObservableSet[E]
+	maxDepth  int
+	undoSteps []undoStep[E]
+	redoSteps []undoStep[E]
+	recording *undoStep[E]
+}
+
+// undoStep is the elements added and removed by a single change to an UndoableSet.
+type undoStep[E comparable] struct {
+	added   []E
+	removed []E
+}
+
+// NewUndoableSet wraps the given set in an [UndoableSet], which keeps a history of up to maxDepth
+// changes. Elements already in the set are not part of the history.
+//
+// The wrapped set must not be modified directly afterwards, as that would bypass the history.
+//
+// Panics if maxDepth is less than 1.
+func NewUndoableSet[E comparable](set Set[E], maxDepth int) *UndoableSet[E] {
+	if maxDepth < 1 {
+		panic(fmt.Sprintf("set: UndoableSet max depth must be at least 1, got %d", maxDepth))
+	}
+
+	undoable := &UndoableSet[E]{ObservableSet: ObservableSet[E]{inner: set}, maxDepth: maxDepth}
+
+	undoable.OnAdd(func(element E) {
+		if undoable.recording != nil {
+			undoable.recording.added = append(undoable.recording.added, element)
+		}
+	})
+	undoable.OnRemove(func(element E) {
+		if undoable.recording != nil {
+			undoable.recording.removed = append(undoable.recording.removed, element)
+		}
+	})
+
+	return undoable
+}
+
+// MaxDepth returns the maximum number of changes that the set keeps in its history.
+func (set *UndoableSet[E]) MaxDepth() int {
+	return set.maxDepth
+}
+
+// Undo reverts the most recent change to the set that has not already been undone. It returns
+// false if there is no change to undo.
+func (set *UndoableSet[E]) Undo() bool {
+	if len(set.undoSteps) == 0 {
+		return false
+	}
+
+	step := set.undoSteps[len(set.undoSteps)-1]
+	set.undoSteps = set.undoSteps[:len(set.undoSteps)-1]
+
+	for _, element := range step.added {
+		set.ObservableSet.Remove(element)
+	}
+	for _, element := range step.removed {
+		set.ObservableSet.Add(element)
+	}
+
+	set.redoSteps = append(set.redoSteps, step)
+	return true
+}
+
+// Redo reapplies the most recently undone change to the set. It returns false if there is no change
+// to redo, either because nothing has been undone, or because the set has been changed since.
+func (set *UndoableSet[E]) Redo() bool {
+	if len(set.redoSteps) == 0 {
+		return false
+	}
+
+	step := set.redoSteps[len(set.redoSteps)-1]
+	set.redoSteps = set.redoSteps[:len(set.redoSteps)-1]
+
+	for _, element := range step.removed {
+		set.ObservableSet.Remove(element)
+	}
+	for _, element := range step.added {
+		set.ObservableSet.Add(element)
+	}
+
+	set.undoSteps = append(set.undoSteps, step)
+	return true
+}
+
+// CanUndo checks if there is a change that can be reverted with Undo.
+func (set *UndoableSet[E]) CanUndo() bool {
+	return len(set.undoSteps) != 0
+}
+
+// CanRedo checks if there is a change that can be reapplied with Redo.
+func (set *UndoableSet[E]) CanRedo() bool {
+	return len(set.redoSteps) != 0
+}
+
+// ClearHistory discards all changes that could be undone or redone, without modifying the set.
+func (set *UndoableSet[E]) ClearHistory() {
+	clear(set.undoSteps)
+	set.undoSteps = set.undoSteps[:0]
+	clear(set.redoSteps)
+	set.redoSteps = set.redoSteps[:0]
+}
+
+// Add adds the given element to the set.
+// If the element is already present in the set, Add is a no-op.
+func (set *UndoableSet[E]) Add(element E) {
+	set.record(func() { set.ObservableSet.Add(element) })
+}
+
+// AddMultiple adds the given elements to the set. Duplicate elements are added only once, and
+// elements already present in the set are not added.
+func (set *UndoableSet[E]) AddMultiple(elements ...E) {
+	set.record(func() { set.ObservableSet.AddFromSlice(elements) })
+}
+
+// AddFromSlice adds the elements from the given slice to the set. Duplicate elements are added only
+// once, and elements already present in the set are not added.
+func (set *UndoableSet[E]) AddFromSlice(elements []E) {
+	set.record(func() { set.ObservableSet.AddFromSlice(elements) })
+}
+
+// AddFromSet adds elements from the given other set to the set.
+func (set *UndoableSet[E]) AddFromSet(otherSet ComparableSet[E]) {
+	if other, ok := otherSet.(*UndoableSet[E]); ok && other == set {
+		return
+	}
+
+	set.record(func() { set.ObservableSet.AddFromSet(otherSet) })
+}
+
+// AddFromSeq adds the elements from the given iterator to the set.
+func (set *UndoableSet[E]) AddFromSeq(seq iter.Seq[E]) {
+	set.record(func() { set.ObservableSet.AddFromSeq(seq) })
+}
+
+// Remove removes the given element from the set.
+// If the element is not present in the set, Remove is a no-op.
+func (set *UndoableSet[E]) Remove(element E) {
+	set.record(func() { set.ObservableSet.Remove(element) })
+}
+
+// Clear removes all elements from the set. This can be undone like any other change.
+func (set *UndoableSet[E]) Clear() {
+	set.record(func() { set.ObservableSet.Clear() })
+}
+
+// String returns a string representation of the set, implementing [fmt.Stringer].
+//
+// The string is the string of the wrapped set, prefixed by "Undoable". An UndoableSet wrapping an
+// ArraySet of elements 1, 2 and 3 will be printed as: UndoableArraySet{1, 2, 3}
+func (set *UndoableSet[E]) String() string {
+	var stringBuilder strings.Builder
+	stringBuilder.WriteString("Undoable")
+	stringBuilder.WriteString(set.inner.String())
+	return stringBuilder.String()
+}
+
+// record calls the given function to modify the set, and records the elements it adds and removes
+// as a single step in the history.
+func (set *UndoableSet[E]) record(modify func()) {
+	var step undoStep[E]
+	set.recording = &step
+	defer func() { set.recording = nil }()
+
+	modify()
+
+	if len(step.added) == 0 && len(step.removed) == 0 {
+		return
+	}
+
+	if len(set.undoSteps) == set.maxDepth {
+		copy(set.undoSteps, set.undoSteps[1:])
+		set.undoSteps = set.undoSteps[:len(set.undoSteps)-1]
+	}
+	set.undoSteps = append(set.undoSteps, step)
+
+	clear(set.redoSteps)
+	set.redoSteps = set.redoSteps[:0]
+}
@@ -0,0 +1,56 @@
+package set_test
+
+import (
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestUndoableSetUndoRedo(t *testing.T) {
+	undoableSet := set.NewUndoableSet[int](&set.ArraySet[int]{}, 10)
+
+	undoableSet.AddMultiple(1, 2, 3)
+	undoableSet.Add(3) // Already present, so not recorded.
+	undoableSet.Remove(1)
+	undoableSet.Clear()
+	assertSize(t, undoableSet, 0)
+
+	if !undoableSet.Undo() {
+		t.Fatal("expected Undo of Clear to succeed")
+	}
+	assertSize(t, undoableSet, 2)
+	assertContains(t, undoableSet, 2, 3)
+
+	undoableSet.Undo()
+	assertSize(t, undoableSet, 3)
+	assertContains(t, undoableSet, 1, 2, 3)
+
+	undoableSet.Undo()
+	assertSize(t, undoableSet, 0)
+	if undoableSet.Undo() {
+		t.Error("expected Undo to fail with no more history")
+	}
+
+	undoableSet.Redo()
+	undoableSet.Redo()
+	assertSize(t, undoableSet, 2)
+	assertContains(t, undoableSet, 2, 3)
+
+	undoableSet.Add(4)
+	if undoableSet.CanRedo() {
+		t.Error("expected new change to discard redo history")
+	}
+}
+
+func TestUndoableSetMaxDepth(t *testing.T) {
+	undoableSet := set.NewUndoableSet[int](&set.HashSet[int]{}, 2)
+
+	undoableSet.Add(1)
+	undoableSet.Add(2)
+	undoableSet.Add(3)
+
+	for undoableSet.Undo() {
+	}
+	assertSize(t, undoableSet, 1)
+	assertContains(t, undoableSet, 1)
+}
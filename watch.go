@@ -0,0 +1,176 @@
+package set
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// A ChangeEvent describes an element being added to or removed from a set, as received from a
+// [Watcher].
+type ChangeEvent[E comparable] struct {
+	Kind    ChangeKind
+	Element E
+}
+
+// ChangeKind is the kind of change described by a [ChangeEvent].
+type ChangeKind int
+
+const (
+	// ChangeAdded is an element being added to the set.
+	ChangeAdded ChangeKind = iota + 1
+
+	// ChangeRemoved is an element being removed from the set.
+	ChangeRemoved
+)
+
+// String returns the name of the change kind, implementing [fmt.Stringer].
+func (kind ChangeKind) String() string {
+	switch kind {
+	case ChangeAdded:
+		return "ChangeAdded"
+	case ChangeRemoved:
+		return "ChangeRemoved"
+	default:
+		return fmt.Sprintf("ChangeKind(%d)", int(kind))
+	}
+}
+
+// BackpressurePolicy determines what a [Watcher] does when its channel's buffer is full, because
+// its receiver has fallen behind.
+type BackpressurePolicy int
+
+const (
+	// BackpressureBlock makes writers to the set wait until the receiver has room for the event.
+	// This guarantees that no events are lost, but a slow receiver slows down all writers.
+	BackpressureBlock BackpressurePolicy = iota
+
+	// BackpressureDrop discards events that do not fit in the buffer, counting them in
+	// [Watcher.Dropped]. Writers are never slowed down, but a receiver that sees a non-zero drop
+	// count must resynchronize with the full set.
+	BackpressureDrop
+)
+
+// String returns the name of the backpressure policy, implementing [fmt.Stringer].
+func (policy BackpressurePolicy) String() string {
+	switch policy {
+	case BackpressureBlock:
+		return "BackpressureBlock"
+	case BackpressureDrop:
+		return "BackpressureDrop"
+	default:
+		return fmt.Sprintf("BackpressurePolicy(%d)", int(policy))
+	}
+}
+
+// A Watcher receives events on a channel for changes to a [SynchronizedSet], as returned by
+// [SynchronizedSet.Watch]. Call [Watcher.Close] to stop receiving events.
+type Watcher[E comparable] struct {
+	set       *SynchronizedSet[E]
+	events    chan ChangeEvent[E]
+	policy    BackpressurePolicy
+	dropped   atomic.Int64
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Watch subscribes to changes to the set, returning a [Watcher] whose channel receives an event
+// for every element that is added to or removed from the set from now on. This lets changes be
+// pushed to other systems as they happen, without polling and diffing the set. The events of a
+// single change are sent in order, before the method that made the change returns.
+//
+// The watcher's channel has the given buffer size. When the buffer is full, the watcher follows
+// the given [BackpressurePolicy]. With BackpressureBlock, the receiver must not modify the set
+// while writers may be waiting on it, as that would deadlock.
+//
+// After the first call to Watch, the set checks whether each added or removed element is already
+// present, to only send events for actual changes, which has a small cost for every write.
+//
+// Panics if bufferSize is negative.
+func (set *SynchronizedSet[E]) Watch(bufferSize int, policy BackpressurePolicy) *Watcher[E] {
+	if bufferSize < 0 {
+		panic(fmt.Sprintf("set: Watch buffer size must be non-negative, got %d", bufferSize))
+	}
+
+	watcher := &Watcher[E]{
+		set:    set,
+		events: make(chan ChangeEvent[E], bufferSize),
+		policy: policy,
+		done:   make(chan struct{}),
+	}
+
+	set.lock.Lock()
+	defer set.lock.Unlock()
+
+	if set.observed == nil {
+		set.observed = Observe(set.inner)
+		set.observed.OnAdd(func(element E) {
+			set.notifyWatchers(ChangeEvent[E]{Kind: ChangeAdded, Element: element})
+		})
+		set.observed.OnRemove(func(element E) {
+			set.notifyWatchers(ChangeEvent[E]{Kind: ChangeRemoved, Element: element})
+		})
+		set.inner = set.observed
+	}
+
+	set.watchers = append(set.watchers, watcher)
+	return watcher
+}
+
+// Events returns the channel on which the watcher receives change events. The channel is closed
+// when the watcher is closed.
+func (watcher *Watcher[E]) Events() <-chan ChangeEvent[E] {
+	return watcher.events
+}
+
+// Dropped returns the number of events that have been discarded because the watcher's buffer was
+// full, with [BackpressureDrop].
+func (watcher *Watcher[E]) Dropped() int64 {
+	return watcher.dropped.Load()
+}
+
+// Close unsubscribes the watcher from the set, and closes its channel. Writers that are waiting for
+// room in the watcher's buffer stop waiting. Close may be called multiple times, and from any
+// goroutine.
+func (watcher *Watcher[E]) Close() {
+	watcher.closeOnce.Do(func() {
+		// Closing done before taking the lock releases writers blocked on sending to us, which
+		// hold the lock.
+		close(watcher.done)
+
+		watcher.set.lock.Lock()
+		defer watcher.set.lock.Unlock()
+
+		for i, candidate := range watcher.set.watchers {
+			if candidate == watcher {
+				watcher.set.watchers = append(watcher.set.watchers[:i], watcher.set.watchers[i+1:]...)
+				break
+			}
+		}
+
+		close(watcher.events)
+	})
+}
+
+func (watcher *Watcher[E]) send(event ChangeEvent[E]) {
+	switch watcher.policy {
+	case BackpressureDrop:
+		select {
+		case watcher.events <- event:
+		default:
+			watcher.dropped.Add(1)
+		}
+	default:
+		select {
+		case watcher.events <- event:
+		case <-watcher.done:
+		}
+	}
+}
+
+// notifyWatchers sends the given event to all watchers. It must be called with the write lock held.
+func (set *SynchronizedSet[E]) notifyWatchers(event ChangeEvent[E]) {
+	for _, watcher := range set.watchers {
+		watcher.send(event)
+	}
+}
@@ -0,0 +1,66 @@
+package set_test
+
+import (
+	"testing"
+
+	"hermannm.dev/set"
+)
+
+func TestWatch(t *testing.T) {
+	synchronizedSet := set.Synchronize[int](&set.HashSet[int]{})
+	synchronizedSet.Add(1)
+
+	watcher := synchronizedSet.Watch(10, set.BackpressureBlock)
+	synchronizedSet.AddMultiple(1, 2)
+	synchronizedSet.Remove(1)
+	synchronizedSet.Remove(3)
+	watcher.Close()
+	watcher.Close()
+
+	var events []set.ChangeEvent[int]
+	for event := range watcher.Events() {
+		events = append(events, event)
+	}
+
+	expected := []set.ChangeEvent[int]{
+		{Kind: set.ChangeAdded, Element: 2},
+		{Kind: set.ChangeRemoved, Element: 1},
+	}
+	if len(events) != len(expected) || events[0] != expected[0] || events[1] != expected[1] {
+		t.Errorf("expected events %v, got %v", expected, events)
+	}
+
+	if str := synchronizedSet.String(); str != "SynchronizedHashSet{2}" {
+		t.Errorf("unexpected string of watched set: %s", str)
+	}
+}
+
+func TestWatchDrop(t *testing.T) {
+	synchronizedSet := set.Synchronize[int](&set.ArraySet[int]{})
+
+	watcher := synchronizedSet.Watch(2, set.BackpressureDrop)
+	defer watcher.Close()
+
+	synchronizedSet.AddMultiple(1, 2, 3, 4, 5)
+	if dropped := watcher.Dropped(); dropped != 3 {
+		t.Errorf("expected 3 dropped events, got %d", dropped)
+	}
+}
+
+func TestWatchCloseUnblocksWriter(t *testing.T) {
+	synchronizedSet := set.Synchronize[int](&set.HashSet[int]{})
+	watcher := synchronizedSet.Watch(0, set.BackpressureBlock)
+
+	done := make(chan struct{})
+	go func() {
+		synchronizedSet.Add(1)
+		close(done)
+	}()
+
+	<-watcher.Events()
+	go synchronizedSet.Add(2)
+	watcher.Close()
+	<-done
+
+	assertContains(t, synchronizedSet, 1)
+}
@@ -0,0 +1,142 @@
+package set
+
+import (
+	"fmt"
+	"time"
+)
+
+// A WindowSet remembers elements for a sliding window of time, answering whether an element has
+// been seen within the last window duration. This is useful for deduplicating events that may be
+// delivered more than once within a time span, such as webhook deliveries:
+//
+//	deliveries := set.NewWindowSet[string](10*time.Minute, 10)
+//	if deliveries.Contains(delivery.ID) {
+//		return // Already handled
+//	}
+//	deliveries.Add(delivery.ID)
+//
+// Instead of storing an expiry time for each element, elements are stored in a ring of time
+// buckets, each covering an equal slice of the window. When the newest bucket's time slice has
+// passed, the oldest bucket is cleared and reused for new elements, which expires all its elements
+// at once, and keeps the memory of the bucket for reuse. This makes expiry O(1) amortized per
+// element, but the granularity of expiry is a bucket: an element is remembered for at least the
+// window duration, and at most the window plus the duration of one bucket. More buckets give more
+// precise expiry, at the cost of more lookups in Contains.
+//
+// A WindowSet must be created with [NewWindowSet]. It must not be copied after first use, and is
+// not safe for concurrent use.
+type WindowSet[E comparable] struct {
+	window         time.Duration
+	bucketDuration time.Duration
+	// buckets is a ring of sets, where buckets[current] holds the elements added since
+	// currentStart, and the preceding buckets (wrapping around) hold older elements.
+	buckets      []HashSet[E]
+	current      int
+	currentStart time.Time
+}
+
+// NewWindowSet creates a new [WindowSet], which remembers elements for the given window duration,
+// with expiry in steps of window/bucketCount.
+// It must not be copied after first use.
+//
+// Panics if the window is not positive, or if bucketCount is less than 1 or greater than the
+// number of nanoseconds in the window.
+func NewWindowSet[E comparable](window time.Duration, bucketCount int) WindowSet[E] {
+	if window <= 0 {
+		panic(fmt.Sprintf("set: WindowSet window must be positive, got %v", window))
+	}
+	if bucketCount < 1 || int64(bucketCount) > int64(window) {
+		panic(fmt.Sprintf(
+			"set: WindowSet bucket count must be between 1 and the window in nanoseconds, got %d",
+			bucketCount,
+		))
+	}
+
+	// One more bucket than the given count is kept, so that the elements of the oldest bucket are
+	// kept for a whole window even when the newest bucket has just started.
+	buckets := make([]HashSet[E], bucketCount+1)
+	for i := range buckets {
+		buckets[i] = NewHashSet[E]()
+	}
+
+	return WindowSet[E]{
+		window:         window,
+		bucketDuration: (window + time.Duration(bucketCount) - 1) / time.Duration(bucketCount),
+		buckets:        buckets,
+		current:        0,
+		currentStart:   time.Time{},
+	}
+}
+
+// Window returns the duration that the set remembers elements for.
+func (set WindowSet[E]) Window() time.Duration {
+	return set.window
+}
+
+// Add adds the given element to the set at the current time, so it is remembered for the next
+// window duration. If the element was already in the set, its window is restarted.
+func (set *WindowSet[E]) Add(element E) {
+	set.AddAt(element, time.Now())
+}
+
+// AddAt is like [WindowSet.Add], but adds the element at the given time instead of the current
+// time. Times should be non-decreasing across calls to AddAt and ContainsAt - an earlier time than
+// a previous call is treated as the time of the previous call.
+func (set *WindowSet[E]) AddAt(element E, now time.Time) {
+	set.advance(now)
+	set.buckets[set.current].Add(element)
+}
+
+// Contains checks if the given element has been added to the set within the last window duration.
+func (set *WindowSet[E]) Contains(element E) bool {
+	return set.ContainsAt(element, time.Now())
+}
+
+// ContainsAt is like [WindowSet.Contains], but checks at the given time instead of the current
+// time. See [WindowSet.AddAt] for how times are handled.
+func (set *WindowSet[E]) ContainsAt(element E, now time.Time) bool {
+	set.advance(now)
+
+	// Checks the newest bucket first, since recently added elements are the most likely to be seen
+	// again.
+	for i := range set.buckets {
+		bucket := (set.current - i + len(set.buckets)) % len(set.buckets)
+		if set.buckets[bucket].Contains(element) {
+			return true
+		}
+	}
+	return false
+}
+
+// Clear removes all elements from the set.
+func (set *WindowSet[E]) Clear() {
+	for i := range set.buckets {
+		set.buckets[i].Clear()
+	}
+}
+
+// advance rotates the buckets until the current bucket covers the given time, clearing the buckets
+// whose elements have expired.
+func (set *WindowSet[E]) advance(now time.Time) {
+	if set.currentStart.IsZero() {
+		set.currentStart = now
+		return
+	}
+
+	elapsed := now.Sub(set.currentStart)
+	if elapsed < set.bucketDuration {
+		return
+	}
+
+	steps := elapsed / set.bucketDuration
+	if steps >= time.Duration(len(set.buckets)) {
+		// All buckets have expired, so there is no need to rotate through each of them.
+		set.Clear()
+	} else {
+		for range int(steps) {
+			set.current = (set.current + 1) % len(set.buckets)
+			set.buckets[set.current].Clear()
+		}
+	}
+	set.currentStart = set.currentStart.Add(steps * set.bucketDuration)
+}
@@ -0,0 +1,59 @@
+package set_test
+
+import (
+	"testing"
+	"time"
+
+	"hermannm.dev/set"
+)
+
+func TestWindowSet(t *testing.T) {
+	windowSet := set.NewWindowSet[string](10*time.Minute, 10)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	windowSet.AddAt("a", start)
+	windowSet.AddAt("b", start.Add(5*time.Minute))
+
+	if !windowSet.ContainsAt("a", start.Add(9*time.Minute)) {
+		t.Errorf("expected element to be remembered within window")
+	}
+	if windowSet.ContainsAt("c", start.Add(9*time.Minute)) {
+		t.Errorf("expected element that was not added to not be contained")
+	}
+
+	// At most one bucket (1 minute) after the window, a must have expired, while b remains.
+	if windowSet.ContainsAt("a", start.Add(11*time.Minute)) {
+		t.Errorf("expected element to expire after window")
+	}
+	if !windowSet.ContainsAt("b", start.Add(11*time.Minute)) {
+		t.Errorf("expected later element to be remembered within its window")
+	}
+
+	// Adding again restarts the window.
+	windowSet.AddAt("b", start.Add(12*time.Minute))
+	if !windowSet.ContainsAt("b", start.Add(21*time.Minute)) {
+		t.Errorf("expected re-added element to be remembered within new window")
+	}
+
+	// A gap longer than the whole window expires everything.
+	if windowSet.ContainsAt("b", start.Add(time.Hour)) {
+		t.Errorf("expected all elements to expire after long gap")
+	}
+}
+
+func TestWindowSetRemembersForWholeWindow(t *testing.T) {
+	windowSet := set.NewWindowSet[int](10*time.Second, 3)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := range 100 {
+		addedAt := start.Add(time.Duration(i) * 700 * time.Millisecond)
+		windowSet.AddAt(i, addedAt)
+
+		for j := max(0, i-20); j <= i; j++ {
+			jAddedAt := start.Add(time.Duration(j) * 700 * time.Millisecond)
+			if addedAt.Sub(jAddedAt) < 10*time.Second && !windowSet.ContainsAt(j, addedAt) {
+				t.Fatalf("expected element %d to be remembered %v after adding", j, addedAt.Sub(jAddedAt))
+			}
+		}
+	}
+}